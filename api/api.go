@@ -32,6 +32,7 @@ import (
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -72,6 +73,13 @@ type Options struct {
 	// according to the current active configuration. Alerts returned are
 	// filtered by the arguments provided to the function.
 	GroupFunc func(func(*dispatch.Route) bool, func(*types.Alert, time.Time) bool) (dispatch.AlertGroups, map[model.Fingerprint][]string)
+	// StormStatusFunc returns the storm mode status of the dispatcher. If
+	// nil, the storm mode is not reported.
+	StormStatusFunc func() dispatch.StormStatus
+	// ApproverHeader is the HTTP header holding the user authenticated by a
+	// reverse proxy. If set, silences can only be approved on behalf of
+	// this user. If empty, silences can't be approved.
+	ApproverHeader string
 }
 
 func (o Options) validate() error {
@@ -120,7 +128,9 @@ func New(opts Options) (*API, error) {
 		opts.Alerts,
 		opts.GroupFunc,
 		opts.StatusFunc,
+		opts.StormStatusFunc,
 		opts.Silences,
+		opts.ApproverHeader,
 		log.With(l, "version", "v2"),
 		opts.Registry,
 	)
@@ -190,10 +200,10 @@ func (api *API) Register(r *route.Router, routePrefix string) *http.ServeMux {
 }
 
 // Update config and resolve timeout of each API. APIv2 also needs
-// setAlertStatus to be updated.
-func (api *API) Update(cfg *config.Config, receivers []*notify.Receiver, setAlertStatus func(model.LabelSet)) {
+// setAlertStatus and the time intervals to be updated.
+func (api *API) Update(cfg *config.Config, receivers []*notify.Receiver, timeIntervals map[string][]timeinterval.TimeInterval, setAlertStatus func(model.LabelSet)) {
 	api.v1.Update(cfg)
-	api.v2.Update(cfg, setAlertStatus, receivers)
+	api.v2.Update(cfg, setAlertStatus, receivers, timeIntervals)
 }
 
 func (api *API) limitHandler(h http.Handler) http.Handler {
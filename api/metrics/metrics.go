@@ -20,6 +20,7 @@ type Alerts struct {
 	firing   prometheus.Counter
 	resolved prometheus.Counter
 	invalid  prometheus.Counter
+	dropped  prometheus.Counter
 }
 
 // NewAlerts returns an *Alerts struct for the given API version.
@@ -34,13 +35,19 @@ func NewAlerts(version string, r prometheus.Registerer) *Alerts {
 		Help:        "The total number of received alerts that were invalid.",
 		ConstLabels: prometheus.Labels{"version": version},
 	})
+	numDroppedAlerts := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "alertmanager_alerts_relabel_dropped_total",
+		Help:        "The total number of received alerts that were dropped by relabeling.",
+		ConstLabels: prometheus.Labels{"version": version},
+	})
 	if r != nil {
-		r.MustRegister(numReceivedAlerts, numInvalidAlerts)
+		r.MustRegister(numReceivedAlerts, numInvalidAlerts, numDroppedAlerts)
 	}
 	return &Alerts{
 		firing:   numReceivedAlerts.WithLabelValues("firing"),
 		resolved: numReceivedAlerts.WithLabelValues("resolved"),
 		invalid:  numInvalidAlerts,
+		dropped:  numDroppedAlerts,
 	}
 }
 
@@ -52,3 +59,6 @@ func (a *Alerts) Resolved() prometheus.Counter { return a.resolved }
 
 // Invalid returns a counter of invalid alerts.
 func (a *Alerts) Invalid() prometheus.Counter { return a.invalid }
+
+// Dropped returns a counter of alerts dropped by relabeling.
+func (a *Alerts) Dropped() prometheus.Counter { return a.dropped }
@@ -34,6 +34,7 @@ import (
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
 	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/pkg/relabel"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
@@ -390,6 +391,7 @@ func (api *API) insertAlerts(w http.ResponseWriter, r *http.Request, alerts ...*
 
 	api.mtx.RLock()
 	resolveTimeout := time.Duration(api.config.Global.ResolveTimeout)
+	relabelConfigs := api.config.AlertRelabelConfigsFor(api.silences.OrgId())
 	api.mtx.RUnlock()
 
 	for _, alert := range alerts {
@@ -424,6 +426,14 @@ func (api *API) insertAlerts(w http.ResponseWriter, r *http.Request, alerts ...*
 	for _, a := range alerts {
 		removeEmptyLabels(a.Labels)
 
+		if len(relabelConfigs) > 0 {
+			a.Labels = relabel.Process(a.Labels, relabelConfigs...)
+			if a.Labels == nil {
+				api.m.Dropped().Inc()
+				continue
+			}
+		}
+
 		if err := a.Validate(); err != nil {
 			validationErrs.Add(err)
 			api.m.Invalid().Inc()
@@ -14,6 +14,7 @@
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -40,15 +41,18 @@ import (
 	general_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/general"
 	receiver_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/receiver"
 	silence_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/silence"
+	timeinterval_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/timeinterval"
 
 	"github.com/prometheus/alertmanager/api/metrics"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/pkg/relabel"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -58,15 +62,21 @@ type API struct {
 	alerts         provider.Alerts
 	alertGroups    groupsFn
 	getAlertStatus getAlertStatusFn
+	stormStatus    stormStatusFn
 	uptime         time.Time
+	// approverHeader is the HTTP header holding the user authenticated by
+	// a reverse proxy. If empty, silences can't be approved.
+	approverHeader string
 
-	// mtx protects alertmanagerConfig, setAlertStatus and route.
+	// mtx protects alertmanagerConfig, setAlertStatus, route and
+	// timeIntervals.
 	mtx sync.RWMutex
 	// resolveTimeout represents the default resolve timeout that an alert is
 	// assigned if no end time is specified.
 	alertmanagerConfig *config.Config
 	route              *dispatch.Route
 	setAlertStatus     setAlertStatusFn
+	timeIntervals      map[string][]timeinterval.TimeInterval
 
 	logger log.Logger
 	m      *metrics.Alerts
@@ -79,6 +89,7 @@ type (
 	groupsFn         func(func(*dispatch.Route) bool, func(*types.Alert, time.Time) bool) (dispatch.AlertGroups, map[prometheus_model.Fingerprint][]string)
 	getAlertStatusFn func(prometheus_model.Fingerprint) types.AlertStatus
 	setAlertStatusFn func(prometheus_model.LabelSet)
+	stormStatusFn    func() dispatch.StormStatus
 )
 
 // NewAPI returns a new Alertmanager API v2
@@ -86,15 +97,19 @@ func NewAPI(
 	alerts provider.Alerts,
 	gf groupsFn,
 	sf getAlertStatusFn,
+	ssf stormStatusFn,
 	silences *silence.Silences,
+	approverHeader string,
 	l log.Logger,
 	r prometheus.Registerer,
 ) (*API, error) {
 	api := API{
 		alerts:         alerts,
 		getAlertStatus: sf,
+		stormStatus:    ssf,
 		alertGroups:    gf,
 		silences:       silences,
+		approverHeader: approverHeader,
 		logger:         l,
 		m:              metrics.NewAlerts("v2", r),
 		uptime:         time.Now(),
@@ -118,15 +133,22 @@ func NewAPI(
 		return middleware.Spec("", swaggerSpec.Raw(), swaggerContext.RoutesHandler(b))
 	}
 
+	openAPI.AlertGetAlertInhibitionHandler = alert_ops.GetAlertInhibitionHandlerFunc(api.getAlertInhibitionHandler)
 	openAPI.AlertGetAlertsHandler = alert_ops.GetAlertsHandlerFunc(api.getAlertsHandler)
 	openAPI.AlertPostAlertsHandler = alert_ops.PostAlertsHandlerFunc(api.postAlertsHandler)
 	openAPI.AlertgroupGetAlertGroupsHandler = alertgroup_ops.GetAlertGroupsHandlerFunc(api.getAlertGroupsHandler)
 	openAPI.GeneralGetStatusHandler = general_ops.GetStatusHandlerFunc(api.getStatusHandler)
 	openAPI.ReceiverGetReceiversHandler = receiver_ops.GetReceiversHandlerFunc(api.getReceiversHandler)
+	openAPI.SilenceApproveSilenceHandler = silence_ops.ApproveSilenceHandlerFunc(api.approveSilenceHandler)
 	openAPI.SilenceDeleteSilenceHandler = silence_ops.DeleteSilenceHandlerFunc(api.deleteSilenceHandler)
+	openAPI.SilenceExpireSilencesHandler = silence_ops.ExpireSilencesHandlerFunc(api.expireSilencesHandler)
+	openAPI.SilenceExtendSilencesHandler = silence_ops.ExtendSilencesHandlerFunc(api.extendSilencesHandler)
 	openAPI.SilenceGetSilenceHandler = silence_ops.GetSilenceHandlerFunc(api.getSilenceHandler)
+	openAPI.SilenceGetSilenceHistoryHandler = silence_ops.GetSilenceHistoryHandlerFunc(api.getSilenceHistoryHandler)
 	openAPI.SilenceGetSilencesHandler = silence_ops.GetSilencesHandlerFunc(api.getSilencesHandler)
 	openAPI.SilencePostSilencesHandler = silence_ops.PostSilencesHandlerFunc(api.postSilencesHandler)
+	openAPI.SilencePostSilencesPreviewHandler = silence_ops.PostSilencesPreviewHandlerFunc(api.postSilencesPreviewHandler)
+	openAPI.TimeintervalGetTimeIntervalsHandler = timeinterval_ops.GetTimeIntervalsHandlerFunc(api.getTimeIntervalsHandler)
 
 	handleCORS := cors.Default().Handler
 	api.Handler = handleCORS(setResponseHeaders(openAPI.Serve(nil)))
@@ -152,7 +174,7 @@ func (api *API) requestLogger(req *http.Request) log.Logger {
 }
 
 // Update sets the API struct members that may change between reloads of alertmanager.
-func (api *API) Update(cfg *config.Config, setAlertStatus setAlertStatusFn, receivers []*notify.Receiver) {
+func (api *API) Update(cfg *config.Config, setAlertStatus setAlertStatusFn, receivers []*notify.Receiver, timeIntervals map[string][]timeinterval.TimeInterval) {
 	api.mtx.Lock()
 	defer api.mtx.Unlock()
 
@@ -160,6 +182,7 @@ func (api *API) Update(cfg *config.Config, setAlertStatus setAlertStatusFn, rece
 	api.route = dispatch.NewRoute(cfg.Route, nil)
 	api.setAlertStatus = setAlertStatus
 	api.receivers = receivers
+	api.timeIntervals = timeIntervals
 }
 
 func (api *API) getStatusHandler(params general_ops.GetStatusParams) middleware.Responder {
@@ -190,6 +213,17 @@ func (api *API) getStatusHandler(params general_ops.GetStatusParams) middleware.
 		},
 	}
 
+	if api.stormStatus != nil {
+		storm := api.stormStatus()
+		resp.StormMode = &open_api_models.StormModeStatus{
+			Active: &storm.Active,
+		}
+		if !storm.Since.IsZero() {
+			since := strfmt.DateTime(storm.Since)
+			resp.StormMode.Since = &since
+		}
+	}
+
 	return general_ops.NewGetStatusOK().WithPayload(&resp)
 }
 
@@ -234,6 +268,47 @@ func (api *API) getReceiversHandler(params receiver_ops.GetReceiversParams) midd
 	return receiver_ops.NewGetReceiversOK().WithPayload(receivers)
 }
 
+func (api *API) getTimeIntervalsHandler(params timeinterval_ops.GetTimeIntervalsParams) middleware.Responder {
+	at := time.Now()
+	if params.At != nil {
+		at = time.Time(*params.At)
+	}
+	// Transitions are searched up to a year ahead, which covers intervals
+	// recurring on any of weekdays, days of month and months.
+	until := at.AddDate(1, 0, 0)
+
+	api.mtx.RLock()
+	timeIntervals := api.timeIntervals
+	api.mtx.RUnlock()
+
+	names := make([]string, 0, len(timeIntervals))
+	for name := range timeIntervals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := make([]*open_api_models.TimeIntervalStatus, 0, len(names))
+	for _, name := range names {
+		name := name
+		tis := timeIntervals[name]
+		active := timeinterval.Contains(tis, at)
+		transitions := make([]*open_api_models.TimeIntervalTransition, 0, *params.Transitions)
+		for _, t := range timeinterval.Transitions(tis, at, until, int(*params.Transitions)) {
+			t := t
+			transitions = append(transitions, &open_api_models.TimeIntervalTransition{
+				Time:   (*strfmt.DateTime)(&t.Time),
+				Active: &t.Active,
+			})
+		}
+		res = append(res, &open_api_models.TimeIntervalStatus{
+			Name:        &name,
+			Active:      &active,
+			Transitions: transitions,
+		})
+	}
+	return timeinterval_ops.NewGetTimeIntervalsOK().WithPayload(res)
+}
+
 func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Responder {
 	var (
 		receiverFilter *regexp.Regexp
@@ -266,7 +341,7 @@ func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Re
 	alerts := api.alerts.GetPending()
 	defer alerts.Close()
 
-	alertFilter := api.alertFilter(matchers, *params.Silenced, *params.Inhibited, *params.Active)
+	alertFilter := api.alertFilter(matchers, *params.Silenced, *params.Inhibited, *params.Active, *params.Flapping)
 	now := time.Now()
 
 	api.mtx.RLock()
@@ -309,6 +384,50 @@ func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Re
 	return alert_ops.NewGetAlertsOK().WithPayload(res)
 }
 
+func (api *API) getAlertInhibitionHandler(params alert_ops.GetAlertInhibitionParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	fp, err := prometheus_model.ParseFingerprint(params.Fingerprint)
+	if err != nil {
+		level.Debug(logger).Log("msg", "Failed to parse fingerprint", "err", err)
+		return alert_ops.NewGetAlertInhibitionBadRequest().WithPayload(
+			fmt.Sprintf("failed to parse fingerprint: %v", err.Error()),
+		)
+	}
+
+	a, err := api.alerts.Get(fp)
+	if errors.Is(err, provider.ErrNotFound) {
+		return alert_ops.NewGetAlertInhibitionNotFound()
+	}
+	if err != nil {
+		level.Error(logger).Log("msg", "Failed to get alert", "err", err)
+		return alert_ops.NewGetAlertInhibitionInternalServerError().WithPayload(err.Error())
+	}
+
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	// Set the alert's current status to explain its current inhibition.
+	api.setAlertStatus(a.Labels)
+	inhibition := api.getAlertStatus(fp).Inhibition
+
+	inhibited := inhibition != nil
+	res := &open_api_models.AlertInhibition{Inhibited: &inhibited}
+	if inhibited {
+		source := inhibition.Source
+		routes := api.route.Match(source.Labels)
+		receivers := make([]string, 0, len(routes))
+		for _, r := range routes {
+			receivers = append(receivers, r.RouteOpts.Receiver)
+		}
+		res.RuleIndex = int64(inhibition.RuleIndex)
+		res.Equal = ModelLabelSetToAPILabelSet(inhibition.Equal)
+		res.DependencyPath = inhibition.DependencyPath
+		res.SourceAlert = AlertToOpenAPIAlert(source, api.getAlertStatus(source.Fingerprint()), receivers)
+	}
+	return alert_ops.NewGetAlertInhibitionOK().WithPayload(res)
+}
+
 func (api *API) postAlertsHandler(params alert_ops.PostAlertsParams) middleware.Responder {
 	logger := api.requestLogger(params.HTTPRequest)
 
@@ -317,6 +436,7 @@ func (api *API) postAlertsHandler(params alert_ops.PostAlertsParams) middleware.
 
 	api.mtx.RLock()
 	resolveTimeout := time.Duration(api.alertmanagerConfig.Global.ResolveTimeout)
+	relabelConfigs := api.alertmanagerConfig.AlertRelabelConfigsFor(api.silences.OrgId())
 	api.mtx.RUnlock()
 
 	for _, alert := range alerts {
@@ -351,6 +471,14 @@ func (api *API) postAlertsHandler(params alert_ops.PostAlertsParams) middleware.
 	for _, a := range alerts {
 		removeEmptyLabels(a.Labels)
 
+		if len(relabelConfigs) > 0 {
+			a.Labels = relabel.Process(a.Labels, relabelConfigs...)
+			if a.Labels == nil {
+				api.m.Dropped().Inc()
+				continue
+			}
+		}
+
 		if err := a.Validate(); err != nil {
 			validationErrs.Add(err)
 			api.m.Invalid().Inc()
@@ -403,7 +531,7 @@ func (api *API) getAlertGroupsHandler(params alertgroup_ops.GetAlertGroupsParams
 		}
 	}(receiverFilter)
 
-	af := api.alertFilter(matchers, *params.Silenced, *params.Inhibited, *params.Active)
+	af := api.alertFilter(matchers, *params.Silenced, *params.Inhibited, *params.Active, *params.Flapping)
 	alertGroups, allReceivers := api.alertGroups(rf, af)
 
 	res := make(open_api_models.AlertGroups, 0, len(alertGroups))
@@ -428,7 +556,7 @@ func (api *API) getAlertGroupsHandler(params alertgroup_ops.GetAlertGroupsParams
 	return alertgroup_ops.NewGetAlertGroupsOK().WithPayload(res)
 }
 
-func (api *API) alertFilter(matchers []*labels.Matcher, silenced, inhibited, active bool) func(a *types.Alert, now time.Time) bool {
+func (api *API) alertFilter(matchers []*labels.Matcher, silenced, inhibited, active, flapping bool) func(a *types.Alert, now time.Time) bool {
 	return func(a *types.Alert, now time.Time) bool {
 		if !a.EndsAt.IsZero() && a.EndsAt.Before(now) {
 			return false
@@ -452,6 +580,10 @@ func (api *API) alertFilter(matchers []*labels.Matcher, silenced, inhibited, act
 			return false
 		}
 
+		if !flapping && status.State == types.AlertStateFlapping {
+			return false
+		}
+
 		return alertMatchesFilterLabels(&a.Alert, matchers)
 	}
 }
@@ -547,15 +679,17 @@ func (api *API) getSilencesHandler(params silence_ops.GetSilencesParams) middlew
 }
 
 var silenceStateOrder = map[types.SilenceState]int{
-	types.SilenceStateActive:  1,
-	types.SilenceStatePending: 2,
-	types.SilenceStateExpired: 3,
+	types.SilenceStateActive:          1,
+	types.SilenceStatePendingApproval: 2,
+	types.SilenceStatePending:         3,
+	types.SilenceStateExpired:         4,
 }
 
-// SortSilences sorts first according to the state "active, pending, expired"
-// then by end time or start time depending on the state.
+// SortSilences sorts first according to the state "active, pendingApproval,
+// pending, expired" then by end time or start time depending on the state.
 // active silences should show the next to expire first
-// pending silences are ordered based on which one starts next
+// pending silences and those pending approval are ordered based on which one
+// starts next
 // expired are ordered based on which one expired most recently
 func SortSilences(sils open_api_models.GettableSilences) {
 	sort.Slice(sils, func(i, j int) bool {
@@ -569,7 +703,7 @@ func SortSilences(sils open_api_models.GettableSilences) {
 			endsAt1 := time.Unix(*sils[i].Silence.EndsAt, 0)
 			endsAt2 := time.Unix(*sils[j].Silence.EndsAt, 0)
 			return endsAt1.Before(endsAt2)
-		case types.SilenceStatePending:
+		case types.SilenceStatePending, types.SilenceStatePendingApproval:
 			startsAt1 := time.Unix(*sils[i].Silence.StartsAt, 0)
 			startsAt2 := time.Unix(*sils[j].Silence.StartsAt, 0)
 			return startsAt1.Before(startsAt2)
@@ -632,6 +766,31 @@ func (api *API) getSilenceHandler(params silence_ops.GetSilenceParams) middlewar
 	return silence_ops.NewGetSilenceOK().WithPayload(&sil)
 }
 
+func (api *API) getSilenceHistoryHandler(params silence_ops.GetSilenceHistoryParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	psils, err := api.silences.History(params.HTTPRequest.Context(), params.SilenceID.String())
+	if err != nil {
+		if err == silence.ErrNotFound {
+			return silence_ops.NewGetSilenceHistoryNotFound()
+		}
+		level.Error(logger).Log("msg", "Failed to get silence history", "err", err, "id", params.SilenceID.String())
+		return silence_ops.NewGetSilenceHistoryInternalServerError().WithPayload(err.Error())
+	}
+
+	sils := make(open_api_models.GettableSilences, 0, len(psils))
+	for _, ps := range psils {
+		sil, err := GettableSilenceFromProto(ps)
+		if err != nil {
+			level.Error(logger).Log("msg", "Failed to convert unmarshal from proto", "err", err)
+			return silence_ops.NewGetSilenceHistoryInternalServerError().WithPayload(err.Error())
+		}
+		sils = append(sils, &sil)
+	}
+
+	return silence_ops.NewGetSilenceHistoryOK().WithPayload(sils)
+}
+
 func (api *API) deleteSilenceHandler(params silence_ops.DeleteSilenceParams) middleware.Responder {
 	logger := api.requestLogger(params.HTTPRequest)
 
@@ -646,6 +805,37 @@ func (api *API) deleteSilenceHandler(params silence_ops.DeleteSilenceParams) mid
 	return silence_ops.NewDeleteSilenceOK()
 }
 
+func (api *API) approveSilenceHandler(params silence_ops.ApproveSilenceParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	// Without an authenticated user anyone could approve on behalf of
+	// anyone else, which defeats the approval.
+	if api.approverHeader == "" {
+		return silence_ops.NewApproveSilenceForbidden().WithPayload("approvals require an approver header to be configured")
+	}
+	user := params.HTTPRequest.Header.Get(api.approverHeader)
+	if user == "" {
+		return silence_ops.NewApproveSilenceBadRequest().WithPayload(fmt.Sprintf("missing %s header", api.approverHeader))
+	}
+	if user != params.ApprovedBy {
+		return silence_ops.NewApproveSilenceBadRequest().WithPayload(fmt.Sprintf("approver %q doesn't match the authenticated user %q", params.ApprovedBy, user))
+	}
+
+	sid := params.SilenceID.String()
+	if err := api.silences.Approve(params.HTTPRequest.Context(), sid, params.ApprovedBy); err != nil {
+		switch err {
+		case silence.ErrNotFound:
+			return silence_ops.NewApproveSilenceNotFound()
+		case silence.ErrNotPendingApproval, silence.ErrSelfApproval, silence.ErrApproverMissing:
+			level.Debug(logger).Log("msg", "Failed to approve silence", "err", err)
+			return silence_ops.NewApproveSilenceBadRequest().WithPayload(err.Error())
+		}
+		level.Error(logger).Log("msg", "Failed to approve silence", "err", err)
+		return silence_ops.NewApproveSilenceInternalServerError().WithPayload(err.Error())
+	}
+	return silence_ops.NewApproveSilenceOK()
+}
+
 func (api *API) postSilencesHandler(params silence_ops.PostSilencesParams) middleware.Responder {
 	logger := api.requestLogger(params.HTTPRequest)
 
@@ -683,6 +873,210 @@ func (api *API) postSilencesHandler(params silence_ops.PostSilencesParams) middl
 	})
 }
 
+func (api *API) postSilencesPreviewHandler(params silence_ops.PostSilencesPreviewParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	sil, err := PostableSilenceToProto(params.Silence)
+	if err != nil {
+		level.Debug(logger).Log("msg", "Failed to marshal silence to proto", "err", err)
+		return silence_ops.NewPostSilencesPreviewBadRequest().WithPayload(
+			fmt.Sprintf("failed to convert API silence to internal silence: %v", err.Error()),
+		)
+	}
+
+	matchers, err := silence.CompileMatchers(sil)
+	if err != nil {
+		level.Debug(logger).Log("msg", "Failed to compile silence matchers", "err", err)
+		return silence_ops.NewPostSilencesPreviewBadRequest().WithPayload(err.Error())
+	}
+
+	alerts := api.alerts.GetPending()
+	defer alerts.Close()
+
+	var (
+		ctx    = params.HTTPRequest.Context()
+		now    = time.Now()
+		groups = map[string]*open_api_models.AlertGroup{}
+	)
+
+	api.mtx.RLock()
+	for a := range alerts.Next() {
+		if err = alerts.Err(); err != nil {
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		if a.ResolvedAt(now) || !matchers.Matches(a.Labels) {
+			continue
+		}
+
+		routes := api.route.Match(a.Labels)
+		receivers := make([]string, 0, len(routes))
+		for _, r := range routes {
+			receivers = append(receivers, r.RouteOpts.Receiver)
+		}
+
+		api.setAlertStatus(a.Labels)
+		alert := AlertToOpenAPIAlert(a, api.getAlertStatus(a.Fingerprint()), receivers)
+
+		for _, r := range routes {
+			if len(sil.Receivers) > 0 && !containsString(sil.Receivers, r.RouteOpts.Receiver) {
+				// The silence does not mute the alert for this receiver.
+				continue
+			}
+			groupLabels := routeGroupLabels(a, r)
+			key := r.Key() + groupLabels.Fingerprint().String()
+			ag, ok := groups[key]
+			if !ok {
+				receiver := r.RouteOpts.Receiver
+				ag = &open_api_models.AlertGroup{
+					Receiver: &open_api_models.Receiver{Name: &receiver},
+					Labels:   ModelLabelSetToAPILabelSet(groupLabels),
+					Alerts:   []*open_api_models.GettableAlert{},
+				}
+				groups[key] = ag
+			}
+			ag.Alerts = append(ag.Alerts, alert)
+		}
+	}
+	api.mtx.RUnlock()
+
+	if err != nil {
+		level.Error(logger).Log("msg", "Failed to preview silence", "err", err)
+		return silence_ops.NewPostSilencesPreviewInternalServerError().WithPayload(err.Error())
+	}
+
+	res := make(open_api_models.AlertGroups, 0, len(groups))
+	for _, ag := range groups {
+		sort.Slice(ag.Alerts, func(i, j int) bool {
+			return *ag.Alerts[i].Fingerprint < *ag.Alerts[j].Fingerprint
+		})
+		res = append(res, ag)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if *res[i].Receiver.Name != *res[j].Receiver.Name {
+			return *res[i].Receiver.Name < *res[j].Receiver.Name
+		}
+		return APILabelSetToModelLabelSet(res[i].Labels).String() < APILabelSetToModelLabelSet(res[j].Labels).String()
+	})
+
+	return silence_ops.NewPostSilencesPreviewOK().WithPayload(res)
+}
+
+// routeGroupLabels returns the labels of the alert by which the route groups
+// it into an aggregation group.
+func routeGroupLabels(a *types.Alert, r *dispatch.Route) prometheus_model.LabelSet {
+	groupLabels := prometheus_model.LabelSet{}
+	for ln, lv := range a.Labels {
+		if _, ok := r.RouteOpts.GroupBy[ln]; ok || r.RouteOpts.GroupByAll {
+			groupLabels[ln] = lv
+		}
+	}
+	return groupLabels
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (api *API) expireSilencesHandler(params silence_ops.ExpireSilencesParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	qparams, err := silenceFilterParams(params.Filter)
+	if err != nil {
+		level.Debug(logger).Log("msg", "Failed to parse silence filter", "err", err)
+		return silence_ops.NewExpireSilencesBadRequest().WithPayload(err.Error())
+	}
+
+	ids, err := api.silences.ExpireAll(params.HTTPRequest.Context(), qparams...)
+	if err != nil {
+		level.Error(logger).Log("msg", "Failed to expire silences", "err", err)
+		return silence_ops.NewExpireSilencesInternalServerError().WithPayload(err.Error())
+	}
+	return silence_ops.NewExpireSilencesOK().WithPayload(ids)
+}
+
+func (api *API) extendSilencesHandler(params silence_ops.ExtendSilencesParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	d, err := prometheus_model.ParseDuration(params.Duration)
+	if err != nil {
+		level.Debug(logger).Log("msg", "Failed to parse duration", "err", err)
+		return silence_ops.NewExtendSilencesBadRequest().WithPayload(
+			fmt.Sprintf("failed to parse duration param: %v", err.Error()),
+		)
+	}
+	if d <= 0 {
+		return silence_ops.NewExtendSilencesBadRequest().WithPayload("duration must be positive")
+	}
+
+	qparams, err := silenceFilterParams(params.Filter)
+	if err != nil {
+		level.Debug(logger).Log("msg", "Failed to parse silence filter", "err", err)
+		return silence_ops.NewExtendSilencesBadRequest().WithPayload(err.Error())
+	}
+
+	ids, err := api.silences.ExtendAll(params.HTTPRequest.Context(), time.Duration(d), qparams...)
+	if err != nil {
+		level.Error(logger).Log("msg", "Failed to extend silences", "err", err)
+		return silence_ops.NewExtendSilencesInternalServerError().WithPayload(err.Error())
+	}
+	return silence_ops.NewExtendSilencesOK().WithPayload(ids)
+}
+
+// silenceFilterParams converts the filter of bulk silence operations to
+// silence query parameters. A filter without matchers, author or comment is
+// rejected so that not all silences are changed by accident.
+func silenceFilterParams(f *open_api_models.SilenceFilter) ([]silence.QueryParam, error) {
+	var params []silence.QueryParam
+	if len(f.Matchers) > 0 {
+		ms := make([]*labels.Matcher, 0, len(f.Matchers))
+		for _, m := range f.Matchers {
+			mt := labels.MatchEqual
+			switch {
+			case m.IsEqual != nil && !*m.IsEqual && m.IsRegex != nil && *m.IsRegex:
+				mt = labels.MatchNotRegexp
+			case m.IsEqual != nil && !*m.IsEqual:
+				mt = labels.MatchNotEqual
+			case m.IsRegex != nil && *m.IsRegex:
+				mt = labels.MatchRegexp
+			}
+			matcher, err := labels.NewMatcher(mt, *m.Name, *m.Value)
+			if err != nil {
+				return nil, err
+			}
+			ms = append(ms, matcher)
+		}
+		params = append(params, silence.QMatchers(ms...))
+	}
+	if f.CreatedBy != "" {
+		params = append(params, silence.QCreatedBy(f.CreatedBy))
+	}
+	if f.Comment != "" {
+		if _, err := regexp.Compile(f.Comment); err != nil {
+			return nil, fmt.Errorf("invalid comment pattern: %w", err)
+		}
+		params = append(params, silence.QComment(f.Comment))
+	}
+	if len(params) == 0 {
+		return nil, errors.New("filter requires matchers, an author or a comment")
+	}
+	if len(f.State) > 0 {
+		states := make([]types.SilenceState, 0, len(f.State))
+		for _, st := range f.State {
+			states = append(states, types.SilenceState(st))
+		}
+		params = append(params, silence.QState(states...))
+	}
+	return params, nil
+}
+
 func parseFilter(filter []string) ([]*labels.Matcher, error) {
 	matchers := make([]*labels.Matcher, 0, len(filter))
 	for _, matcherString := range filter {
@@ -33,10 +33,13 @@ import (
 	general_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/general"
 	receiver_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/receiver"
 	silence_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/silence"
+	timeinterval_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/timeinterval"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/test/redistest"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 
 	"github.com/go-kit/log"
@@ -84,7 +87,15 @@ var (
 )
 
 func newSilences(t *testing.T) *silence.Silences {
-	silences, err := silence.New(silence.Options{})
+	return newSilencesWithPolicy(t, silence.Policy{})
+}
+
+func newSilencesWithPolicy(t *testing.T, policy silence.Policy) *silence.Silences {
+	silences, err := silence.New(silence.Options{
+		OrgId:  1,
+		Rdb:    redistest.Run(t).Client(t),
+		Policy: policy,
+	})
 	require.NoError(t, err)
 
 	return silences
@@ -212,6 +223,62 @@ func TestDeleteSilenceHandler(t *testing.T) {
 	}
 }
 
+func TestApproveSilenceHandler(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	for i, tc := range []struct {
+		approverHeader string
+		user           string
+		approvedBy     string
+		expectedCode   int
+	}{
+		// Approvers can't be verified without an approver header.
+		{approvedBy: "bob", expectedCode: 403},
+		{approverHeader: "X-Forwarded-User", user: "bob", approvedBy: "bob", expectedCode: 200},
+		// Authors can't approve their own silences.
+		{approverHeader: "X-Forwarded-User", user: "alice", approvedBy: "alice", expectedCode: 400},
+		{approverHeader: "X-Forwarded-User", user: "mallory", approvedBy: "bob", expectedCode: 400},
+		{approverHeader: "X-Forwarded-User", approvedBy: "bob", expectedCode: 400},
+	} {
+		silences := newSilencesWithPolicy(t, silence.Policy{MaxDuration: time.Hour})
+		sid, err := silences.Set(ctx, &silencepb.Silence{
+			Matchers:  []*silencepb.Matcher{{Type: silencepb.Matcher_EQUAL, Name: "a", Pattern: "b"}},
+			StartsAt:  now,
+			EndsAt:    now.Add(2 * time.Hour),
+			CreatedBy: "alice",
+		})
+		require.NoError(t, err)
+
+		api := API{
+			uptime:         time.Now(),
+			silences:       silences,
+			approverHeader: tc.approverHeader,
+			logger:         log.NewNopLogger(),
+		}
+
+		r, err := http.NewRequest("POST", "/api/v2/silence/"+sid+"/approve", nil)
+		require.NoError(t, err)
+		if tc.user != "" {
+			r.Header.Set(tc.approverHeader, tc.user)
+		}
+
+		w := httptest.NewRecorder()
+		responder := api.approveSilenceHandler(silence_ops.ApproveSilenceParams{
+			SilenceID:   strfmt.UUID(sid),
+			ApprovedBy:  tc.approvedBy,
+			HTTPRequest: r,
+		})
+		responder.WriteResponse(w, runtime.JSONProducer())
+		body, _ := io.ReadAll(w.Result().Body)
+		require.Equal(t, tc.expectedCode, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+
+		sil, err := silences.QueryOne(silence.QIDs(sid))
+		require.NoError(t, err)
+		require.Equal(t, tc.expectedCode == 200, !sil.Approval.Pending(), fmt.Sprintf("test case: %d", i))
+	}
+}
+
 func TestPostSilencesHandler(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -512,3 +579,66 @@ receivers:
 		require.Equal(t, tc.body, string(body))
 	}
 }
+
+func TestGetTimeIntervalsHandler(t *testing.T) {
+	in := `
+route:
+    receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+time_intervals:
+- name: business-hours
+  time_intervals:
+  - weekdays: ['monday:friday']
+    times:
+    - start_time: '09:00'
+      end_time: '17:00'
+    location: 'Europe/Berlin'
+`
+	cfg, err := config.Load(in)
+	require.NoError(t, err)
+	api := API{
+		uptime:             time.Now(),
+		logger:             log.NewNopLogger(),
+		alertmanagerConfig: cfg,
+		timeIntervals: map[string][]timeinterval.TimeInterval{
+			"business-hours": cfg.TimeIntervals[0].TimeIntervals,
+		},
+	}
+
+	for _, tc := range []struct {
+		at   string
+		body string
+	}{
+		{
+			"2024-01-05T16:30:00Z",
+			`[{"active":false,"name":"business-hours","transitions":[{"active":true,"time":"2024-01-08T09:00:00.000+01:00"},{"active":false,"time":"2024-01-08T17:00:00.000+01:00"}]}]`,
+		},
+		{
+			"2024-01-08T10:00:00Z",
+			`[{"active":true,"name":"business-hours","transitions":[{"active":false,"time":"2024-01-08T17:00:00.000+01:00"},{"active":true,"time":"2024-01-09T09:00:00.000+01:00"}]}]`,
+		},
+	} {
+		r, err := http.NewRequest("GET", "/api/v2/timeintervals", nil)
+		require.NoError(t, err)
+
+		at, err := strfmt.ParseDateTime(tc.at)
+		require.NoError(t, err)
+		transitions := int64(2)
+
+		w := httptest.NewRecorder()
+		p := runtime.JSONProducer()
+		responder := api.getTimeIntervalsHandler(timeinterval_ops.GetTimeIntervalsParams{
+			HTTPRequest: r,
+			At:          &at,
+			Transitions: &transitions,
+		})
+		responder.WriteResponse(w, p)
+		body, _ := io.ReadAll(w.Result().Body)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, tc.body, string(body))
+	}
+}
@@ -44,6 +44,8 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	GetAlertInhibition(params *GetAlertInhibitionParams, opts ...ClientOption) (*GetAlertInhibitionOK, error)
+
 	GetAlerts(params *GetAlertsParams, opts ...ClientOption) (*GetAlertsOK, error)
 
 	PostAlerts(params *PostAlertsParams, opts ...ClientOption) (*PostAlertsOK, error)
@@ -51,6 +53,44 @@ type ClientService interface {
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+GetAlertInhibition Explain why an alert is inhibited
+*/
+func (a *Client) GetAlertInhibition(params *GetAlertInhibitionParams, opts ...ClientOption) (*GetAlertInhibitionOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetAlertInhibitionParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getAlertInhibition",
+		Method:             "GET",
+		PathPattern:        "/alerts/{fingerprint}/inhibition",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetAlertInhibitionReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetAlertInhibitionOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getAlertInhibition: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetAlerts Get a list of alerts
 */
@@ -90,6 +90,14 @@ type GetAlertsParams struct {
 	*/
 	Filter []string
 
+	/* Flapping.
+
+	   Show flapping alerts
+
+	   Default: true
+	*/
+	Flapping *bool
+
 	/* Inhibited.
 
 	   Show inhibited alerts
@@ -140,6 +148,8 @@ func (o *GetAlertsParams) SetDefaults() {
 	var (
 		activeDefault = bool(true)
 
+		flappingDefault = bool(true)
+
 		inhibitedDefault = bool(true)
 
 		silencedDefault = bool(true)
@@ -149,6 +159,7 @@ func (o *GetAlertsParams) SetDefaults() {
 
 	val := GetAlertsParams{
 		Active:      &activeDefault,
+		Flapping:    &flappingDefault,
 		Inhibited:   &inhibitedDefault,
 		Silenced:    &silencedDefault,
 		Unprocessed: &unprocessedDefault,
@@ -215,6 +226,17 @@ func (o *GetAlertsParams) SetFilter(filter []string) {
 	o.Filter = filter
 }
 
+// WithFlapping adds the flapping to the get alerts params
+func (o *GetAlertsParams) WithFlapping(flapping *bool) *GetAlertsParams {
+	o.SetFlapping(flapping)
+	return o
+}
+
+// SetFlapping adds the flapping to the get alerts params
+func (o *GetAlertsParams) SetFlapping(flapping *bool) {
+	o.Flapping = flapping
+}
+
 // WithInhibited adds the inhibited to the get alerts params
 func (o *GetAlertsParams) WithInhibited(inhibited *bool) *GetAlertsParams {
 	o.SetInhibited(inhibited)
@@ -295,6 +317,23 @@ func (o *GetAlertsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Reg
 		}
 	}
 
+	if o.Flapping != nil {
+
+		// query param flapping
+		var qrFlapping bool
+
+		if o.Flapping != nil {
+			qrFlapping = *o.Flapping
+		}
+		qFlapping := swag.FormatBool(qrFlapping)
+		if qFlapping != "" {
+
+			if err := r.SetQueryParam("flapping", qFlapping); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.Inhibited != nil {
 
 		// query param inhibited
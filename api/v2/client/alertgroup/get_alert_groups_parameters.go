@@ -90,6 +90,14 @@ type GetAlertGroupsParams struct {
 	*/
 	Filter []string
 
+	/* Flapping.
+
+	   Show flapping alerts
+
+	   Default: true
+	*/
+	Flapping *bool
+
 	/* Inhibited.
 
 	   Show inhibited alerts
@@ -132,6 +140,8 @@ func (o *GetAlertGroupsParams) SetDefaults() {
 	var (
 		activeDefault = bool(true)
 
+		flappingDefault = bool(true)
+
 		inhibitedDefault = bool(true)
 
 		silencedDefault = bool(true)
@@ -139,6 +149,7 @@ func (o *GetAlertGroupsParams) SetDefaults() {
 
 	val := GetAlertGroupsParams{
 		Active:    &activeDefault,
+		Flapping:  &flappingDefault,
 		Inhibited: &inhibitedDefault,
 		Silenced:  &silencedDefault,
 	}
@@ -204,6 +215,17 @@ func (o *GetAlertGroupsParams) SetFilter(filter []string) {
 	o.Filter = filter
 }
 
+// WithFlapping adds the flapping to the get alert groups params
+func (o *GetAlertGroupsParams) WithFlapping(flapping *bool) *GetAlertGroupsParams {
+	o.SetFlapping(flapping)
+	return o
+}
+
+// SetFlapping adds the flapping to the get alert groups params
+func (o *GetAlertGroupsParams) SetFlapping(flapping *bool) {
+	o.Flapping = flapping
+}
+
 // WithInhibited adds the inhibited to the get alert groups params
 func (o *GetAlertGroupsParams) WithInhibited(inhibited *bool) *GetAlertGroupsParams {
 	o.SetInhibited(inhibited)
@@ -273,6 +295,23 @@ func (o *GetAlertGroupsParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 		}
 	}
 
+	if o.Flapping != nil {
+
+		// query param flapping
+		var qrFlapping bool
+
+		if o.Flapping != nil {
+			qrFlapping = *o.Flapping
+		}
+		qFlapping := swag.FormatBool(qrFlapping)
+		if qFlapping != "" {
+
+			if err := r.SetQueryParam("flapping", qFlapping); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.Inhibited != nil {
 
 		// query param inhibited
@@ -44,17 +44,65 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	ApproveSilence(params *ApproveSilenceParams, opts ...ClientOption) (*ApproveSilenceOK, error)
+
 	DeleteSilence(params *DeleteSilenceParams, opts ...ClientOption) (*DeleteSilenceOK, error)
 
+	ExpireSilences(params *ExpireSilencesParams, opts ...ClientOption) (*ExpireSilencesOK, error)
+
+	ExtendSilences(params *ExtendSilencesParams, opts ...ClientOption) (*ExtendSilencesOK, error)
+
 	GetSilence(params *GetSilenceParams, opts ...ClientOption) (*GetSilenceOK, error)
 
+	GetSilenceHistory(params *GetSilenceHistoryParams, opts ...ClientOption) (*GetSilenceHistoryOK, error)
+
 	GetSilences(params *GetSilencesParams, opts ...ClientOption) (*GetSilencesOK, error)
 
 	PostSilences(params *PostSilencesParams, opts ...ClientOption) (*PostSilencesOK, error)
 
+	PostSilencesPreview(params *PostSilencesPreviewParams, opts ...ClientOption) (*PostSilencesPreviewOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+ApproveSilence Approve a silence which is pending approval
+*/
+func (a *Client) ApproveSilence(params *ApproveSilenceParams, opts ...ClientOption) (*ApproveSilenceOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewApproveSilenceParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "approveSilence",
+		Method:             "POST",
+		PathPattern:        "/silence/{silenceID}/approve",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ApproveSilenceReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ApproveSilenceOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for approveSilence: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 DeleteSilence Delete a silence by its ID
 */
@@ -93,6 +141,82 @@ func (a *Client) DeleteSilence(params *DeleteSilenceParams, opts ...ClientOption
 	panic(msg)
 }
 
+/*
+ExpireSilences Expire all silences matching a filter at once
+*/
+func (a *Client) ExpireSilences(params *ExpireSilencesParams, opts ...ClientOption) (*ExpireSilencesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExpireSilencesParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "expireSilences",
+		Method:             "POST",
+		PathPattern:        "/silences/expire",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ExpireSilencesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExpireSilencesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for expireSilences: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+ExtendSilences Extend all silences matching a filter at once
+*/
+func (a *Client) ExtendSilences(params *ExtendSilencesParams, opts ...ClientOption) (*ExtendSilencesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExtendSilencesParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "extendSilences",
+		Method:             "POST",
+		PathPattern:        "/silences/extend",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ExtendSilencesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExtendSilencesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for extendSilences: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetSilence Get a silence by its ID
 */
@@ -131,6 +255,44 @@ func (a *Client) GetSilence(params *GetSilenceParams, opts ...ClientOption) (*Ge
 	panic(msg)
 }
 
+/*
+GetSilenceHistory Get the prior versions of a silence by its ID
+*/
+func (a *Client) GetSilenceHistory(params *GetSilenceHistoryParams, opts ...ClientOption) (*GetSilenceHistoryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetSilenceHistoryParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getSilenceHistory",
+		Method:             "GET",
+		PathPattern:        "/silence/{silenceID}/history",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetSilenceHistoryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetSilenceHistoryOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getSilenceHistory: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetSilences Get a list of silences
 */
@@ -207,6 +369,44 @@ func (a *Client) PostSilences(params *PostSilencesParams, opts ...ClientOption)
 	panic(msg)
 }
 
+/*
+PostSilencesPreview Preview the alerts a silence would mute
+*/
+func (a *Client) PostSilencesPreview(params *PostSilencesPreviewParams, opts ...ClientOption) (*PostSilencesPreviewOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostSilencesPreviewParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "postSilencesPreview",
+		Method:             "POST",
+		PathPattern:        "/silences/preview",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostSilencesPreviewReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostSilencesPreviewOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for postSilencesPreview: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
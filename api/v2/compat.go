@@ -14,6 +14,7 @@
 package v2
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 
 	open_api_models "github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -31,13 +33,19 @@ func GettableSilenceFromProto(s *silencepb.Silence) (open_api_models.GettableSil
 	end := s.EndsAt.Unix()
 	updated := strfmt.DateTime(s.UpdatedAt)
 	state := string(types.CalcSilenceState(s.StartsAt, s.EndsAt))
+	if state != string(types.SilenceStateExpired) && s.Approval.Pending() {
+		state = string(types.SilenceStatePendingApproval)
+	}
 	sil := open_api_models.GettableSilence{
 		Silence: open_api_models.Silence{
-			Name:      s.Name,
-			StartsAt:  &start,
-			EndsAt:    &end,
-			Comment:   &s.Comment,
-			CreatedBy: &s.CreatedBy,
+			Name:            s.Name,
+			StartsAt:        &start,
+			EndsAt:          &end,
+			Comment:         &s.Comment,
+			CreatedBy:       &s.CreatedBy,
+			ExpireOnResolve: s.ExpireOnResolve,
+			Receivers:       s.Receivers,
+			Integrations:    s.Integrations,
 		},
 		ID:        &s.Id,
 		UpdatedAt: &updated,
@@ -45,6 +53,20 @@ func GettableSilenceFromProto(s *silencepb.Silence) (open_api_models.GettableSil
 			State: &state,
 		},
 	}
+	if s.Approval != nil {
+		sil.Status.ApprovalReasons = s.Approval.Reasons
+		sil.Status.ApprovedBy = s.Approval.ApprovedBy
+	}
+	if s.ResolveState != nil && !s.ResolveState.ResolvedAt.IsZero() {
+		sil.Status.ResolvedAt = strfmt.DateTime(s.ResolveState.ResolvedAt)
+	}
+	if s.Recurrence != nil {
+		rec, err := recurrenceFromProto(s.Recurrence)
+		if err != nil {
+			return sil, fmt.Errorf("invalid recurrence in silence '%v': %w", s.Id, err)
+		}
+		sil.Recurrence = rec
+	}
 
 	for _, m := range s.Matchers {
 		matcher := &open_api_models.Matcher{
@@ -88,6 +110,17 @@ func PostableSilenceToProto(s *open_api_models.PostableSilence) (*silencepb.Sile
 		Comment:   *s.Comment,
 		CreatedBy: *s.CreatedBy,
 		Name:      s.Name,
+
+		ExpireOnResolve: s.ExpireOnResolve,
+		Receivers:       s.Receivers,
+		Integrations:    s.Integrations,
+	}
+	if s.Recurrence != nil {
+		rec, err := recurrenceToProto(s.Recurrence)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence: %w", err)
+		}
+		sil.Recurrence = rec
 	}
 	for _, m := range s.Matchers {
 		matcher := &silencepb.Matcher{
@@ -118,6 +151,116 @@ func PostableSilenceToProto(s *open_api_models.PostableSilence) (*silencepb.Sile
 	return sil, nil
 }
 
+// recurrenceToProto converts *open_api_models.SilenceRecurrence to *silencepb.Recurrence.
+func recurrenceToProto(r *open_api_models.SilenceRecurrence) (*silencepb.Recurrence, error) {
+	rec := &silencepb.Recurrence{
+		RRule:    r.Rrule,
+		Location: r.Location,
+	}
+	if r.Duration != "" {
+		d, err := prometheus_model.ParseDuration(r.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration: %w", err)
+		}
+		rec.Duration = time.Duration(d)
+	}
+	for i, ti := range r.TimeIntervals {
+		interval, err := TimeIntervalToProto(ti)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time interval %d: %w", i, err)
+		}
+		rec.TimeIntervals = append(rec.TimeIntervals, interval)
+	}
+	return rec, nil
+}
+
+// recurrenceFromProto converts *silencepb.Recurrence to *open_api_models.SilenceRecurrence.
+func recurrenceFromProto(r *silencepb.Recurrence) (*open_api_models.SilenceRecurrence, error) {
+	rec := &open_api_models.SilenceRecurrence{
+		Rrule:    r.RRule,
+		Location: r.Location,
+	}
+	if r.Duration != 0 {
+		rec.Duration = prometheus_model.Duration(r.Duration).String()
+	}
+	for _, ti := range r.TimeIntervals {
+		interval, err := TimeIntervalFromProto(ti)
+		if err != nil {
+			return nil, err
+		}
+		rec.TimeIntervals = append(rec.TimeIntervals, interval)
+	}
+	return rec, nil
+}
+
+// TimeIntervalToProto converts *open_api_models.TimeInterval to timeinterval.TimeInterval.
+// The ranges use the syntax of the time intervals in the configuration file.
+func TimeIntervalToProto(ti *open_api_models.TimeInterval) (timeinterval.TimeInterval, error) {
+	type timeRange struct {
+		StartTime string `json:"start_time"`
+		EndTime   string `json:"end_time"`
+	}
+	in := struct {
+		Times       []timeRange `json:"times,omitempty"`
+		Weekdays    []string    `json:"weekdays,omitempty"`
+		DaysOfMonth []string    `json:"days_of_month,omitempty"`
+		Months      []string    `json:"months,omitempty"`
+		Years       []string    `json:"years,omitempty"`
+		Location    string      `json:"location,omitempty"`
+	}{
+		Weekdays:    ti.Weekdays,
+		DaysOfMonth: ti.DaysOfMonth,
+		Months:      ti.Months,
+		Years:       ti.Years,
+		Location:    ti.Location,
+	}
+	for _, tr := range ti.Times {
+		in.Times = append(in.Times, timeRange{StartTime: *tr.StartTime, EndTime: *tr.EndTime})
+	}
+
+	// The time interval unmarshalling holds the parsing and validation logic.
+	var res timeinterval.TimeInterval
+	b, err := json.Marshal(in)
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(b, &res)
+	return res, err
+}
+
+// TimeIntervalFromProto converts timeinterval.TimeInterval to *open_api_models.TimeInterval.
+func TimeIntervalFromProto(ti timeinterval.TimeInterval) (*open_api_models.TimeInterval, error) {
+	res := &open_api_models.TimeInterval{}
+	for _, tr := range ti.Times {
+		start := fmt.Sprintf("%02d:%02d", tr.StartMinute/60, tr.StartMinute%60)
+		end := fmt.Sprintf("%02d:%02d", tr.EndMinute/60, tr.EndMinute%60)
+		res.Times = append(res.Times, &open_api_models.TimeRange{StartTime: &start, EndTime: &end})
+	}
+	for _, r := range ti.Weekdays {
+		b, err := r.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		res.Weekdays = append(res.Weekdays, string(b))
+	}
+	for _, r := range ti.DaysOfMonth {
+		b, _ := r.MarshalText()
+		res.DaysOfMonth = append(res.DaysOfMonth, string(b))
+	}
+	for _, r := range ti.Months {
+		b, _ := r.MarshalText()
+		res.Months = append(res.Months, string(b))
+	}
+	for _, r := range ti.Years {
+		b, _ := r.MarshalText()
+		res.Years = append(res.Years, string(b))
+	}
+	if ti.Location != nil {
+		res.Location = ti.Location.String()
+	}
+	return res, nil
+}
+
 // AlertToOpenAPIAlert converts internal alerts, alert types, and receivers to *open_api_models.GettableAlert.
 func AlertToOpenAPIAlert(alert *types.Alert, status types.AlertStatus, receivers []string) *open_api_models.GettableAlert {
 	startsAt := strfmt.DateTime(alert.StartsAt)
@@ -146,6 +289,8 @@ func AlertToOpenAPIAlert(alert *types.Alert, status types.AlertStatus, receivers
 			State:       &state,
 			SilencedBy:  status.SilencedBy,
 			InhibitedBy: status.InhibitedBy,
+
+			SilencedReceivers: status.SilencedReceivers,
 		},
 	}
 
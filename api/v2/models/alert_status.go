@@ -28,9 +28,12 @@ type AlertStatus struct {
 	// Required: true
 	SilencedBy []string `json:"silencedBy"`
 
+	// Receivers, or receiver/integration pairs, the alert is silenced for by silences restricted to receivers
+	SilencedReceivers []string `json:"silencedReceivers"`
+
 	// state
 	// Required: true
-	// Enum: [unprocessed active suppressed]
+	// Enum: [unprocessed active suppressed flapping]
 	State *string `json:"state"`
 }
 
@@ -78,7 +81,7 @@ var alertStatusTypeStatePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["unprocessed","active","suppressed"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["unprocessed","active","suppressed","flapping"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -96,6 +99,9 @@ const (
 
 	// AlertStatusStateSuppressed captures enum value "suppressed"
 	AlertStatusStateSuppressed string = "suppressed"
+
+	// AlertStatusStateFlapping captures enum value "flapping"
+	AlertStatusStateFlapping string = "flapping"
 )
 
 // prop value enum
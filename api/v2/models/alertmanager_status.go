@@ -27,6 +27,9 @@ type AlertmanagerStatus struct {
 	// Required: true
 	Config *AlertmanagerConfig `json:"config"`
 
+	// storm mode
+	StormMode *StormModeStatus `json:"stormMode,omitempty"`
+
 	// uptime
 	// Required: true
 	// Format: date-time
@@ -49,6 +52,10 @@ func (m *AlertmanagerStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateStormMode(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateUptime(formats); err != nil {
 		res = append(res, err)
 	}
@@ -103,6 +110,25 @@ func (m *AlertmanagerStatus) validateConfig(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *AlertmanagerStatus) validateStormMode(formats strfmt.Registry) error {
+	if swag.IsZero(m.StormMode) { // not required
+		return nil
+	}
+
+	if m.StormMode != nil {
+		if err := m.StormMode.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("stormMode")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("stormMode")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *AlertmanagerStatus) validateUptime(formats strfmt.Registry) error {
 
 	if err := validate.Required("uptime", "body", m.Uptime); err != nil {
@@ -148,6 +174,10 @@ func (m *AlertmanagerStatus) ContextValidate(ctx context.Context, formats strfmt
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateStormMode(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateVersionInfo(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -190,6 +220,22 @@ func (m *AlertmanagerStatus) contextValidateConfig(ctx context.Context, formats
 	return nil
 }
 
+func (m *AlertmanagerStatus) contextValidateStormMode(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.StormMode != nil {
+		if err := m.StormMode.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("stormMode")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("stormMode")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *AlertmanagerStatus) contextValidateVersionInfo(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.VersionInfo != nil {
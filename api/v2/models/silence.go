@@ -31,6 +31,12 @@ type Silence struct {
 	// Required: true
 	EndsAt *int64 `json:"endsAt"`
 
+	// expire on resolve
+	ExpireOnResolve bool `json:"expireOnResolve,omitempty"`
+
+	// Names of the integrations of the receivers the silence is restricted to
+	Integrations []string `json:"integrations"`
+
 	// matchers
 	// Required: true
 	Matchers Matchers `json:"matchers"`
@@ -38,6 +44,12 @@ type Silence struct {
 	// name
 	Name string `json:"name,omitempty"`
 
+	// Names of the receivers the silence is restricted to
+	Receivers []string `json:"receivers"`
+
+	// recurrence
+	Recurrence *SilenceRecurrence `json:"recurrence,omitempty"`
+
 	// starts at
 	// Required: true
 	StartsAt *int64 `json:"startsAt"`
@@ -63,6 +75,10 @@ func (m *Silence) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateRecurrence(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStartsAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -118,6 +134,25 @@ func (m *Silence) validateMatchers(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Silence) validateRecurrence(formats strfmt.Registry) error {
+	if swag.IsZero(m.Recurrence) { // not required
+		return nil
+	}
+
+	if m.Recurrence != nil {
+		if err := m.Recurrence.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("recurrence")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("recurrence")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Silence) validateStartsAt(formats strfmt.Registry) error {
 
 	if err := validate.Required("startsAt", "body", m.StartsAt); err != nil {
@@ -135,6 +170,10 @@ func (m *Silence) ContextValidate(ctx context.Context, formats strfmt.Registry)
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateRecurrence(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -155,6 +194,22 @@ func (m *Silence) contextValidateMatchers(ctx context.Context, formats strfmt.Re
 	return nil
 }
 
+func (m *Silence) contextValidateRecurrence(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Recurrence != nil {
+		if err := m.Recurrence.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("recurrence")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("recurrence")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Silence) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -20,9 +20,19 @@ import (
 // swagger:model silenceStatus
 type SilenceStatus struct {
 
+	// The policy violations for which the silence requires an approval
+	ApprovalReasons []string `json:"approvalReasons"`
+
+	// The user who approved the silence
+	ApprovedBy string `json:"approvedBy,omitempty"`
+
+	// resolved at
+	// Format: date-time
+	ResolvedAt strfmt.DateTime `json:"resolvedAt,omitempty"`
+
 	// state
 	// Required: true
-	// Enum: [expired active pending]
+	// Enum: [expired active pending pendingApproval]
 	State *string `json:"state"`
 }
 
@@ -30,6 +40,10 @@ type SilenceStatus struct {
 func (m *SilenceStatus) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateResolvedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateState(formats); err != nil {
 		res = append(res, err)
 	}
@@ -40,11 +54,23 @@ func (m *SilenceStatus) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SilenceStatus) validateResolvedAt(formats strfmt.Registry) error {
+	if swag.IsZero(m.ResolvedAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("resolvedAt", "body", "date-time", m.ResolvedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 var silenceStatusTypeStatePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["expired","active","pending"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["expired","active","pending","pendingApproval"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -62,6 +88,9 @@ const (
 
 	// SilenceStatusStatePending captures enum value "pending"
 	SilenceStatusStatePending string = "pending"
+
+	// SilenceStatusStatePendingApproval captures enum value "pendingApproval"
+	SilenceStatusStatePendingApproval string = "pendingApproval"
 )
 
 // prop value enum
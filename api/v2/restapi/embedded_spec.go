@@ -65,6 +65,13 @@ func init() {
             "name": "inhibited",
             "in": "query"
           },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show flapping alerts",
+            "name": "flapping",
+            "in": "query"
+          },
           {
             "type": "boolean",
             "default": true,
@@ -163,6 +170,13 @@ func init() {
             "name": "inhibited",
             "in": "query"
           },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show flapping alerts",
+            "name": "flapping",
+            "in": "query"
+          },
           {
             "type": "array",
             "items": {
@@ -196,6 +210,41 @@ func init() {
         }
       }
     },
+    "/alerts/{fingerprint}/inhibition": {
+      "get": {
+        "description": "Explain why an alert is inhibited",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "getAlertInhibition",
+        "responses": {
+          "200": {
+            "description": "Get alert inhibition response",
+            "schema": {
+              "$ref": "#/definitions/alertInhibition"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "404": {
+            "description": "An alert with the specified fingerprint was not found"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Fingerprint of the alert",
+          "name": "fingerprint",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/receivers": {
       "get": {
         "description": "Get list of all receivers (name of notification integrations)",
@@ -277,6 +326,85 @@ func init() {
         }
       ]
     },
+    "/silence/{silenceID}/approve": {
+      "post": {
+        "description": "Approve a silence which is pending approval",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "approveSilence",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "ID of the silence to approve",
+            "name": "silenceID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The user approving the silence. It must match the user authenticated by a reverse proxy, see the approver header of Alertmanager.",
+            "name": "approvedBy",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Approve silence response"
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "403": {
+            "description": "Approvals are disabled because Alertmanager is not configured with an approver header",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "A silence with the specified ID was not found"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/silence/{silenceID}/history": {
+      "get": {
+        "description": "Get the prior versions of a silence by its ID",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "getSilenceHistory",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "ID of the silence to get the history of",
+            "name": "silenceID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get silence history response",
+            "schema": {
+              "$ref": "#/definitions/gettableSilences"
+            }
+          },
+          "404": {
+            "description": "A silence with the specified ID was not found"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/silences": {
       "get": {
         "description": "Get a list of silences",
@@ -349,6 +477,115 @@ func init() {
         }
       }
     },
+    "/silences/expire": {
+      "post": {
+        "description": "Expire all silences matching a filter at once",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "expireSilences",
+        "parameters": [
+          {
+            "description": "The filter selecting the silences",
+            "name": "filter",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/silenceFilter"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "IDs of the expired silences",
+            "schema": {
+              "$ref": "#/definitions/silenceIDs"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/silences/extend": {
+      "post": {
+        "description": "Extend all silences matching a filter at once",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "extendSilences",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Duration by which the silences are extended, e.g. 2h",
+            "name": "duration",
+            "in": "query",
+            "required": true
+          },
+          {
+            "description": "The filter selecting the silences",
+            "name": "filter",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/silenceFilter"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "IDs of the extended silences",
+            "schema": {
+              "$ref": "#/definitions/silenceIDs"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/silences/preview": {
+      "post": {
+        "description": "Preview the alerts a silence would mute",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "postSilencesPreview",
+        "parameters": [
+          {
+            "description": "The silence to preview",
+            "name": "silence",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableSilence"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Alert groups with the alerts the silence would mute",
+            "schema": {
+              "$ref": "#/definitions/alertGroups"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/status": {
       "get": {
         "description": "Get current status of an Alertmanager instance and its cluster",
@@ -365,6 +602,47 @@ func init() {
           }
         }
       }
+    },
+    "/timeintervals": {
+      "get": {
+        "description": "Get whether the time intervals are active and their next transitions",
+        "tags": [
+          "timeinterval"
+        ],
+        "operationId": "getTimeIntervals",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "The time at which the time intervals are evaluated, defaults to now",
+            "name": "at",
+            "in": "query"
+          },
+          {
+            "maximum": 100,
+            "minimum": 0,
+            "type": "integer",
+            "default": 5,
+            "description": "The maximum number of transitions searched up to a year ahead",
+            "name": "transitions",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get time intervals response",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/timeIntervalStatus"
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          }
+        }
+      }
     }
   },
   "definitions": {
@@ -411,6 +689,35 @@ func init() {
         "$ref": "#/definitions/alertGroup"
       }
     },
+    "alertInhibition": {
+      "type": "object",
+      "required": [
+        "inhibited"
+      ],
+      "properties": {
+        "dependencyPath": {
+          "description": "Services from the service of the inhibited alert to the service of the source alert",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "equal": {
+          "description": "Values of the equal labels of the rule, shared by the source and the inhibited alert",
+          "$ref": "#/definitions/labelSet"
+        },
+        "inhibited": {
+          "type": "boolean"
+        },
+        "ruleIndex": {
+          "description": "Index of the inhibition rule in the configuration, -1 for inhibitions by the service dependencies",
+          "type": "integer"
+        },
+        "sourceAlert": {
+          "$ref": "#/definitions/gettableAlert"
+        }
+      }
+    },
     "alertStatus": {
       "type": "object",
       "required": [
@@ -431,12 +738,20 @@ func init() {
             "type": "string"
           }
         },
+        "silencedReceivers": {
+          "description": "Receivers, or receiver/integration pairs, the alert is silenced for by silences restricted to receivers",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "state": {
           "type": "string",
           "enum": [
             "unprocessed",
             "active",
-            "suppressed"
+            "suppressed",
+            "flapping"
           ]
         }
       }
@@ -467,6 +782,9 @@ func init() {
         "config": {
           "$ref": "#/definitions/alertmanagerConfig"
         },
+        "stormMode": {
+          "$ref": "#/definitions/stormModeStatus"
+        },
         "uptime": {
           "type": "string",
           "format": "date-time"
@@ -756,34 +1074,231 @@ func init() {
           "type": "number",
           "format": "int64"
         },
+        "expireOnResolve": {
+          "type": "boolean"
+        },
+        "integrations": {
+          "description": "Names of the integrations of the receivers the silence is restricted to",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "matchers": {
           "$ref": "#/definitions/matchers"
         },
         "name": {
           "type": "string"
         },
+        "receivers": {
+          "description": "Names of the receivers the silence is restricted to",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "recurrence": {
+          "$ref": "#/definitions/silenceRecurrence"
+        },
         "startsAt": {
           "type": "number",
           "format": "int64"
         }
       }
     },
-    "silenceStatus": {
+    "silenceFilter": {
       "type": "object",
-      "required": [
-        "state"
-      ],
       "properties": {
-        "state": {
+        "comment": {
+          "description": "Regular expression matching a part of the comment of the silences",
+          "type": "string"
+        },
+        "createdBy": {
+          "description": "Author of the silences",
+          "type": "string"
+        },
+        "matchers": {
+          "$ref": "#/definitions/matchers"
+        },
+        "state": {
+          "description": "States of the silences",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": [
+              "active",
+              "pending",
+              "pendingApproval"
+            ]
+          }
+        }
+      }
+    },
+    "silenceIDs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      }
+    },
+    "silenceRecurrence": {
+      "type": "object",
+      "properties": {
+        "duration": {
+          "type": "string"
+        },
+        "location": {
+          "type": "string"
+        },
+        "rrule": {
+          "type": "string"
+        },
+        "timeIntervals": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/timeInterval"
+          }
+        }
+      }
+    },
+    "silenceStatus": {
+      "type": "object",
+      "required": [
+        "state"
+      ],
+      "properties": {
+        "approvalReasons": {
+          "description": "The policy violations for which the silence requires an approval",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "approvedBy": {
+          "description": "The user who approved the silence",
+          "type": "string"
+        },
+        "resolvedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "state": {
           "type": "string",
           "enum": [
             "expired",
             "active",
-            "pending"
+            "pending",
+            "pendingApproval"
           ]
         }
       }
     },
+    "stormModeStatus": {
+      "type": "object",
+      "required": [
+        "active"
+      ],
+      "properties": {
+        "active": {
+          "type": "boolean"
+        },
+        "since": {
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        }
+      }
+    },
+    "timeInterval": {
+      "type": "object",
+      "properties": {
+        "daysOfMonth": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "location": {
+          "type": "string"
+        },
+        "months": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "times": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/timeRange"
+          }
+        },
+        "weekdays": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "years": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "timeIntervalStatus": {
+      "type": "object",
+      "required": [
+        "name",
+        "active",
+        "transitions"
+      ],
+      "properties": {
+        "active": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "transitions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/timeIntervalTransition"
+          }
+        }
+      }
+    },
+    "timeIntervalTransition": {
+      "type": "object",
+      "required": [
+        "time",
+        "active"
+      ],
+      "properties": {
+        "active": {
+          "type": "boolean"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "timeRange": {
+      "type": "object",
+      "required": [
+        "startTime",
+        "endTime"
+      ],
+      "properties": {
+        "endTime": {
+          "type": "string"
+        },
+        "startTime": {
+          "type": "string"
+        }
+      }
+    },
     "versionInfo": {
       "type": "object",
       "required": [
@@ -897,6 +1412,13 @@ func init() {
             "name": "inhibited",
             "in": "query"
           },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show flapping alerts",
+            "name": "flapping",
+            "in": "query"
+          },
           {
             "type": "boolean",
             "default": true,
@@ -1007,6 +1529,13 @@ func init() {
             "name": "inhibited",
             "in": "query"
           },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show flapping alerts",
+            "name": "flapping",
+            "in": "query"
+          },
           {
             "type": "array",
             "items": {
@@ -1046,6 +1575,47 @@ func init() {
         }
       }
     },
+    "/alerts/{fingerprint}/inhibition": {
+      "get": {
+        "description": "Explain why an alert is inhibited",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "getAlertInhibition",
+        "responses": {
+          "200": {
+            "description": "Get alert inhibition response",
+            "schema": {
+              "$ref": "#/definitions/alertInhibition"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "An alert with the specified fingerprint was not found"
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Fingerprint of the alert",
+          "name": "fingerprint",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/receivers": {
       "get": {
         "description": "Get list of all receivers (name of notification integrations)",
@@ -1121,42 +1691,260 @@ func init() {
             }
           }
         }
-      },
-      "parameters": [
-        {
-          "type": "string",
-          "format": "uuid",
-          "description": "ID of the silence to get",
-          "name": "silenceID",
-          "in": "path",
-          "required": true
-        }
-      ]
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "format": "uuid",
+          "description": "ID of the silence to get",
+          "name": "silenceID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/silence/{silenceID}/approve": {
+      "post": {
+        "description": "Approve a silence which is pending approval",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "approveSilence",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "ID of the silence to approve",
+            "name": "silenceID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The user approving the silence. It must match the user authenticated by a reverse proxy, see the approver header of Alertmanager.",
+            "name": "approvedBy",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Approve silence response"
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "403": {
+            "description": "Approvals are disabled because Alertmanager is not configured with an approver header",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "A silence with the specified ID was not found"
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/silence/{silenceID}/history": {
+      "get": {
+        "description": "Get the prior versions of a silence by its ID",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "getSilenceHistory",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "ID of the silence to get the history of",
+            "name": "silenceID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get silence history response",
+            "schema": {
+              "$ref": "#/definitions/gettableSilences"
+            }
+          },
+          "404": {
+            "description": "A silence with the specified ID was not found"
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/silences": {
+      "get": {
+        "description": "Get a list of silences",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "getSilences",
+        "parameters": [
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "A list of matchers to filter silences by",
+            "name": "filter",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get silences response",
+            "schema": {
+              "$ref": "#/definitions/gettableSilences"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Post a new silence or update an existing one",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "postSilences",
+        "parameters": [
+          {
+            "description": "The silence to create",
+            "name": "silence",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableSilence"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Create / update silence response",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "silenceID": {
+                  "type": "string"
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "A silence with the specified ID was not found",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/silences/expire": {
+      "post": {
+        "description": "Expire all silences matching a filter at once",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "expireSilences",
+        "parameters": [
+          {
+            "description": "The filter selecting the silences",
+            "name": "filter",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/silenceFilter"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "IDs of the expired silences",
+            "schema": {
+              "$ref": "#/definitions/silenceIDs"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
     },
-    "/silences": {
-      "get": {
-        "description": "Get a list of silences",
+    "/silences/extend": {
+      "post": {
+        "description": "Extend all silences matching a filter at once",
         "tags": [
           "silence"
         ],
-        "operationId": "getSilences",
+        "operationId": "extendSilences",
         "parameters": [
           {
-            "type": "array",
-            "items": {
-              "type": "string"
-            },
-            "collectionFormat": "multi",
-            "description": "A list of matchers to filter silences by",
+            "type": "string",
+            "description": "Duration by which the silences are extended, e.g. 2h",
+            "name": "duration",
+            "in": "query",
+            "required": true
+          },
+          {
+            "description": "The filter selecting the silences",
             "name": "filter",
-            "in": "query"
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/silenceFilter"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "Get silences response",
+            "description": "IDs of the extended silences",
             "schema": {
-              "$ref": "#/definitions/gettableSilences"
+              "$ref": "#/definitions/silenceIDs"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
             }
           },
           "500": {
@@ -1166,16 +1954,18 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/silences/preview": {
       "post": {
-        "description": "Post a new silence or update an existing one",
+        "description": "Preview the alerts a silence would mute",
         "tags": [
           "silence"
         ],
-        "operationId": "postSilences",
+        "operationId": "postSilencesPreview",
         "parameters": [
           {
-            "description": "The silence to create",
+            "description": "The silence to preview",
             "name": "silence",
             "in": "body",
             "required": true,
@@ -1186,14 +1976,9 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "Create / update silence response",
+            "description": "Alert groups with the alerts the silence would mute",
             "schema": {
-              "type": "object",
-              "properties": {
-                "silenceID": {
-                  "type": "string"
-                }
-              }
+              "$ref": "#/definitions/alertGroups"
             }
           },
           "400": {
@@ -1202,8 +1987,8 @@ func init() {
               "type": "string"
             }
           },
-          "404": {
-            "description": "A silence with the specified ID was not found",
+          "500": {
+            "description": "Internal server error",
             "schema": {
               "type": "string"
             }
@@ -1227,6 +2012,50 @@ func init() {
           }
         }
       }
+    },
+    "/timeintervals": {
+      "get": {
+        "description": "Get whether the time intervals are active and their next transitions",
+        "tags": [
+          "timeinterval"
+        ],
+        "operationId": "getTimeIntervals",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "The time at which the time intervals are evaluated, defaults to now",
+            "name": "at",
+            "in": "query"
+          },
+          {
+            "maximum": 100,
+            "minimum": 0,
+            "type": "integer",
+            "default": 5,
+            "description": "The maximum number of transitions searched up to a year ahead",
+            "name": "transitions",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get time intervals response",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/timeIntervalStatus"
+              }
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
@@ -1273,6 +2102,35 @@ func init() {
         "$ref": "#/definitions/alertGroup"
       }
     },
+    "alertInhibition": {
+      "type": "object",
+      "required": [
+        "inhibited"
+      ],
+      "properties": {
+        "dependencyPath": {
+          "description": "Services from the service of the inhibited alert to the service of the source alert",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "equal": {
+          "description": "Values of the equal labels of the rule, shared by the source and the inhibited alert",
+          "$ref": "#/definitions/labelSet"
+        },
+        "inhibited": {
+          "type": "boolean"
+        },
+        "ruleIndex": {
+          "description": "Index of the inhibition rule in the configuration, -1 for inhibitions by the service dependencies",
+          "type": "integer"
+        },
+        "sourceAlert": {
+          "$ref": "#/definitions/gettableAlert"
+        }
+      }
+    },
     "alertStatus": {
       "type": "object",
       "required": [
@@ -1293,12 +2151,20 @@ func init() {
             "type": "string"
           }
         },
+        "silencedReceivers": {
+          "description": "Receivers, or receiver/integration pairs, the alert is silenced for by silences restricted to receivers",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "state": {
           "type": "string",
           "enum": [
             "unprocessed",
             "active",
-            "suppressed"
+            "suppressed",
+            "flapping"
           ]
         }
       }
@@ -1329,6 +2195,9 @@ func init() {
         "config": {
           "$ref": "#/definitions/alertmanagerConfig"
         },
+        "stormMode": {
+          "$ref": "#/definitions/stormModeStatus"
+        },
         "uptime": {
           "type": "string",
           "format": "date-time"
@@ -1618,34 +2487,231 @@ func init() {
           "type": "number",
           "format": "int64"
         },
+        "expireOnResolve": {
+          "type": "boolean"
+        },
+        "integrations": {
+          "description": "Names of the integrations of the receivers the silence is restricted to",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "matchers": {
           "$ref": "#/definitions/matchers"
         },
         "name": {
           "type": "string"
         },
+        "receivers": {
+          "description": "Names of the receivers the silence is restricted to",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "recurrence": {
+          "$ref": "#/definitions/silenceRecurrence"
+        },
         "startsAt": {
           "type": "number",
           "format": "int64"
         }
       }
     },
+    "silenceFilter": {
+      "type": "object",
+      "properties": {
+        "comment": {
+          "description": "Regular expression matching a part of the comment of the silences",
+          "type": "string"
+        },
+        "createdBy": {
+          "description": "Author of the silences",
+          "type": "string"
+        },
+        "matchers": {
+          "$ref": "#/definitions/matchers"
+        },
+        "state": {
+          "description": "States of the silences",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": [
+              "active",
+              "pending",
+              "pendingApproval"
+            ]
+          }
+        }
+      }
+    },
+    "silenceIDs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      }
+    },
+    "silenceRecurrence": {
+      "type": "object",
+      "properties": {
+        "duration": {
+          "type": "string"
+        },
+        "location": {
+          "type": "string"
+        },
+        "rrule": {
+          "type": "string"
+        },
+        "timeIntervals": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/timeInterval"
+          }
+        }
+      }
+    },
     "silenceStatus": {
       "type": "object",
       "required": [
         "state"
       ],
       "properties": {
+        "approvalReasons": {
+          "description": "The policy violations for which the silence requires an approval",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "approvedBy": {
+          "description": "The user who approved the silence",
+          "type": "string"
+        },
+        "resolvedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
         "state": {
           "type": "string",
           "enum": [
             "expired",
             "active",
-            "pending"
+            "pending",
+            "pendingApproval"
           ]
         }
       }
     },
+    "stormModeStatus": {
+      "type": "object",
+      "required": [
+        "active"
+      ],
+      "properties": {
+        "active": {
+          "type": "boolean"
+        },
+        "since": {
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        }
+      }
+    },
+    "timeInterval": {
+      "type": "object",
+      "properties": {
+        "daysOfMonth": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "location": {
+          "type": "string"
+        },
+        "months": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "times": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/timeRange"
+          }
+        },
+        "weekdays": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "years": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "timeIntervalStatus": {
+      "type": "object",
+      "required": [
+        "name",
+        "active",
+        "transitions"
+      ],
+      "properties": {
+        "active": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "transitions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/timeIntervalTransition"
+          }
+        }
+      }
+    },
+    "timeIntervalTransition": {
+      "type": "object",
+      "required": [
+        "time",
+        "active"
+      ],
+      "properties": {
+        "active": {
+          "type": "boolean"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "timeRange": {
+      "type": "object",
+      "required": [
+        "startTime",
+        "endTime"
+      ],
+      "properties": {
+        "endTime": {
+          "type": "string"
+        },
+        "startTime": {
+          "type": "string"
+        }
+      }
+    },
     "versionInfo": {
       "type": "object",
       "required": [
@@ -24,6 +24,8 @@ func NewGetAlertsParams() GetAlertsParams {
 
 		activeDefault = bool(true)
 
+		flappingDefault = bool(true)
+
 		inhibitedDefault = bool(true)
 
 		silencedDefault    = bool(true)
@@ -33,6 +35,8 @@ func NewGetAlertsParams() GetAlertsParams {
 	return GetAlertsParams{
 		Active: &activeDefault,
 
+		Flapping: &flappingDefault,
+
 		Inhibited: &inhibitedDefault,
 
 		Silenced: &silencedDefault,
@@ -60,6 +64,11 @@ type GetAlertsParams struct {
 	  Collection Format: multi
 	*/
 	Filter []string
+	/*Show flapping alerts
+	  In: query
+	  Default: true
+	*/
+	Flapping *bool
 	/*Show inhibited alerts
 	  In: query
 	  Default: true
@@ -102,6 +111,11 @@ func (o *GetAlertsParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qFlapping, qhkFlapping, _ := qs.GetOK("flapping")
+	if err := o.bindFlapping(qFlapping, qhkFlapping, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qInhibited, qhkInhibited, _ := qs.GetOK("inhibited")
 	if err := o.bindInhibited(qInhibited, qhkInhibited, route.Formats); err != nil {
 		res = append(res, err)
@@ -173,6 +187,30 @@ func (o *GetAlertsParams) bindFilter(rawData []string, hasKey bool, formats strf
 	return nil
 }
 
+// bindFlapping binds and validates parameter Flapping from query.
+func (o *GetAlertsParams) bindFlapping(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetAlertsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("flapping", "query", "bool", raw)
+	}
+	o.Flapping = &value
+
+	return nil
+}
+
 // bindInhibited binds and validates parameter Inhibited from query.
 func (o *GetAlertsParams) bindInhibited(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -24,6 +24,8 @@ func NewGetAlertGroupsParams() GetAlertGroupsParams {
 
 		activeDefault = bool(true)
 
+		flappingDefault = bool(true)
+
 		inhibitedDefault = bool(true)
 
 		silencedDefault = bool(true)
@@ -32,6 +34,8 @@ func NewGetAlertGroupsParams() GetAlertGroupsParams {
 	return GetAlertGroupsParams{
 		Active: &activeDefault,
 
+		Flapping: &flappingDefault,
+
 		Inhibited: &inhibitedDefault,
 
 		Silenced: &silencedDefault,
@@ -57,6 +61,11 @@ type GetAlertGroupsParams struct {
 	  Collection Format: multi
 	*/
 	Filter []string
+	/*Show flapping alerts
+	  In: query
+	  Default: true
+	*/
+	Flapping *bool
 	/*Show inhibited alerts
 	  In: query
 	  Default: true
@@ -94,6 +103,11 @@ func (o *GetAlertGroupsParams) BindRequest(r *http.Request, route *middleware.Ma
 		res = append(res, err)
 	}
 
+	qFlapping, qhkFlapping, _ := qs.GetOK("flapping")
+	if err := o.bindFlapping(qFlapping, qhkFlapping, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qInhibited, qhkInhibited, _ := qs.GetOK("inhibited")
 	if err := o.bindInhibited(qInhibited, qhkInhibited, route.Formats); err != nil {
 		res = append(res, err)
@@ -160,6 +174,30 @@ func (o *GetAlertGroupsParams) bindFilter(rawData []string, hasKey bool, formats
 	return nil
 }
 
+// bindFlapping binds and validates parameter Flapping from query.
+func (o *GetAlertGroupsParams) bindFlapping(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetAlertGroupsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("flapping", "query", "bool", raw)
+	}
+	o.Flapping = &value
+
+	return nil
+}
+
 // bindInhibited binds and validates parameter Inhibited from query.
 func (o *GetAlertGroupsParams) bindInhibited(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
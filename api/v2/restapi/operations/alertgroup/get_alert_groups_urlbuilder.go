@@ -17,6 +17,7 @@ import (
 type GetAlertGroupsURL struct {
 	Active    *bool
 	Filter    []string
+	Flapping  *bool
 	Inhibited *bool
 	Receiver  *string
 	Silenced  *bool
@@ -77,6 +78,14 @@ func (o *GetAlertGroupsURL) Build() (*url.URL, error) {
 		qs.Add("filter", qsv)
 	}
 
+	var flappingQ string
+	if o.Flapping != nil {
+		flappingQ = swag.FormatBool(*o.Flapping)
+	}
+	if flappingQ != "" {
+		qs.Set("flapping", flappingQ)
+	}
+
 	var inhibitedQ string
 	if o.Inhibited != nil {
 		inhibitedQ = swag.FormatBool(*o.Inhibited)
@@ -38,6 +38,7 @@ import (
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/general"
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/receiver"
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/silence"
+	"github.com/prometheus/alertmanager/api/v2/restapi/operations/timeinterval"
 )
 
 // NewAlertmanagerAPI creates a new Alertmanager instance
@@ -62,12 +63,24 @@ func NewAlertmanagerAPI(spec *loads.Document) *AlertmanagerAPI {
 
 		JSONProducer: runtime.JSONProducer(),
 
+		SilenceApproveSilenceHandler: silence.ApproveSilenceHandlerFunc(func(params silence.ApproveSilenceParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.ApproveSilence has not yet been implemented")
+		}),
 		SilenceDeleteSilenceHandler: silence.DeleteSilenceHandlerFunc(func(params silence.DeleteSilenceParams) middleware.Responder {
 			return middleware.NotImplemented("operation silence.DeleteSilence has not yet been implemented")
 		}),
+		SilenceExpireSilencesHandler: silence.ExpireSilencesHandlerFunc(func(params silence.ExpireSilencesParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.ExpireSilences has not yet been implemented")
+		}),
+		SilenceExtendSilencesHandler: silence.ExtendSilencesHandlerFunc(func(params silence.ExtendSilencesParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.ExtendSilences has not yet been implemented")
+		}),
 		AlertgroupGetAlertGroupsHandler: alertgroup.GetAlertGroupsHandlerFunc(func(params alertgroup.GetAlertGroupsParams) middleware.Responder {
 			return middleware.NotImplemented("operation alertgroup.GetAlertGroups has not yet been implemented")
 		}),
+		AlertGetAlertInhibitionHandler: alert.GetAlertInhibitionHandlerFunc(func(params alert.GetAlertInhibitionParams) middleware.Responder {
+			return middleware.NotImplemented("operation alert.GetAlertInhibition has not yet been implemented")
+		}),
 		AlertGetAlertsHandler: alert.GetAlertsHandlerFunc(func(params alert.GetAlertsParams) middleware.Responder {
 			return middleware.NotImplemented("operation alert.GetAlerts has not yet been implemented")
 		}),
@@ -77,18 +90,27 @@ func NewAlertmanagerAPI(spec *loads.Document) *AlertmanagerAPI {
 		SilenceGetSilenceHandler: silence.GetSilenceHandlerFunc(func(params silence.GetSilenceParams) middleware.Responder {
 			return middleware.NotImplemented("operation silence.GetSilence has not yet been implemented")
 		}),
+		SilenceGetSilenceHistoryHandler: silence.GetSilenceHistoryHandlerFunc(func(params silence.GetSilenceHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.GetSilenceHistory has not yet been implemented")
+		}),
 		SilenceGetSilencesHandler: silence.GetSilencesHandlerFunc(func(params silence.GetSilencesParams) middleware.Responder {
 			return middleware.NotImplemented("operation silence.GetSilences has not yet been implemented")
 		}),
 		GeneralGetStatusHandler: general.GetStatusHandlerFunc(func(params general.GetStatusParams) middleware.Responder {
 			return middleware.NotImplemented("operation general.GetStatus has not yet been implemented")
 		}),
+		TimeintervalGetTimeIntervalsHandler: timeinterval.GetTimeIntervalsHandlerFunc(func(params timeinterval.GetTimeIntervalsParams) middleware.Responder {
+			return middleware.NotImplemented("operation timeinterval.GetTimeIntervals has not yet been implemented")
+		}),
 		AlertPostAlertsHandler: alert.PostAlertsHandlerFunc(func(params alert.PostAlertsParams) middleware.Responder {
 			return middleware.NotImplemented("operation alert.PostAlerts has not yet been implemented")
 		}),
 		SilencePostSilencesHandler: silence.PostSilencesHandlerFunc(func(params silence.PostSilencesParams) middleware.Responder {
 			return middleware.NotImplemented("operation silence.PostSilences has not yet been implemented")
 		}),
+		SilencePostSilencesPreviewHandler: silence.PostSilencesPreviewHandlerFunc(func(params silence.PostSilencesPreviewParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.PostSilencesPreview has not yet been implemented")
+		}),
 	}
 }
 
@@ -125,24 +147,38 @@ type AlertmanagerAPI struct {
 	//   - application/json
 	JSONProducer runtime.Producer
 
+	// SilenceApproveSilenceHandler sets the operation handler for the approve silence operation
+	SilenceApproveSilenceHandler silence.ApproveSilenceHandler
 	// SilenceDeleteSilenceHandler sets the operation handler for the delete silence operation
 	SilenceDeleteSilenceHandler silence.DeleteSilenceHandler
+	// SilenceExpireSilencesHandler sets the operation handler for the expire silences operation
+	SilenceExpireSilencesHandler silence.ExpireSilencesHandler
+	// SilenceExtendSilencesHandler sets the operation handler for the extend silences operation
+	SilenceExtendSilencesHandler silence.ExtendSilencesHandler
 	// AlertgroupGetAlertGroupsHandler sets the operation handler for the get alert groups operation
 	AlertgroupGetAlertGroupsHandler alertgroup.GetAlertGroupsHandler
+	// AlertGetAlertInhibitionHandler sets the operation handler for the get alert inhibition operation
+	AlertGetAlertInhibitionHandler alert.GetAlertInhibitionHandler
 	// AlertGetAlertsHandler sets the operation handler for the get alerts operation
 	AlertGetAlertsHandler alert.GetAlertsHandler
 	// ReceiverGetReceiversHandler sets the operation handler for the get receivers operation
 	ReceiverGetReceiversHandler receiver.GetReceiversHandler
 	// SilenceGetSilenceHandler sets the operation handler for the get silence operation
 	SilenceGetSilenceHandler silence.GetSilenceHandler
+	// SilenceGetSilenceHistoryHandler sets the operation handler for the get silence history operation
+	SilenceGetSilenceHistoryHandler silence.GetSilenceHistoryHandler
 	// SilenceGetSilencesHandler sets the operation handler for the get silences operation
 	SilenceGetSilencesHandler silence.GetSilencesHandler
 	// GeneralGetStatusHandler sets the operation handler for the get status operation
 	GeneralGetStatusHandler general.GetStatusHandler
+	// TimeintervalGetTimeIntervalsHandler sets the operation handler for the get time intervals operation
+	TimeintervalGetTimeIntervalsHandler timeinterval.GetTimeIntervalsHandler
 	// AlertPostAlertsHandler sets the operation handler for the post alerts operation
 	AlertPostAlertsHandler alert.PostAlertsHandler
 	// SilencePostSilencesHandler sets the operation handler for the post silences operation
 	SilencePostSilencesHandler silence.PostSilencesHandler
+	// SilencePostSilencesPreviewHandler sets the operation handler for the post silences preview operation
+	SilencePostSilencesPreviewHandler silence.PostSilencesPreviewHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -220,12 +256,24 @@ func (o *AlertmanagerAPI) Validate() error {
 		unregistered = append(unregistered, "JSONProducer")
 	}
 
+	if o.SilenceApproveSilenceHandler == nil {
+		unregistered = append(unregistered, "silence.ApproveSilenceHandler")
+	}
 	if o.SilenceDeleteSilenceHandler == nil {
 		unregistered = append(unregistered, "silence.DeleteSilenceHandler")
 	}
+	if o.SilenceExpireSilencesHandler == nil {
+		unregistered = append(unregistered, "silence.ExpireSilencesHandler")
+	}
+	if o.SilenceExtendSilencesHandler == nil {
+		unregistered = append(unregistered, "silence.ExtendSilencesHandler")
+	}
 	if o.AlertgroupGetAlertGroupsHandler == nil {
 		unregistered = append(unregistered, "alertgroup.GetAlertGroupsHandler")
 	}
+	if o.AlertGetAlertInhibitionHandler == nil {
+		unregistered = append(unregistered, "alert.GetAlertInhibitionHandler")
+	}
 	if o.AlertGetAlertsHandler == nil {
 		unregistered = append(unregistered, "alert.GetAlertsHandler")
 	}
@@ -235,18 +283,27 @@ func (o *AlertmanagerAPI) Validate() error {
 	if o.SilenceGetSilenceHandler == nil {
 		unregistered = append(unregistered, "silence.GetSilenceHandler")
 	}
+	if o.SilenceGetSilenceHistoryHandler == nil {
+		unregistered = append(unregistered, "silence.GetSilenceHistoryHandler")
+	}
 	if o.SilenceGetSilencesHandler == nil {
 		unregistered = append(unregistered, "silence.GetSilencesHandler")
 	}
 	if o.GeneralGetStatusHandler == nil {
 		unregistered = append(unregistered, "general.GetStatusHandler")
 	}
+	if o.TimeintervalGetTimeIntervalsHandler == nil {
+		unregistered = append(unregistered, "timeinterval.GetTimeIntervalsHandler")
+	}
 	if o.AlertPostAlertsHandler == nil {
 		unregistered = append(unregistered, "alert.PostAlertsHandler")
 	}
 	if o.SilencePostSilencesHandler == nil {
 		unregistered = append(unregistered, "silence.PostSilencesHandler")
 	}
+	if o.SilencePostSilencesPreviewHandler == nil {
+		unregistered = append(unregistered, "silence.PostSilencesPreviewHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -335,10 +392,22 @@ func (o *AlertmanagerAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/silence/{silenceID}/approve"] = silence.NewApproveSilence(o.context, o.SilenceApproveSilenceHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
 	o.handlers["DELETE"]["/silence/{silenceID}"] = silence.NewDeleteSilence(o.context, o.SilenceDeleteSilenceHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/silences/expire"] = silence.NewExpireSilences(o.context, o.SilenceExpireSilencesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/silences/extend"] = silence.NewExtendSilences(o.context, o.SilenceExtendSilencesHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -346,6 +415,10 @@ func (o *AlertmanagerAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/alerts/{fingerprint}/inhibition"] = alert.NewGetAlertInhibition(o.context, o.AlertGetAlertInhibitionHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/alerts"] = alert.NewGetAlerts(o.context, o.AlertGetAlertsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -358,11 +431,19 @@ func (o *AlertmanagerAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/silence/{silenceID}/history"] = silence.NewGetSilenceHistory(o.context, o.SilenceGetSilenceHistoryHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/silences"] = silence.NewGetSilences(o.context, o.SilenceGetSilencesHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/status"] = general.NewGetStatus(o.context, o.GeneralGetStatusHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/timeintervals"] = timeinterval.NewGetTimeIntervals(o.context, o.TimeintervalGetTimeIntervalsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -371,6 +452,10 @@ func (o *AlertmanagerAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/silences"] = silence.NewPostSilences(o.context, o.SilencePostSilencesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/silences/preview"] = silence.NewPostSilencesPreview(o.context, o.SilencePostSilencesPreviewHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
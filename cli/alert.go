@@ -18,7 +18,8 @@ import (
 )
 
 func configureAlertCmd(app *kingpin.Application) {
-	alertCmd := app.Command("alert", "Add or query alerts.").PreAction(requireAlertManagerURL)
+	alertCmd := app.Command("alert", "Add, query or relabel alerts.")
 	configureQueryAlertsCmd(alertCmd)
 	configureAddAlertCmd(alertCmd)
+	configureRelabelAlertCmd(alertCmd)
 }
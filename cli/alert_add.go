@@ -66,7 +66,7 @@ func configureAddAlertCmd(cc *kingpin.CmdClause) {
 	addCmd.Flag("start", "Set when the alert should start. RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&a.start)
 	addCmd.Flag("end", "Set when the alert should should end. RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&a.end)
 	addCmd.Flag("annotation", "Set an annotation to be included with the alert").StringsVar(&a.annotations)
-	addCmd.Action(execWithTimeout(a.addAlert))
+	addCmd.Action(execWithTimeout(a.addAlert)).PreAction(requireAlertManagerURL)
 }
 
 func (a *alertAddCmd) addAlert(ctx context.Context, _ *kingpin.ParseContext) error {
@@ -17,16 +17,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/alecthomas/kingpin/v2"
 
+	v2 "github.com/prometheus/alertmanager/api/v2"
+	"github.com/prometheus/alertmanager/api/v2/client"
 	"github.com/prometheus/alertmanager/api/v2/client/alert"
+	"github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/alertmanager/cli/format"
 	"github.com/prometheus/alertmanager/pkg/labels"
 )
 
 type alertQueryCmd struct {
 	inhibited, silenced, active, unprocessed bool
+	explain                                  bool
 	receiver                                 string
 	matcherGroups                            []string
 }
@@ -57,6 +65,12 @@ amtool alert query 'alertname=~foo.*'
 Amtool supports several flags for filtering the returned alerts by state
 (inhibited, silenced, active, unprocessed). If none of these flags is given,
 only active alerts are returned.
+
+amtool alert query --explain alertname=foo
+
+	Also return the inhibited alerts and explain for each of them which
+	inhibition rule, or chain of service dependencies, and source alert inhibit
+	it.
 `
 
 func configureQueryAlertsCmd(cc *kingpin.CmdClause) {
@@ -68,9 +82,10 @@ func configureQueryAlertsCmd(cc *kingpin.CmdClause) {
 	queryCmd.Flag("silenced", "Show silenced alerts").Short('s').BoolVar(&a.silenced)
 	queryCmd.Flag("active", "Show active alerts").Short('a').BoolVar(&a.active)
 	queryCmd.Flag("unprocessed", "Show unprocessed alerts").Short('u').BoolVar(&a.unprocessed)
+	queryCmd.Flag("explain", "Show inhibited alerts with the rule and source alert inhibiting them").BoolVar(&a.explain)
 	queryCmd.Flag("receiver", "Show alerts matching receiver (Supports regex syntax)").Short('r').StringVar(&a.receiver)
 	queryCmd.Arg("matcher-groups", "Query filter").StringsVar(&a.matcherGroups)
-	queryCmd.Action(execWithTimeout(a.queryAlerts))
+	queryCmd.Action(execWithTimeout(a.queryAlerts)).PreAction(requireAlertManagerURL)
 }
 
 func (a *alertQueryCmd) queryAlerts(ctx context.Context, _ *kingpin.ParseContext) error {
@@ -90,6 +105,9 @@ func (a *alertQueryCmd) queryAlerts(ctx context.Context, _ *kingpin.ParseContext
 	if !a.silenced && !a.inhibited && !a.active && !a.unprocessed {
 		a.active = true
 	}
+	if a.explain {
+		a.inhibited = true
+	}
 
 	alertParams := alert.NewGetAlertsParams().WithContext(ctx).
 		WithActive(&a.active).
@@ -110,5 +128,47 @@ func (a *alertQueryCmd) queryAlerts(ctx context.Context, _ *kingpin.ParseContext
 	if !found {
 		return errors.New("unknown output formatter")
 	}
-	return formatter.FormatAlerts(getOk.Payload)
+	if err := formatter.FormatAlerts(getOk.Payload); err != nil {
+		return err
+	}
+	if !a.explain {
+		return nil
+	}
+	return explainInhibitions(ctx, amclient, getOk.Payload)
+}
+
+// explainInhibitions prints the inhibition rule and source alert of every
+// inhibited alert.
+func explainInhibitions(ctx context.Context, amclient *client.AlertmanagerAPI, alerts models.GettableAlerts) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Alert\tInhibited By\tRule\tEqual\t")
+	for _, a := range alerts {
+		if len(a.Status.InhibitedBy) == 0 {
+			continue
+		}
+		params := alert.NewGetAlertInhibitionParams().WithContext(ctx).WithFingerprint(*a.Fingerprint)
+		ok, err := amclient.Alert.GetAlertInhibition(params)
+		if err != nil {
+			return err
+		}
+		inh := ok.Payload
+		if !*inh.Inhibited || inh.SourceAlert == nil {
+			// The inhibition ended in the meantime.
+			continue
+		}
+		rule := strconv.FormatInt(inh.RuleIndex, 10)
+		if len(inh.DependencyPath) > 0 {
+			rule = "dependency " + strings.Join(inh.DependencyPath, " -> ")
+		}
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t\n",
+			v2.APILabelSetToModelLabelSet(a.Labels),
+			v2.APILabelSetToModelLabelSet(inh.SourceAlert.Labels),
+			rule,
+			v2.APILabelSetToModelLabelSet(inh.Equal),
+		)
+	}
+	return w.Flush()
 }
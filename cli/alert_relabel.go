@@ -0,0 +1,75 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus/alertmanager/pkg/relabel"
+)
+
+type alertRelabelCmd struct {
+	configFile string
+	orgId      int64
+	labels     []string
+}
+
+const alertRelabelHelp = `Dry-run the alert relabeling.
+
+Will print the labels of an alert with the given labels after the configured
+alert_relabel_configs have been applied, or report that the alert is dropped.
+
+The relabel configuration is loaded from a local configuration file or a running Alertmanager configuration.
+Specifying --config.file takes precedence over --alertmanager.url.
+
+Example:
+
+./amtool alert relabel --config.file=doc/examples/simple.yml --org-id=1 alertname=foo environment=prod
+
+`
+
+func configureRelabelAlertCmd(cc *kingpin.CmdClause) {
+	var (
+		a          = &alertRelabelCmd{}
+		relabelCmd = cc.Command("relabel", alertRelabelHelp)
+	)
+	relabelCmd.Flag("config.file", "Config file to be tested.").ExistingFileVar(&a.configFile)
+	relabelCmd.Flag("org-id", "Apply the relabel configuration of the given org.").Int64Var(&a.orgId)
+	relabelCmd.Arg("labels", "List of labels of the alert to be relabeled.").StringsVar(&a.labels)
+	relabelCmd.Action(execWithTimeout(a.relabelAlert))
+}
+
+func (a *alertRelabelCmd) relabelAlert(ctx context.Context, _ *kingpin.ParseContext) error {
+	cfg, err := loadAlertmanagerConfig(ctx, alertmanagerURL, a.configFile)
+	if err != nil {
+		kingpin.Fatalf("%v\n", err)
+		return err
+	}
+
+	ls, err := parseLabels(a.labels)
+	if err != nil {
+		kingpin.Fatalf("Failed to parse labels: %v\n", err)
+	}
+
+	res := relabel.Process(convertClientToCommonLabelSet(ls), cfg.AlertRelabelConfigsFor(a.orgId)...)
+	if res == nil {
+		fmt.Println("Alert dropped by relabeling.")
+		return nil
+	}
+	fmt.Println(res.String())
+	return nil
+}
@@ -19,9 +19,11 @@ import (
 
 // silenceCmd represents the silence command
 func configureSilenceCmd(app *kingpin.Application) {
-	silenceCmd := app.Command("silence", "Add, expire or view silences. For more information and additional flags see query help").PreAction(requireAlertManagerURL)
+	silenceCmd := app.Command("silence", "Add, expire, extend, approve or view silences. For more information and additional flags see query help").PreAction(requireAlertManagerURL)
 	configureSilenceAddCmd(silenceCmd)
+	configureSilenceApproveCmd(silenceCmd)
 	configureSilenceExpireCmd(silenceCmd)
+	configureSilenceExtendCmd(silenceCmd)
 	configureSilenceImportCmd(silenceCmd)
 	configureSilenceQueryCmd(silenceCmd)
 	configureSilenceUpdateCmd(silenceCmd)
@@ -17,15 +17,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/user"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/go-openapi/strfmt"
 	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
 
+	v2 "github.com/prometheus/alertmanager/api/v2"
 	"github.com/prometheus/alertmanager/api/v2/client/silence"
 	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/rrule"
+	"github.com/prometheus/alertmanager/timeinterval"
 )
 
 func username() string {
@@ -44,6 +51,16 @@ type silenceAddCmd struct {
 	end            string
 	comment        string
 	matchers       []string
+
+	expireOnResolve bool
+	dryRun          bool
+
+	receivers    []string
+	integrations []string
+
+	recurrence         string
+	recurrenceDuration string
+	recurrenceLocation string
 }
 
 const silenceAddHelp = `Add a new alertmanager silence
@@ -68,6 +85,31 @@ const silenceAddHelp = `Add a new alertmanager silence
 	As well as direct equality, regex matching is also supported. The '=~' syntax
 	(similar to Prometheus) is used to represent a regex match. Regex matching
 	can be used in combination with a direct match.
+
+  amtool silence add --expire-on-resolve --duration=24h alertname=DiskFull
+
+	The silence is expired once the alerts it matched have resolved for the
+	grace period configured in Alertmanager, even before its end.
+
+  amtool silence add --start=2024-01-06T00:00:00Z --end=2024-07-01T00:00:00Z \
+	--recurrence='FREQ=WEEKLY;BYDAY=SA;BYHOUR=22;BYMINUTE=0' --recurrence-duration=4h \
+	--recurrence-location=Europe/Berlin job=db
+
+	The silence only mutes alerts within the occurrences of the recurrence
+	between its start and end. The recurrence is either an RFC 5545 recurrence
+	rule or time intervals in the syntax of the configuration file, e.g.
+	--recurrence='{weekdays: [saturday, sunday], times: [{start_time: "02:00", end_time: "04:00"}]}'.
+
+  amtool silence add --receiver=team-X-pager --duration=8h alertname=DiskFull
+
+	The silence only mutes the alerts for the given receivers, they are still
+	sent to all other receivers. With --integration, it is further restricted
+	to the given integrations of these receivers, e.g. --integration=pagerduty.
+
+  amtool silence add --dry-run 'alertname=~Disk.*' instance=db1
+
+	Instead of adding the silence, list the currently active alerts it would
+	mute, grouped by the receivers and alert groups they are routed to.
 `
 
 func configureSilenceAddCmd(cc *kingpin.CmdClause) {
@@ -81,6 +123,13 @@ func configureSilenceAddCmd(cc *kingpin.CmdClause) {
 	addCmd.Flag("start", "Set when the silence should start. RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.start)
 	addCmd.Flag("end", "Set when the silence should end (overwrites duration). RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.end)
 	addCmd.Flag("comment", "A comment to help describe the silence").Short('c').StringVar(&c.comment)
+	addCmd.Flag("expire-on-resolve", "Expire the silence once the alerts it matched have resolved").BoolVar(&c.expireOnResolve)
+	addCmd.Flag("receiver", "Only mute the alerts for the given receiver, can be repeated").StringsVar(&c.receivers)
+	addCmd.Flag("integration", "Only mute the alerts for the given integration of the receivers, can be repeated").StringsVar(&c.integrations)
+	addCmd.Flag("dry-run", "Print the active alerts the silence would mute instead of adding it").BoolVar(&c.dryRun)
+	addCmd.Flag("recurrence", "Restrict the silence to a recurrence rule or time intervals").StringVar(&c.recurrence)
+	addCmd.Flag("recurrence-duration", "Duration of each occurrence of the recurrence rule").StringVar(&c.recurrenceDuration)
+	addCmd.Flag("recurrence-location", "Time zone the recurrence rule is evaluated in").StringVar(&c.recurrenceLocation)
 	addCmd.Arg("matcher-groups", "Query filter").StringsVar(&c.matchers)
 	addCmd.Action(execWithTimeout(c.add))
 }
@@ -143,22 +192,47 @@ func (c *silenceAddCmd) add(ctx context.Context, _ *kingpin.ParseContext) error
 		return errors.New("comment required by config")
 	}
 
+	if len(c.integrations) > 0 && len(c.receivers) == 0 {
+		return errors.New("--integration requires --receiver")
+	}
+
+	recurrence, err := c.parseRecurrence(startsAt)
+	if err != nil {
+		return err
+	}
+
 	start := strfmt.DateTime(startsAt)
 	end := strfmt.DateTime(endsAt)
 	ps := &models.PostableSilence{
 		Silence: models.Silence{
-			Matchers:  TypeMatchers(matchers),
-			StartsAt:  &start,
-			EndsAt:    &end,
-			CreatedBy: &c.author,
-			Comment:   &c.comment,
+			Matchers:   TypeMatchers(matchers),
+			StartsAt:   &start,
+			EndsAt:     &end,
+			CreatedBy:  &c.author,
+			Comment:    &c.comment,
+			Recurrence: recurrence,
+
+			ExpireOnResolve: c.expireOnResolve,
+			Receivers:       c.receivers,
+			Integrations:    c.integrations,
 		},
 	}
-	silenceParams := silence.NewPostSilencesParams().WithContext(ctx).
-		WithSilence(ps)
 
 	amclient := NewAlertmanagerClient(alertmanagerURL)
 
+	if c.dryRun {
+		previewParams := silence.NewPostSilencesPreviewParams().WithContext(ctx).
+			WithSilence(ps)
+		previewOk, err := amclient.Silence.PostSilencesPreview(previewParams)
+		if err != nil {
+			return err
+		}
+		return formatSilencePreview(previewOk.Payload)
+	}
+
+	silenceParams := silence.NewPostSilencesParams().WithContext(ctx).
+		WithSilence(ps)
+
 	postOk, err := amclient.Silence.PostSilences(silenceParams)
 	if err != nil {
 		return err
@@ -166,3 +240,91 @@ func (c *silenceAddCmd) add(ctx context.Context, _ *kingpin.ParseContext) error
 	_, err = fmt.Println(postOk.Payload.SilenceID)
 	return err
 }
+
+// formatSilencePreview prints the alerts a silence would mute, one line per
+// alert group they are in.
+func formatSilencePreview(groups models.AlertGroups) error {
+	if len(groups) == 0 {
+		_, err := fmt.Println("No active alerts match the silence")
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Receiver\tGroup\tAlert\tState\t")
+	for _, ag := range groups {
+		for _, a := range ag.Alerts {
+			fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\t%s\t\n",
+				*ag.Receiver.Name,
+				v2.APILabelSetToModelLabelSet(ag.Labels),
+				v2.APILabelSetToModelLabelSet(a.Labels),
+				*a.Status.State,
+			)
+		}
+	}
+	return w.Flush()
+}
+
+// parseRecurrence parses the recurrence flags. A recurrence starting with
+// "FREQ=" or "RRULE:" is a recurrence rule, otherwise it is one or a list of
+// time intervals.
+func (c *silenceAddCmd) parseRecurrence(startsAt time.Time) (*models.SilenceRecurrence, error) {
+	if c.recurrence == "" {
+		if c.recurrenceDuration != "" || c.recurrenceLocation != "" {
+			return nil, errors.New("recurrence duration and location require a recurrence")
+		}
+		return nil, nil
+	}
+
+	rule := strings.ToUpper(c.recurrence)
+	if !strings.HasPrefix(rule, "FREQ=") && !strings.HasPrefix(rule, "RRULE:") {
+		if c.recurrenceDuration != "" || c.recurrenceLocation != "" {
+			return nil, errors.New("recurrence duration and location require a recurrence rule")
+		}
+		var intervals []timeinterval.TimeInterval
+		if strings.HasPrefix(strings.TrimSpace(c.recurrence), "[") {
+			if err := yaml.UnmarshalStrict([]byte(c.recurrence), &intervals); err != nil {
+				return nil, fmt.Errorf("invalid recurrence: %w", err)
+			}
+		} else {
+			var ti timeinterval.TimeInterval
+			if err := yaml.UnmarshalStrict([]byte(c.recurrence), &ti); err != nil {
+				return nil, fmt.Errorf("invalid recurrence: %w", err)
+			}
+			intervals = append(intervals, ti)
+		}
+		rec := &models.SilenceRecurrence{}
+		for _, ti := range intervals {
+			interval, err := v2.TimeIntervalFromProto(ti)
+			if err != nil {
+				return nil, err
+			}
+			rec.TimeIntervals = append(rec.TimeIntervals, interval)
+		}
+		return rec, nil
+	}
+
+	if c.recurrenceDuration == "" {
+		return nil, errors.New("a recurrence rule requires --recurrence-duration")
+	}
+	d, err := model.ParseDuration(c.recurrenceDuration)
+	if err != nil {
+		return nil, err
+	}
+	if d == 0 {
+		return nil, errors.New("recurrence duration must be greater than 0")
+	}
+	loc, err := time.LoadLocation(c.recurrenceLocation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence location: %w", err)
+	}
+	// Check the rule before sending it, for a more helpful error.
+	if _, err := rrule.Parse(c.recurrence, startsAt.In(loc)); err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	return &models.SilenceRecurrence{
+		Rrule:    c.recurrence,
+		Duration: d.String(),
+		Location: c.recurrenceLocation,
+	}, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/prometheus/alertmanager/api/v2/client/silence"
+)
+
+type silenceApproveCmd struct {
+	approver string
+	ids      []string
+}
+
+const silenceApproveHelp = `Approve alertmanager silences which are pending approval
+
+  Silences which violate the silence policy of Alertmanager, e.g. because
+  they last too long or match too many alerts, do not mute alerts until
+  another user than their author approves them.
+
+  amtool silence approve 0c4c7b2e-2b8f-4c1b-9d41-7b1f3c2a6e55
+`
+
+func configureSilenceApproveCmd(cc *kingpin.CmdClause) {
+	var (
+		c          = &silenceApproveCmd{}
+		approveCmd = cc.Command("approve", silenceApproveHelp)
+	)
+	approveCmd.Flag("approver", "Username approving the silences").Short('a').Default(username()).StringVar(&c.approver)
+	approveCmd.Arg("silence-ids", "Ids of silences to approve").StringsVar(&c.ids)
+	approveCmd.Action(execWithTimeout(c.approve))
+}
+
+func (c *silenceApproveCmd) approve(ctx context.Context, _ *kingpin.ParseContext) error {
+	if len(c.ids) < 1 {
+		return errors.New("no silence IDs specified")
+	}
+	if c.approver == "" {
+		return errors.New("no approver specified")
+	}
+
+	amclient := NewAlertmanagerClient(alertmanagerURL)
+
+	for _, id := range c.ids {
+		params := silence.NewApproveSilenceParams().WithContext(ctx).
+			WithApprovedBy(c.approver)
+		params.SilenceID = strfmt.UUID(id)
+		_, err := amclient.Silence.ApproveSilence(params)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
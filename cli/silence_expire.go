@@ -16,33 +16,101 @@ package cli
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/go-openapi/strfmt"
 
 	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/models"
 )
 
+// silenceFilterFlags select silences for bulk operations on the server.
+type silenceFilterFlags struct {
+	matchers  []string
+	createdBy string
+	comment   string
+	states    []string
+}
+
+func (f *silenceFilterFlags) configure(cmd *kingpin.CmdClause) {
+	cmd.Flag("filter", "Select silences with an equal matcher, e.g. incident=INC123. May be repeated.").StringsVar(&f.matchers)
+	cmd.Flag("created-by", "Select silences created by the given author").StringVar(&f.createdBy)
+	cmd.Flag("comment", "Select silences whose comment matches the regular expression").StringVar(&f.comment)
+	cmd.Flag("state", "Select silences in the given state (active, pending or pendingApproval). May be repeated.").EnumsVar(&f.states, "active", "pending", "pendingApproval")
+}
+
+// empty returns true if no silences are selected.
+func (f *silenceFilterFlags) empty() bool {
+	return len(f.matchers) == 0 && f.createdBy == "" && f.comment == ""
+}
+
+func (f *silenceFilterFlags) filter() (*models.SilenceFilter, error) {
+	matchers, err := parseMatchers(f.matchers)
+	if err != nil {
+		return nil, err
+	}
+	filter := &models.SilenceFilter{
+		CreatedBy: f.createdBy,
+		Comment:   f.comment,
+		State:     f.states,
+	}
+	if len(matchers) > 0 {
+		filter.Matchers = TypeMatchers(matchers)
+	}
+	return filter, nil
+}
+
 type silenceExpireCmd struct {
 	ids []string
+	silenceFilterFlags
 }
 
+const silenceExpireHelp = `expire alertmanager silences
+
+  Silences are either given by their IDs or selected by a filter, in which
+  case Alertmanager expires all of them at once and the IDs of the expired
+  silences are printed.
+
+  amtool silence expire --filter=incident=INC123
+`
+
 func configureSilenceExpireCmd(cc *kingpin.CmdClause) {
 	var (
 		c         = &silenceExpireCmd{}
-		expireCmd = cc.Command("expire", "expire an alertmanager silence")
+		expireCmd = cc.Command("expire", silenceExpireHelp)
 	)
 	expireCmd.Arg("silence-ids", "Ids of silences to expire").StringsVar(&c.ids)
+	c.silenceFilterFlags.configure(expireCmd)
 	expireCmd.Action(execWithTimeout(c.expire))
 }
 
 func (c *silenceExpireCmd) expire(ctx context.Context, _ *kingpin.ParseContext) error {
-	if len(c.ids) < 1 {
-		return errors.New("no silence IDs specified")
+	if len(c.ids) > 0 && !c.empty() {
+		return errors.New("silence IDs and filter flags are mutually exclusive")
+	}
+	if len(c.ids) < 1 && c.empty() {
+		return errors.New("no silence IDs or filter specified")
 	}
 
 	amclient := NewAlertmanagerClient(alertmanagerURL)
 
+	if len(c.ids) < 1 {
+		filter, err := c.filter()
+		if err != nil {
+			return err
+		}
+		params := silence.NewExpireSilencesParams().WithContext(ctx).WithFilter(filter)
+		expireOk, err := amclient.Silence.ExpireSilences(params)
+		if err != nil {
+			return err
+		}
+		for _, id := range expireOk.Payload {
+			fmt.Println(id)
+		}
+		return nil
+	}
+
 	for _, id := range c.ids {
 		params := silence.NewDeleteSilenceParams().WithContext(ctx)
 		params.SilenceID = strfmt.UUID(id)
@@ -0,0 +1,80 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/api/v2/client/silence"
+)
+
+type silenceExtendCmd struct {
+	duration string
+	silenceFilterFlags
+}
+
+const silenceExtendHelp = `Extend alertmanager silences selected by a filter
+
+  Alertmanager extends the end of all silences which are selected by the
+  filter and have not expired yet at once. The IDs of the extended silences
+  are printed.
+
+  amtool silence extend --duration=4h --created-by=alice --comment='INC123'
+`
+
+func configureSilenceExtendCmd(cc *kingpin.CmdClause) {
+	var (
+		c         = &silenceExtendCmd{}
+		extendCmd = cc.Command("extend", silenceExtendHelp)
+	)
+	extendCmd.Flag("duration", "Duration by which the silences are extended").Short('d').Required().StringVar(&c.duration)
+	c.silenceFilterFlags.configure(extendCmd)
+	extendCmd.Action(execWithTimeout(c.extend))
+}
+
+func (c *silenceExtendCmd) extend(ctx context.Context, _ *kingpin.ParseContext) error {
+	if c.empty() {
+		return errors.New("no filter specified")
+	}
+	d, err := model.ParseDuration(c.duration)
+	if err != nil {
+		return err
+	}
+	if d == 0 {
+		return errors.New("duration must be greater than 0")
+	}
+	filter, err := c.filter()
+	if err != nil {
+		return err
+	}
+
+	amclient := NewAlertmanagerClient(alertmanagerURL)
+
+	params := silence.NewExtendSilencesParams().WithContext(ctx).
+		WithDuration(c.duration).
+		WithFilter(filter)
+	extendOk, err := amclient.Silence.ExtendSilences(params)
+	if err != nil {
+		return err
+	}
+	for _, id := range extendOk.Payload {
+		fmt.Println(id)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/prometheus/alertmanager/api/v2/client/timeinterval"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/config"
+	ti "github.com/prometheus/alertmanager/timeinterval"
+)
+
+type timeIntervalCheckCmd struct {
+	configFile  string
+	name        string
+	at          string
+	transitions int
+}
+
+const (
+	timeIntervalHelp      = `Evaluate time intervals.`
+	timeIntervalCheckHelp = `Check whether a time interval is active
+
+Prints whether the time interval is active at the given time, now by default,
+and its next transitions within a year.
+
+The time interval is evaluated by a running Alertmanager or, if --config.file
+is specified, locally from the configuration file.
+
+Example:
+
+./amtool timeinterval check business-hours --at=2024-01-05T18:05:00+01:00
+
+`
+)
+
+func configureTimeIntervalCmd(app *kingpin.Application) {
+	var (
+		c               = &timeIntervalCheckCmd{}
+		timeIntervalCmd = app.Command("timeinterval", timeIntervalHelp)
+		checkCmd        = timeIntervalCmd.Command("check", timeIntervalCheckHelp)
+	)
+	checkCmd.Arg("name", "Name of the time interval").Required().StringVar(&c.name)
+	checkCmd.Flag("at", "Time at which the time interval is evaluated. RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.at)
+	checkCmd.Flag("transitions", "Number of upcoming transitions to print").Default("5").IntVar(&c.transitions)
+	checkCmd.Flag("config.file", "Config file defining the time interval.").ExistingFileVar(&c.configFile)
+	checkCmd.Action(execWithTimeout(c.check))
+}
+
+func (c *timeIntervalCheckCmd) check(ctx context.Context, _ *kingpin.ParseContext) error {
+	at := time.Now()
+	if c.at != "" {
+		var err error
+		at, err = time.Parse(time.RFC3339, c.at)
+		if err != nil {
+			return err
+		}
+	}
+	if c.transitions < 0 {
+		return fmt.Errorf("invalid number of transitions %d", c.transitions)
+	}
+
+	var (
+		status *models.TimeIntervalStatus
+		err    error
+	)
+	if c.configFile != "" {
+		status, err = c.evaluate(at)
+	} else {
+		status, err = c.query(ctx, at)
+	}
+	if err != nil {
+		return err
+	}
+
+	state := "inactive"
+	if *status.Active {
+		state = "active"
+	}
+	fmt.Printf("%s is %s at %s\n", c.name, state, at.Format(time.RFC3339))
+	if len(status.Transitions) == 0 {
+		fmt.Println("No transitions within a year")
+		return nil
+	}
+	fmt.Println("Next transitions:")
+	for _, t := range status.Transitions {
+		state := "inactive"
+		if *t.Active {
+			state = "active"
+		}
+		fmt.Printf("  %s  %s\n", time.Time(*t.Time).Format(time.RFC3339), state)
+	}
+	return nil
+}
+
+// query evaluates the time interval by the Alertmanager.
+func (c *timeIntervalCheckCmd) query(ctx context.Context, at time.Time) (*models.TimeIntervalStatus, error) {
+	if alertmanagerURL == nil {
+		kingpin.Fatalf("You have to specify one of --config.file or --alertmanager.url flags.")
+	}
+	amclient := NewAlertmanagerClient(alertmanagerURL)
+	transitions := int64(c.transitions)
+	params := timeinterval.NewGetTimeIntervalsParams().WithContext(ctx).
+		WithAt((*strfmt.DateTime)(&at)).
+		WithTransitions(&transitions)
+	getOk, err := amclient.Timeinterval.GetTimeIntervals(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range getOk.Payload {
+		if *status.Name == c.name {
+			return status, nil
+		}
+	}
+	return nil, fmt.Errorf("time interval %q not found", c.name)
+}
+
+// evaluate evaluates the time interval defined in the configuration file.
+func (c *timeIntervalCheckCmd) evaluate(at time.Time) (*models.TimeIntervalStatus, error) {
+	cfg, err := config.LoadFile(c.configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var tis []ti.TimeInterval
+	found := false
+	for _, mt := range cfg.MuteTimeIntervals {
+		if mt.Name == c.name {
+			tis, found = mt.TimeIntervals, true
+		}
+	}
+	for _, t := range cfg.TimeIntervals {
+		if t.Name == c.name {
+			tis, found = t.TimeIntervals, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("time interval %q not found", c.name)
+	}
+
+	active := ti.Contains(tis, at)
+	status := &models.TimeIntervalStatus{
+		Name:        &c.name,
+		Active:      &active,
+		Transitions: []*models.TimeIntervalTransition{},
+	}
+	for _, t := range ti.Transitions(tis, at, at.AddDate(1, 0, 0), c.transitions) {
+		t := t
+		status.Transitions = append(status.Transitions, &models.TimeIntervalTransition{
+			Time:   (*strfmt.DateTime)(&t.Time),
+			Active: &t.Active,
+		})
+	}
+	return status, nil
+}
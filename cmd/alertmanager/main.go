@@ -40,6 +40,7 @@ import (
 	"github.com/prometheus/common/version"
 	"github.com/prometheus/exporter-toolkit/web"
 	webflag "github.com/prometheus/exporter-toolkit/web/kingpinflag"
+	"github.com/redis/go-redis/v9"
 
 	"github.com/prometheus/alertmanager/api"
 	"github.com/prometheus/alertmanager/config"
@@ -162,10 +163,23 @@ func run() int {
 	}
 
 	var (
-		configFile      = kingpin.Flag("config.file", "Alertmanager configuration file name.").Default("alertmanager.yml").String()
-		dataDir         = kingpin.Flag("storage.path", "Base path for data storage.").Default("data/").String()
-		retention       = kingpin.Flag("data.retention", "How long to keep data for.").Default("120h").Duration()
-		alertGCInterval = kingpin.Flag("alerts.gc-interval", "Interval between alert GC.").Default("30m").Duration()
+		configFile          = kingpin.Flag("config.file", "Alertmanager configuration file name.").Default("alertmanager.yml").String()
+		dataDir             = kingpin.Flag("storage.path", "Base path for data storage.").Default("data/").String()
+		retention           = kingpin.Flag("data.retention", "How long to keep data for.").Default("120h").Duration()
+		maintenanceInterval = kingpin.Flag("data.maintenance-interval", "Interval between garbage collection of expired silences.").Default("15m").Duration()
+		alertGCInterval     = kingpin.Flag("alerts.gc-interval", "Interval between alert GC.").Default("30m").Duration()
+		flapWindow          = kingpin.Flag("alerts.flapping-window", "Window in which transitions between firing and resolved are counted to detect flapping alerts.").Default("1h").Duration()
+		flapThreshold       = kingpin.Flag("alerts.flapping-threshold", "Minimum number of transitions within the flapping window for an alert to be considered flapping and held back from notification. 0 disables flapping detection.").Default("0").Int()
+		resolveGracePeriod  = kingpin.Flag("silences.resolve-grace-period", "How long the alerts matched by a silence expiring on resolve must stay resolved before the silence is expired.").Default("5m").Duration()
+		resolveInterval     = kingpin.Flag("silences.resolve-check-interval", "Interval between checks whether the alerts matched by silences expiring on resolve have resolved.").Default("30s").Duration()
+		maxSilenceDuration  = kingpin.Flag("silences.max-duration", "Maximum duration of silences which take effect without approval. 0 disables the limit.").Default("0").Duration()
+		requiredLabels      = kingpin.Flag("silences.required-label", "Label of which silences must match at least one to take effect without approval, e.g. team or service. May be repeated.").Strings()
+		maxMatchedAlerts    = kingpin.Flag("silences.max-matched-alerts", "Maximum number of firing alerts a silence may match to take effect without approval. 0 disables the limit.").Default("0").Int()
+		maxPerAuthor        = kingpin.Flag("silences.max-per-author", "Maximum number of active and pending silences per author which take effect without approval. 0 disables the limit.").Default("0").Int()
+		approverHeader      = kingpin.Flag("silences.approver-header", "HTTP header holding the user authenticated by a reverse proxy, e.g. X-Forwarded-User. Silences can only be approved on behalf of this user. If empty, silences can't be approved.").Default("").String()
+		redisAddr           = kingpin.Flag("redis.address", "Address of the Redis server holding the silences and the notification state. If empty, the silences are neither maintained nor synchronized and no reminders are sent.").Default("").String()
+		sharedInhibitCache  = kingpin.Flag("inhibit.shared-cache", "Share the source alerts of inhibition rules with the other Alertmanager instances through Redis, so that target alerts are inhibited whichever instance received the source alerts.").Default("false").Bool()
+		reminderInterval    = kingpin.Flag("silences.reminder-interval", "Interval between checks whether reminders are due for the authors of silences, see silence_reminders in the configuration.").Default("1m").Duration()
 
 		webConfig      = webflag.AddFlags(kingpin.CommandLine, ":9093")
 		externalURL    = kingpin.Flag("web.external-url", "The URL under which Alertmanager is externally reachable (for example, if Alertmanager is served via a reverse proxy). Used for generating relative and absolute links back to Alertmanager itself. If the URL has a path portion, it will be used to prefix all HTTP endpoints served by Alertmanager. If omitted, relevant URL components will be derived automatically.").String()
@@ -197,9 +211,47 @@ func run() int {
 
 	marker := types.NewMarker(prometheus.DefaultRegisterer)
 
+	alerts, err := mem.NewAlerts(context.Background(), marker, *alertGCInterval, nil, logger, prometheus.DefaultRegisterer)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 1
+	}
+	defer alerts.Close()
+	alerts.SetFlapDetection(*flapWindow, *flapThreshold)
+
+	pendingAlerts := func() []*types.Alert {
+		it := alerts.GetPending()
+		defer it.Close()
+		var res []*types.Alert
+		for a := range it.Next() {
+			res = append(res, a)
+		}
+		return res
+	}
+
 	silenceOpts := silence.Options{
-		Logger:  log.With(logger, "component", "silences"),
-		Metrics: prometheus.DefaultRegisterer,
+		Logger:             log.With(logger, "component", "silences"),
+		Metrics:            prometheus.DefaultRegisterer,
+		Retention:          *retention,
+		ResolveGracePeriod: *resolveGracePeriod,
+		Policy: silence.Policy{
+			MaxDuration:      *maxSilenceDuration,
+			RequiredLabels:   *requiredLabels,
+			MaxMatchedAlerts: *maxMatchedAlerts,
+			MaxPerAuthor:     *maxPerAuthor,
+		},
+		Alerts: pendingAlerts,
+	}
+
+	// The client stays a nil interface without an address, as the Redis
+	// backed components check for it.
+	var rdb redis.Cmdable
+	if *redisAddr != "" {
+		rdb = redis.NewClient(&redis.Options{Addr: *redisAddr})
+		silenceOpts.Rdb = rdb
+	} else if *sharedInhibitCache {
+		level.Error(logger).Log("msg", "--inhibit.shared-cache requires --redis.address")
+		return 1
 	}
 
 	silences, err := silence.New(silenceOpts)
@@ -213,12 +265,34 @@ func run() int {
 		wg.Wait()
 	}()
 
-	alerts, err := mem.NewAlerts(context.Background(), marker, *alertGCInterval, nil, logger, prometheus.DefaultRegisterer)
-	if err != nil {
-		level.Error(logger).Log("err", err)
-		return 1
+	silenceReminder := notify.NewSilenceReminder(rdb, silences, log.With(logger, "component", "silence_reminder"))
+	if rdb == nil {
+		level.Warn(logger).Log("msg", "No Redis address configured, silences are not maintained and no silence reminders are sent")
+	} else {
+		wg.Add(1)
+		go func() {
+			silences.Maintenance(*maintenanceInterval, stopc, nil)
+			wg.Done()
+		}()
+
+		wg.Add(1)
+		go func() {
+			silences.Run(stopc)
+			wg.Done()
+		}()
+
+		wg.Add(1)
+		go func() {
+			silences.RunExpireResolved(*resolveInterval, stopc, pendingAlerts)
+			wg.Done()
+		}()
+
+		wg.Add(1)
+		go func() {
+			silenceReminder.Run(*reminderInterval, stopc)
+			wg.Done()
+		}()
 	}
-	defer alerts.Close()
 
 	var disp *dispatch.Dispatcher
 	defer func() {
@@ -229,15 +303,21 @@ func run() int {
 		return disp.Groups(routeFilter, alertFilter)
 	}
 
+	stormStatusFn := func() dispatch.StormStatus {
+		return disp.StormStatus()
+	}
+
 	api, err := api.New(api.Options{
-		Alerts:      alerts,
-		Silences:    silences,
-		StatusFunc:  marker.Status,
-		Timeout:     *httpTimeout,
-		Concurrency: *getConcurrency,
-		Logger:      log.With(logger, "component", "api"),
-		Registry:    prometheus.DefaultRegisterer,
-		GroupFunc:   groupFn,
+		Alerts:          alerts,
+		Silences:        silences,
+		StatusFunc:      marker.Status,
+		Timeout:         *httpTimeout,
+		Concurrency:     *getConcurrency,
+		Logger:          log.With(logger, "component", "api"),
+		Registry:        prometheus.DefaultRegisterer,
+		GroupFunc:       groupFn,
+		StormStatusFunc: stormStatusFn,
+		ApproverHeader:  *approverHeader,
 	})
 	if err != nil {
 		level.Error(logger).Log("err", errors.Wrap(err, "failed to create API"))
@@ -265,7 +345,7 @@ func run() int {
 		tmpl      *template.Template
 	)
 
-	dispMetrics := dispatch.NewDispatcherMetrics(false, prometheus.DefaultRegisterer)
+	dispMetrics := dispatch.NewDispatcherMetrics(true, prometheus.DefaultRegisterer)
 	pipelineBuilder := notify.NewPipelineBuilder(prometheus.DefaultRegisterer)
 	configLogger := log.With(logger, "component", "configuration")
 	configCoordinator := config.NewCoordinator(
@@ -286,6 +366,9 @@ func run() int {
 		routes.Walk(func(r *dispatch.Route) {
 			activeReceiversMap[r.RouteOpts.Receiver] = struct{}{}
 		})
+		if conf.SilenceReminders != nil {
+			activeReceiversMap[conf.SilenceReminders.Receiver] = struct{}{}
+		}
 
 		// Build the map of receiver to integrations.
 		receivers := make([]*notify.Receiver, 0, len(activeReceiversMap))
@@ -315,10 +398,35 @@ func run() int {
 			timeIntervals[ti.Name] = ti.TimeIntervals
 		}
 
+		// Build the map of enricher names to enrichers.
+		enrichers := make(map[string]*notify.Enricher, len(conf.Enrichers))
+		for _, ec := range conf.Enrichers {
+			enricher, err := notify.NewEnricher(ec)
+			if err != nil {
+				return err
+			}
+			enrichers[ec.Name] = enricher
+		}
+
+		// Read the service dependency graph, which may be kept in a
+		// separate file.
+		var dependencies *inhibit.DependencyRule
+		if conf.Dependencies != nil {
+			graph, err := conf.Dependencies.Graph()
+			if err != nil {
+				return err
+			}
+			dependencies = inhibit.NewDependencyRule(conf.Dependencies, graph)
+		}
+
 		inhibitor.Stop()
 		disp.Stop()
 
-		inhibitor = inhibit.NewInhibitor(alerts, conf.InhibitRules, marker, logger)
+		inhibitor = inhibit.NewInhibitor(alerts, conf.InhibitRules, timeIntervals, marker, logger)
+		inhibitor.SetDependencies(dependencies)
+		if *sharedInhibitCache {
+			inhibitor.SetSharedCache(rdb, silences.OrgId())
+		}
 		silencer := silence.NewSilencer(silences, marker, logger)
 
 		activeReceivers := make([]*notify.Receiver, 0, len(receivers))
@@ -330,21 +438,26 @@ func run() int {
 		}
 
 		pipeline := pipelineBuilder.New(
-			nil,
+			rdb,
 			activeReceivers,
 			inhibitor,
 			silencer,
+			alerts,
 			timeIntervals,
+			enrichers,
 		)
+		silenceReminder.Update(conf.SilenceReminders, pipelineBuilder.NewReceiverStages(rdb, activeReceivers), tmpl)
 		configuredReceivers.Set(float64(len(activeReceiversMap)))
 		configuredIntegrations.Set(float64(integrationsNum))
 
-		api.Update(conf, receivers, func(labels model.LabelSet) {
+		api.Update(conf, receivers, timeIntervals, func(labels model.LabelSet) {
 			inhibitor.Mutes(labels)
 			silencer.Mutes(labels)
+			alerts.Flapping(labels.Fingerprint())
 		})
 
-		disp = dispatch.NewDispatcher(alerts, routes, pipeline, marker, timeoutFunc, nil, logger, dispMetrics)
+		disp = dispatch.NewDispatcher(alerts, routes, pipeline, marker, timeoutFunc, dispatch.NewConfigLimits(conf.Limits), logger, dispMetrics)
+		disp.SetStormMode(conf.StormMode)
 		routes.Walk(func(r *dispatch.Route) {
 			if r.RouteOpts.RepeatInterval > *retention {
 				level.Warn(configLogger).Log(
@@ -31,6 +31,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/pkg/relabel"
 	"github.com/prometheus/alertmanager/timeinterval"
 )
 
@@ -202,9 +203,35 @@ func LoadFile(filename string) (*Config, error) {
 	}
 
 	resolveFilepaths(filepath.Dir(filename), cfg)
+	if err := cfg.loadCalendarFiles(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
+// loadCalendarFiles reads the calendar files of the time intervals.
+func (c *Config) loadCalendarFiles() error {
+	load := func(name string, tis []timeinterval.TimeInterval) error {
+		for i := range tis {
+			if err := tis[i].LoadCalendarFile(); err != nil {
+				return fmt.Errorf("time interval %q: %w", name, err)
+			}
+		}
+		return nil
+	}
+	for _, mt := range c.MuteTimeIntervals {
+		if err := load(mt.Name, mt.TimeIntervals); err != nil {
+			return err
+		}
+	}
+	for _, ti := range c.TimeIntervals {
+		if err := load(ti.Name, ti.TimeIntervals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // resolveFilepaths joins all relative paths in a configuration
 // with a given base directory.
 func resolveFilepaths(baseDir string, cfg *Config) {
@@ -218,6 +245,19 @@ func resolveFilepaths(baseDir string, cfg *Config) {
 	for i, tf := range cfg.Templates {
 		cfg.Templates[i] = join(tf)
 	}
+	if cfg.Dependencies != nil {
+		cfg.Dependencies.File = join(cfg.Dependencies.File)
+	}
+	for _, mt := range cfg.MuteTimeIntervals {
+		for i := range mt.TimeIntervals {
+			mt.TimeIntervals[i].CalendarFile = join(mt.TimeIntervals[i].CalendarFile)
+		}
+	}
+	for _, ti := range cfg.TimeIntervals {
+		for i := range ti.TimeIntervals {
+			ti.TimeIntervals[i].CalendarFile = join(ti.TimeIntervals[i].CalendarFile)
+		}
+	}
 
 	cfg.Global.HTTPConfig.SetDirectory(baseDir)
 	for _, receiver := range cfg.Receivers {
@@ -306,6 +346,17 @@ type Config struct {
 	// Deprecated. Remove before v1.0 release.
 	MuteTimeIntervals []MuteTimeInterval `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty"`
 	TimeIntervals     []TimeInterval     `yaml:"time_intervals,omitempty" json:"time_intervals,omitempty"`
+	Limits            *Limits            `yaml:"limits,omitempty" json:"limits,omitempty"`
+	StormMode         *StormModeConfig   `yaml:"storm_mode,omitempty" json:"storm_mode,omitempty"`
+	// AlertRelabelConfigs are applied to the labels of every alert received
+	// through the API before it is validated and stored.
+	AlertRelabelConfigs []*relabel.Config `yaml:"alert_relabel_configs,omitempty" json:"alert_relabel_configs,omitempty"`
+	// OrgAlertRelabelConfigs override AlertRelabelConfigs for alerts
+	// received by the Alertmanager of a given org.
+	OrgAlertRelabelConfigs []OrgAlertRelabelConfig `yaml:"org_alert_relabel_configs,omitempty" json:"org_alert_relabel_configs,omitempty"`
+	Enrichers              []*EnricherConfig       `yaml:"enrichers,omitempty" json:"enrichers,omitempty"`
+	SilenceReminders       *SilenceRemindersConfig `yaml:"silence_reminders,omitempty" json:"silence_reminders,omitempty"`
+	Dependencies           *DependenciesConfig     `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
 
 	// original is the input from which the config was parsed.
 	original string
@@ -566,6 +617,11 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err := checkReceiver(c.Route, names); err != nil {
 		return err
 	}
+	if c.SilenceReminders != nil {
+		if _, ok := names[c.SilenceReminders.Receiver]; !ok {
+			return fmt.Errorf("undefined receiver %q used in silence_reminders", c.SilenceReminders.Receiver)
+		}
+	}
 
 	tiNames := make(map[string]struct{})
 
@@ -584,7 +640,54 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		tiNames[mt.Name] = struct{}{}
 	}
 
-	return checkTimeInterval(c.Route, tiNames)
+	orgs := make(map[int64]struct{})
+	for _, oc := range c.OrgAlertRelabelConfigs {
+		if _, ok := orgs[oc.OrgId]; ok {
+			return fmt.Errorf("org %d in org_alert_relabel_configs is not unique", oc.OrgId)
+		}
+		orgs[oc.OrgId] = struct{}{}
+	}
+
+	if err := checkTimeInterval(c.Route, tiNames); err != nil {
+		return err
+	}
+	for _, ir := range c.InhibitRules {
+		for _, ti := range ir.ActiveTimeIntervals {
+			if _, ok := tiNames[ti]; !ok {
+				return fmt.Errorf("undefined time interval %q used in inhibit rule", ti)
+			}
+		}
+		for _, tm := range ir.MuteTimeIntervals {
+			if _, ok := tiNames[tm]; !ok {
+				return fmt.Errorf("undefined time interval %q used in inhibit rule", tm)
+			}
+		}
+	}
+
+	enrichers := make(map[string]struct{})
+	for _, e := range c.Enrichers {
+		if _, ok := enrichers[e.Name]; ok {
+			return fmt.Errorf("enricher %q is not unique", e.Name)
+		}
+		if e.HTTPConfig == nil {
+			e.HTTPConfig = c.Global.HTTPConfig
+		}
+		enrichers[e.Name] = struct{}{}
+	}
+
+	return checkEnricher(c.Route, enrichers)
+}
+
+// AlertRelabelConfigsFor returns the relabel configurations to apply to
+// alerts of the given org. Org specific configurations replace the global
+// ones.
+func (c *Config) AlertRelabelConfigsFor(orgId int64) []*relabel.Config {
+	for _, oc := range c.OrgAlertRelabelConfigs {
+		if oc.OrgId == orgId {
+			return oc.AlertRelabelConfigs
+		}
+	}
+	return c.AlertRelabelConfigs
 }
 
 // checkReceiver returns an error if a node in the routing tree
@@ -625,6 +728,23 @@ func checkTimeInterval(r *Route, timeIntervals map[string]struct{}) error {
 	return nil
 }
 
+// checkEnricher returns an error if a node in the routing tree
+// references an enricher not in the given map.
+func checkEnricher(r *Route, enrichers map[string]struct{}) error {
+	for _, sr := range r.Routes {
+		if err := checkEnricher(sr, enrichers); err != nil {
+			return err
+		}
+	}
+	if r.Enricher == "" {
+		return nil
+	}
+	if _, ok := enrichers[r.Enricher]; !ok {
+		return fmt.Errorf("undefined enricher %q used in route", r.Enricher)
+	}
+	return nil
+}
+
 // DefaultGlobalConfig returns GlobalConfig with default values.
 func DefaultGlobalConfig() GlobalConfig {
 	defaultHTTPConfig := commoncfg.DefaultHTTPClientConfig
@@ -793,6 +913,35 @@ type Route struct {
 	GroupWait      *model.Duration `yaml:"group_wait,omitempty" json:"group_wait,omitempty"`
 	GroupInterval  *model.Duration `yaml:"group_interval,omitempty" json:"group_interval,omitempty"`
 	RepeatInterval *model.Duration `yaml:"repeat_interval,omitempty" json:"repeat_interval,omitempty"`
+
+	// AlignIntervals aligns the flushes of the aggregation groups and the
+	// repeated notifications to multiples of group_interval and
+	// repeat_interval since midnight in AlignLocation. Both are inherited
+	// by child routes.
+	AlignIntervals *bool                  `yaml:"align_intervals,omitempty" json:"align_intervals,omitempty"`
+	AlignLocation  *timeinterval.Location `yaml:"align_location,omitempty" json:"align_location,omitempty"`
+
+	// Enricher is the name of the enricher called before notifying the
+	// receiver of the route. It is inherited by child routes.
+	Enricher string `yaml:"enricher,omitempty" json:"enricher,omitempty"`
+
+	// NotifyMode selects which alerts of a group are notified. It is
+	// inherited by child routes.
+	NotifyMode NotifyMode `yaml:"notify_mode,omitempty" json:"notify_mode,omitempty"`
+
+	// TimeIntervalMode selects what happens to the notifications
+	// suppressed by the mute and active time intervals. It is inherited by
+	// child routes.
+	TimeIntervalMode TimeIntervalMode `yaml:"time_interval_mode,omitempty" json:"time_interval_mode,omitempty"`
+
+	// MaxAggregationGroups limits the number of aggregation groups of this
+	// route. Alerts that would create a group above the limit are added to
+	// the overflow group of the route instead.
+	MaxAggregationGroups *int `yaml:"max_aggregation_groups,omitempty" json:"max_aggregation_groups,omitempty"`
+	// MaxAlertsPerGroup limits the number of alerts in a single aggregation
+	// group of this route. Alerts above the limit are added to the overflow
+	// group of the route instead.
+	MaxAlertsPerGroup *int `yaml:"max_alerts_per_group,omitempty" json:"max_alerts_per_group,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface for Route.
@@ -839,7 +988,354 @@ func (r *Route) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if r.RepeatInterval != nil && time.Duration(*r.RepeatInterval) == time.Duration(0) {
 		return fmt.Errorf("repeat_interval cannot be zero")
 	}
+	if r.MaxAggregationGroups != nil && *r.MaxAggregationGroups < 0 {
+		return fmt.Errorf("max_aggregation_groups cannot be negative")
+	}
+	if r.MaxAlertsPerGroup != nil && *r.MaxAlertsPerGroup < 0 {
+		return fmt.Errorf("max_alerts_per_group cannot be negative")
+	}
+	switch r.NotifyMode {
+	case "", NotifyModeFull, NotifyModeDelta:
+	default:
+		return fmt.Errorf("unknown notify_mode %q, must be %q or %q", r.NotifyMode, NotifyModeFull, NotifyModeDelta)
+	}
+	switch r.TimeIntervalMode {
+	case "", TimeIntervalModeDrop, TimeIntervalModeDefer:
+	default:
+		return fmt.Errorf("unknown time_interval_mode %q, must be %q or %q", r.TimeIntervalMode, TimeIntervalModeDrop, TimeIntervalModeDefer)
+	}
+
+	return nil
+}
+
+// NotifyMode selects which alerts of an aggregation group are notified.
+type NotifyMode string
+
+const (
+	// NotifyModeFull notifies about the alerts which are new, resolved or
+	// due to be repeated.
+	NotifyModeFull NotifyMode = "full"
+	// NotifyModeDelta only notifies about the alerts which are new or
+	// resolved since the previous notification, and summarizes the alerts
+	// which are still firing. Alerts due to be repeated are only notified
+	// if nothing else changed.
+	NotifyModeDelta NotifyMode = "delta"
+)
 
+// TimeIntervalMode selects what happens to the notifications suppressed by
+// time intervals.
+type TimeIntervalMode string
+
+const (
+	// TimeIntervalModeDrop drops the suppressed notifications.
+	TimeIntervalModeDrop TimeIntervalMode = "drop"
+	// TimeIntervalModeDefer queues the suppressed notifications and
+	// delivers them as one catch-up notification once the route is no
+	// longer muted.
+	TimeIntervalModeDefer TimeIntervalMode = "defer"
+)
+
+// Limits defines limits applied by the dispatcher to protect Alertmanager
+// from an unbounded number of aggregation groups and alerts. A zero value
+// means unlimited.
+type Limits struct {
+	// MaxAggregationGroups is the maximum number of aggregation groups across
+	// all routes.
+	MaxAggregationGroups int `yaml:"max_aggregation_groups,omitempty" json:"max_aggregation_groups,omitempty"`
+	// MaxAlertsPerGroup is the maximum number of alerts in a single
+	// aggregation group. Routes can override it.
+	MaxAlertsPerGroup int `yaml:"max_alerts_per_group,omitempty" json:"max_alerts_per_group,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Limits.
+func (l *Limits) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Limits
+	if err := unmarshal((*plain)(l)); err != nil {
+		return err
+	}
+	if l.MaxAggregationGroups < 0 {
+		return fmt.Errorf("max_aggregation_groups cannot be negative")
+	}
+	if l.MaxAlertsPerGroup < 0 {
+		return fmt.Errorf("max_alerts_per_group cannot be negative")
+	}
+	return nil
+}
+
+// DefaultStormModeConfig defines default values for the storm mode configuration.
+var DefaultStormModeConfig = StormModeConfig{
+	DigestInterval: model.Duration(5 * time.Minute),
+	Cooldown:       model.Duration(5 * time.Minute),
+	TopGroups:      10,
+}
+
+// StormModeConfig configures the storm mode of the dispatcher. While the rate
+// of received alerts or of flushed aggregation groups exceeds a threshold,
+// the notifications of each receiver are coalesced into periodic digests.
+type StormModeConfig struct {
+	// AlertsPerMinute is the rate of received alerts above which storm mode
+	// is entered. Zero disables the threshold.
+	AlertsPerMinute int `yaml:"alerts_per_minute,omitempty" json:"alerts_per_minute,omitempty"`
+	// FlushesPerMinute is the rate of flushed aggregation groups above which
+	// storm mode is entered. Zero disables the threshold.
+	FlushesPerMinute int `yaml:"flushes_per_minute,omitempty" json:"flushes_per_minute,omitempty"`
+	// DigestInterval is the interval at which digests are sent to receivers.
+	DigestInterval model.Duration `yaml:"digest_interval,omitempty" json:"digest_interval,omitempty"`
+	// Cooldown is the duration for which all rates must stay below their
+	// thresholds before storm mode is left.
+	Cooldown model.Duration `yaml:"cooldown,omitempty" json:"cooldown,omitempty"`
+	// TopGroups is the number of largest aggregation groups listed in digests.
+	TopGroups int `yaml:"top_groups,omitempty" json:"top_groups,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for StormModeConfig.
+func (c *StormModeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultStormModeConfig
+	type plain StormModeConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.AlertsPerMinute < 0 {
+		return fmt.Errorf("alerts_per_minute cannot be negative")
+	}
+	if c.FlushesPerMinute < 0 {
+		return fmt.Errorf("flushes_per_minute cannot be negative")
+	}
+	if c.AlertsPerMinute == 0 && c.FlushesPerMinute == 0 {
+		return fmt.Errorf("storm mode requires alerts_per_minute or flushes_per_minute")
+	}
+	if c.DigestInterval <= 0 {
+		return fmt.Errorf("digest_interval must be positive")
+	}
+	if c.Cooldown < 0 {
+		return fmt.Errorf("cooldown cannot be negative")
+	}
+	if c.TopGroups < 0 {
+		return fmt.Errorf("top_groups cannot be negative")
+	}
+	return nil
+}
+
+// DefaultSilenceRemindersConfig defines default values for the silence
+// reminders configuration.
+var DefaultSilenceRemindersConfig = SilenceRemindersConfig{
+	BeforeExpiry:        model.Duration(time.Hour),
+	LongSilenceInterval: model.Duration(24 * time.Hour),
+}
+
+// SilenceRemindersConfig configures the reminders sent to the authors of
+// silences shortly before their silences expire and periodically while long
+// silences are in place.
+type SilenceRemindersConfig struct {
+	// Receiver is the name of the receiver the reminders are sent through.
+	Receiver string `yaml:"receiver" json:"receiver"`
+	// BeforeExpiry is how long before the end of a silence its author is
+	// reminded. Zero disables the reminder.
+	BeforeExpiry model.Duration `yaml:"before_expiry" json:"before_expiry"`
+	// LongSilenceThreshold is the duration above which a silence is long.
+	// Zero disables the periodic reminders.
+	LongSilenceThreshold model.Duration `yaml:"long_silence_threshold,omitempty" json:"long_silence_threshold,omitempty"`
+	// LongSilenceInterval is the interval at which the authors of long
+	// silences are reminded.
+	LongSilenceInterval model.Duration `yaml:"long_silence_interval,omitempty" json:"long_silence_interval,omitempty"`
+	// AuthorAddresses maps the authors of silences to the addresses the
+	// reminders are sent to. Authors not listed are used as the address.
+	AuthorAddresses map[string]string `yaml:"author_addresses,omitempty" json:"author_addresses,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for SilenceRemindersConfig.
+func (c *SilenceRemindersConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultSilenceRemindersConfig
+	type plain SilenceRemindersConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.Receiver == "" {
+		return fmt.Errorf("silence_reminders requires a receiver")
+	}
+	if c.BeforeExpiry < 0 {
+		return fmt.Errorf("before_expiry cannot be negative")
+	}
+	if c.LongSilenceThreshold < 0 {
+		return fmt.Errorf("long_silence_threshold cannot be negative")
+	}
+	if c.BeforeExpiry == 0 && c.LongSilenceThreshold == 0 {
+		return fmt.Errorf("silence_reminders requires before_expiry or long_silence_threshold")
+	}
+	if c.LongSilenceThreshold > 0 && c.LongSilenceInterval <= 0 {
+		return fmt.Errorf("long_silence_interval must be positive")
+	}
+	return nil
+}
+
+// DefaultDependenciesConfig defines default values for the dependencies
+// configuration.
+var DefaultDependenciesConfig = DependenciesConfig{
+	Label: "service",
+}
+
+// DependenciesConfig configures the inhibition of alerts of services while a
+// service they depend on, directly or transitively, has a firing alert.
+type DependenciesConfig struct {
+	// Label is the name of the label holding the service of an alert.
+	Label model.LabelName `yaml:"label" json:"label"`
+	// SourceMatchers select the alerts of upstream services which inhibit
+	// the alerts of their downstream services.
+	SourceMatchers Matchers `yaml:"source_matchers,omitempty" json:"source_matchers,omitempty"`
+	// TargetMatchers select the alerts of downstream services which can be
+	// inhibited. If empty, all alerts of downstream services can be.
+	TargetMatchers Matchers `yaml:"target_matchers,omitempty" json:"target_matchers,omitempty"`
+	// Equal is a set of labels that must be equal between the upstream and
+	// downstream alerts for the inhibition to take effect.
+	Equal model.LabelNames `yaml:"equal,omitempty" json:"equal,omitempty"`
+	// Services maps each service to the services it depends on.
+	Services map[string][]string `yaml:"services,omitempty" json:"services,omitempty"`
+	// File is the path of a YAML file with the same format as Services. It
+	// is read again each time the configuration is reloaded.
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for DependenciesConfig.
+func (c *DependenciesConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultDependenciesConfig
+	type plain DependenciesConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if !c.Label.IsValid() {
+		return fmt.Errorf("invalid dependencies label %q", c.Label)
+	}
+	if len(c.SourceMatchers) == 0 {
+		return fmt.Errorf("dependencies require source_matchers")
+	}
+	if len(c.Services) > 0 && c.File != "" {
+		return fmt.Errorf("at most one of services & file must be configured in dependencies")
+	}
+	if len(c.Services) == 0 && c.File == "" {
+		return fmt.Errorf("one of services or file must be configured in dependencies")
+	}
+	return checkDependencyGraph(c.Services)
+}
+
+// Graph returns the service dependency graph, reading it from the file if one
+// is configured.
+func (c *DependenciesConfig) Graph() (map[string][]string, error) {
+	if c.File == "" {
+		return c.Services, nil
+	}
+	content, err := os.ReadFile(c.File)
+	if err != nil {
+		return nil, err
+	}
+	var services map[string][]string
+	if err := yaml.UnmarshalStrict(content, &services); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", c.File, err)
+	}
+	if err := checkDependencyGraph(services); err != nil {
+		return nil, fmt.Errorf("%s: %w", c.File, err)
+	}
+	return services, nil
+}
+
+// checkDependencyGraph returns an error if the service dependency graph
+// contains a cycle.
+func checkDependencyGraph(services map[string][]string) error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(services))
+	var visit func(service string, path []string) error
+	visit = func(service string, path []string) error {
+		switch state[service] {
+		case visiting:
+			return fmt.Errorf("dependency cycle between services: %s", strings.Join(append(path, service), " -> "))
+		case visited:
+			return nil
+		}
+		state[service] = visiting
+		for _, upstream := range services[service] {
+			if err := visit(upstream, append(path, service)); err != nil {
+				return err
+			}
+		}
+		state[service] = visited
+		return nil
+	}
+
+	// Visit the services in a deterministic order to report the same cycle
+	// on every load.
+	names := make([]string, 0, len(services))
+	for service := range services {
+		names = append(names, service)
+	}
+	sort.Strings(names)
+	for _, service := range names {
+		if err := visit(service, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DefaultEnricherConfig defines default values for enricher configurations.
+var DefaultEnricherConfig = EnricherConfig{
+	Timeout:  model.Duration(5 * time.Second),
+	CacheTTL: model.Duration(5 * time.Minute),
+}
+
+// EnricherConfig configures an external service which is called with the
+// alerts of an aggregation group before notification and which returns
+// additional labels and annotations for them.
+type EnricherConfig struct {
+	Name       string                      `yaml:"name" json:"name"`
+	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	// URL to send POST requests to.
+	URL *SecretURL `yaml:"url" json:"url"`
+	// Timeout is the maximum duration of a call to the enricher.
+	Timeout model.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// CacheTTL is the duration for which the enrichment of an alert is cached.
+	CacheTTL model.Duration `yaml:"cache_ttl,omitempty" json:"cache_ttl,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for EnricherConfig.
+func (c *EnricherConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultEnricherConfig
+	type plain EnricherConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.Name == "" {
+		return fmt.Errorf("missing name in enricher config")
+	}
+	if c.URL == nil {
+		return fmt.Errorf("missing url in enricher config")
+	}
+	if c.URL.Scheme != "https" && c.URL.Scheme != "http" {
+		return fmt.Errorf("scheme required for enricher url")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("enricher timeout must be positive")
+	}
+	return nil
+}
+
+// OrgAlertRelabelConfig defines the alert relabel configurations of a
+// single org.
+type OrgAlertRelabelConfig struct {
+	OrgId               int64             `yaml:"org_id" json:"org_id"`
+	AlertRelabelConfigs []*relabel.Config `yaml:"alert_relabel_configs,omitempty" json:"alert_relabel_configs,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for OrgAlertRelabelConfig.
+func (oc *OrgAlertRelabelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain OrgAlertRelabelConfig
+	if err := unmarshal((*plain)(oc)); err != nil {
+		return err
+	}
+	if oc.OrgId <= 0 {
+		return fmt.Errorf("missing org_id in org alert relabel config")
+	}
 	return nil
 }
 
@@ -866,6 +1362,16 @@ type InhibitRule struct {
 	// A set of labels that must be equal between the source and target alert
 	// for them to be a match.
 	Equal model.LabelNames `yaml:"equal,omitempty" json:"equal,omitempty"`
+	// SourceMinDuration is how long a source alert must have been firing
+	// before it inhibits target alerts.
+	SourceMinDuration model.Duration `yaml:"source_min_duration,omitempty" json:"source_min_duration,omitempty"`
+	// HoldAfterResolve is how long a source alert keeps inhibiting target
+	// alerts after it resolved.
+	HoldAfterResolve model.Duration `yaml:"hold_after_resolve,omitempty" json:"hold_after_resolve,omitempty"`
+	// ActiveTimeIntervals restricts the rule to the given time intervals.
+	ActiveTimeIntervals []string `yaml:"active_time_intervals,omitempty" json:"active_time_intervals,omitempty"`
+	// MuteTimeIntervals disables the rule during the given time intervals.
+	MuteTimeIntervals []string `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface for InhibitRule.
@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -197,6 +198,33 @@ receivers:
 	}
 }
 
+func TestInhibitRuleTimeIntervalExists(t *testing.T) {
+	in := `
+route:
+    receiver: team-Y
+
+receivers:
+- name: 'team-Y'
+
+inhibit_rules:
+- source_matchers: ['severity="critical"']
+  target_matchers: ['severity="warning"']
+  hold_after_resolve: 5m
+  active_time_intervals:
+  - business_hours
+`
+	_, err := Load(in)
+
+	expected := "undefined time interval \"business_hours\" used in inhibit rule"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
 func TestTimeIntervalHasName(t *testing.T) {
 	in := `
 time_intervals:
@@ -508,6 +536,424 @@ receivers:
 	}
 }
 
+func TestLimitsAreNotNegative(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in: `
+route:
+    receiver: team-X-mails
+    max_aggregation_groups: -1
+
+receivers:
+- name: 'team-X-mails'
+`,
+			expected: "max_aggregation_groups cannot be negative",
+		},
+		{
+			in: `
+route:
+    receiver: team-X-mails
+    max_alerts_per_group: -1
+
+receivers:
+- name: 'team-X-mails'
+`,
+			expected: "max_alerts_per_group cannot be negative",
+		},
+		{
+			in: `
+limits:
+    max_aggregation_groups: -10
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`,
+			expected: "max_aggregation_groups cannot be negative",
+		},
+	} {
+		_, err := Load(tc.in)
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%q", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%q\ngot:\n%q", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestOrgAlertRelabelConfigs(t *testing.T) {
+	in := `
+alert_relabel_configs:
+- source_labels: [environment]
+  target_label: env
+org_alert_relabel_configs:
+- org_id: 1
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: replica
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`
+	cfg, err := Load(in)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.AlertRelabelConfigsFor(0), 1)
+	require.Equal(t, "env", cfg.AlertRelabelConfigsFor(0)[0].TargetLabel)
+	require.Equal(t, cfg.AlertRelabelConfigs, cfg.AlertRelabelConfigsFor(2))
+
+	orgCfgs := cfg.AlertRelabelConfigsFor(1)
+	require.Len(t, orgCfgs, 1)
+	require.Equal(t, "labeldrop", string(orgCfgs[0].Action))
+}
+
+func TestOrgAlertRelabelConfigsUnique(t *testing.T) {
+	in := `
+org_alert_relabel_configs:
+- org_id: 1
+- org_id: 1
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`
+	_, err := Load(in)
+	require.EqualError(t, err, `org 1 in org_alert_relabel_configs is not unique`)
+}
+
+func TestEnrichers(t *testing.T) {
+	in := `
+enrichers:
+- name: cmdb
+  url: http://cmdb.example.com/enrich
+
+route:
+    receiver: team-X-mails
+    routes:
+    - enricher: cmdb
+
+receivers:
+- name: 'team-X-mails'
+`
+	cfg, err := Load(in)
+	require.NoError(t, err)
+	require.Len(t, cfg.Enrichers, 1)
+	require.Equal(t, DefaultEnricherConfig.Timeout, cfg.Enrichers[0].Timeout)
+	require.Equal(t, DefaultEnricherConfig.CacheTTL, cfg.Enrichers[0].CacheTTL)
+	require.Equal(t, cfg.Global.HTTPConfig, cfg.Enrichers[0].HTTPConfig)
+	require.Equal(t, "cmdb", cfg.Route.Routes[0].Enricher)
+}
+
+func TestUndefinedEnricher(t *testing.T) {
+	in := `
+route:
+    receiver: team-X-mails
+    routes:
+    - enricher: cmdb
+
+receivers:
+- name: 'team-X-mails'
+`
+	_, err := Load(in)
+	require.EqualError(t, err, `undefined enricher "cmdb" used in route`)
+}
+
+func TestNotifyMode(t *testing.T) {
+	in := `
+route:
+    receiver: team-X-mails
+    routes:
+    - notify_mode: delta
+
+receivers:
+- name: 'team-X-mails'
+`
+	cfg, err := Load(in)
+	require.NoError(t, err)
+	require.Equal(t, NotifyModeDelta, cfg.Route.Routes[0].NotifyMode)
+
+	in = `
+route:
+    receiver: team-X-mails
+    routes:
+    - notify_mode: diff
+
+receivers:
+- name: 'team-X-mails'
+`
+	_, err = Load(in)
+	require.EqualError(t, err, `unknown notify_mode "diff", must be "full" or "delta"`)
+}
+
+func TestTimeIntervalMode(t *testing.T) {
+	in := `
+route:
+    receiver: team-X-mails
+    routes:
+    - active_time_intervals: [business_hours]
+      time_interval_mode: defer
+
+time_intervals:
+- name: business_hours
+  time_intervals:
+  - weekdays: ['monday:friday']
+
+receivers:
+- name: 'team-X-mails'
+`
+	cfg, err := Load(in)
+	require.NoError(t, err)
+	require.Equal(t, TimeIntervalModeDefer, cfg.Route.Routes[0].TimeIntervalMode)
+
+	in = `
+route:
+    receiver: team-X-mails
+    time_interval_mode: queue
+
+receivers:
+- name: 'team-X-mails'
+`
+	_, err = Load(in)
+	require.EqualError(t, err, `unknown time_interval_mode "queue", must be "drop" or "defer"`)
+}
+
+func TestAlignIntervals(t *testing.T) {
+	in := `
+route:
+    receiver: team-X-mails
+    align_intervals: true
+    align_location: Europe/Berlin
+    routes:
+    - align_intervals: false
+
+receivers:
+- name: 'team-X-mails'
+`
+	cfg, err := Load(in)
+	require.NoError(t, err)
+	require.True(t, *cfg.Route.AlignIntervals)
+	require.Equal(t, "Europe/Berlin", cfg.Route.AlignLocation.String())
+	require.False(t, *cfg.Route.Routes[0].AlignIntervals)
+	require.Nil(t, cfg.Route.Routes[0].AlignLocation)
+
+	in = `
+route:
+    receiver: team-X-mails
+    align_intervals: true
+    align_location: Europe/Nowhere
+
+receivers:
+- name: 'team-X-mails'
+`
+	_, err = Load(in)
+	require.EqualError(t, err, "unknown time zone Europe/Nowhere")
+}
+
+func TestStormMode(t *testing.T) {
+	in := `
+storm_mode:
+    flushes_per_minute: 100
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`
+	cfg, err := Load(in)
+	require.NoError(t, err)
+	require.Equal(t, 100, cfg.StormMode.FlushesPerMinute)
+	require.Equal(t, DefaultStormModeConfig.DigestInterval, cfg.StormMode.DigestInterval)
+	require.Equal(t, DefaultStormModeConfig.Cooldown, cfg.StormMode.Cooldown)
+	require.Equal(t, DefaultStormModeConfig.TopGroups, cfg.StormMode.TopGroups)
+
+	_, err = Load(`
+storm_mode:
+    digest_interval: 1m
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`)
+	require.EqualError(t, err, "storm mode requires alerts_per_minute or flushes_per_minute")
+}
+
+func TestSilenceReminders(t *testing.T) {
+	in := `
+silence_reminders:
+    receiver: team-X-mails
+    long_silence_threshold: 7d
+    author_addresses:
+        alice: alice@example.org
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`
+	cfg, err := Load(in)
+	require.NoError(t, err)
+	require.Equal(t, DefaultSilenceRemindersConfig.BeforeExpiry, cfg.SilenceReminders.BeforeExpiry)
+	require.Equal(t, model.Duration(7*24*time.Hour), cfg.SilenceReminders.LongSilenceThreshold)
+	require.Equal(t, DefaultSilenceRemindersConfig.LongSilenceInterval, cfg.SilenceReminders.LongSilenceInterval)
+	require.Equal(t, map[string]string{"alice": "alice@example.org"}, cfg.SilenceReminders.AuthorAddresses)
+
+	_, err = Load(`
+silence_reminders:
+    receiver: team-Y-mails
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`)
+	require.EqualError(t, err, `undefined receiver "team-Y-mails" used in silence_reminders`)
+
+	_, err = Load(`
+silence_reminders:
+    receiver: team-X-mails
+    before_expiry: 0s
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`)
+	require.EqualError(t, err, "silence_reminders requires before_expiry or long_silence_threshold")
+}
+
+func TestDependencies(t *testing.T) {
+	in := `
+dependencies:
+    source_matchers: ['severity="critical"']
+    equal: [cluster]
+    services:
+        frontend: [api]
+        api: [db]
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`
+	cfg, err := Load(in)
+	require.NoError(t, err)
+	require.Equal(t, model.LabelName("service"), cfg.Dependencies.Label)
+	graph, err := cfg.Dependencies.Graph()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"frontend": {"api"}, "api": {"db"}}, graph)
+
+	_, err = Load(`
+dependencies:
+    source_matchers: ['severity="critical"']
+    services:
+        frontend: [api]
+        api: [db]
+        db: [frontend]
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`)
+	require.EqualError(t, err, "dependency cycle between services: api -> db -> frontend -> api")
+
+	_, err = Load(`
+dependencies:
+    services:
+        frontend: [api]
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`)
+	require.EqualError(t, err, "dependencies require source_matchers")
+
+	// The graph is read from the file relative to the configuration file.
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "services.yml"), []byte("frontend: [api]\napi: [db]\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alertmanager.yml"), []byte(`
+dependencies:
+    source_matchers: ['severity="critical"']
+    file: services.yml
+
+route:
+    receiver: team-X-mails
+
+receivers:
+- name: 'team-X-mails'
+`), 0o644))
+	cfg, err = LoadFile(filepath.Join(dir, "alertmanager.yml"))
+	require.NoError(t, err)
+	graph, err = cfg.Dependencies.Graph()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"frontend": {"api"}, "api": {"db"}}, graph)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "services.yml"), []byte("frontend: [api]\napi: [frontend]\n"), 0o644))
+	_, err = cfg.Dependencies.Graph()
+	require.EqualError(t, err, filepath.Join(dir, "services.yml")+": dependency cycle between services: api -> frontend -> api")
+}
+
+func TestTimeIntervalCalendarFile(t *testing.T) {
+	// The calendar is read from the file relative to the configuration
+	// file.
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "holidays.ics"), []byte(`BEGIN:VCALENDAR
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20241225
+RRULE:FREQ=YEARLY
+END:VEVENT
+END:VCALENDAR
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alertmanager.yml"), []byte(`
+route:
+    receiver: team-X-mails
+    routes:
+    - mute_time_intervals: [holidays]
+
+time_intervals:
+- name: holidays
+  time_intervals:
+  - calendar_file: holidays.ics
+    location: Europe/Berlin
+  - dates: ['2024-05-01', '2024-10-03']
+
+receivers:
+- name: 'team-X-mails'
+`), 0o644))
+	cfg, err := LoadFile(filepath.Join(dir, "alertmanager.yml"))
+	require.NoError(t, err)
+	tis := cfg.TimeIntervals[0].TimeIntervals
+	require.Equal(t, filepath.Join(dir, "holidays.ics"), tis[0].CalendarFile)
+	require.True(t, tis[0].ContainsTime(time.Date(2025, 12, 24, 23, 30, 0, 0, time.UTC)))
+	require.False(t, tis[0].ContainsTime(time.Date(2025, 12, 26, 12, 0, 0, 0, time.UTC)))
+	require.True(t, tis[1].ContainsTime(time.Date(2024, 10, 3, 12, 0, 0, 0, time.UTC)))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "holidays.ics"), []byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VEVENT\nEND:VCALENDAR\n"), 0o644))
+	_, err = LoadFile(filepath.Join(dir, "alertmanager.yml"))
+	require.EqualError(t, err, `time interval "holidays": `+filepath.Join(dir, "holidays.ics")+": event 1: missing DTSTART")
+}
+
 func TestHideConfigSecrets(t *testing.T) {
 	c, err := LoadFile("testdata/conf.good.yml")
 	if err != nil {
@@ -0,0 +1,69 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatch
+
+import (
+	"time"
+)
+
+// alignedAfter returns the first multiple of d since midnight in loc which
+// is after t. Intervals up to a day restart at every midnight. The multiples
+// are counted in wall-clock time, so that they stay on the same local times
+// on days with daylight saving time changes.
+func alignedAfter(t time.Time, d time.Duration, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	y, m, day := t.Date()
+	h, min, s := t.Clock()
+	wall := time.Duration(h)*time.Hour + time.Duration(min)*time.Minute +
+		time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+
+	next := time.Date(y, m, day, 0, 0, 0, int((wall/d+1)*d), loc)
+	if midnight := time.Date(y, m, day+1, 0, 0, 0, 0, loc); d <= 24*time.Hour && next.After(midnight) {
+		next = midnight
+	}
+	// The wall-clock time may be ambiguous when the clocks are turned back.
+	for !next.After(t) {
+		next = next.Add(d)
+	}
+	return next
+}
+
+// groupInterval returns the time to wait after the flush at now before
+// flushing the aggregation group again.
+func (ag *aggrGroup) groupInterval(now time.Time) time.Duration {
+	if !ag.opts.AlignIntervals {
+		return ag.opts.GroupInterval
+	}
+	// Skip the boundaries closer than half an interval, so that a flush
+	// slightly before a boundary does not cause another flush right after.
+	next := alignedAfter(now.Add(ag.opts.GroupInterval/2), ag.opts.GroupInterval, ag.opts.AlignLocation)
+	return next.Sub(now)
+}
+
+// repeatInterval returns the time after which the alerts notified by the
+// flush at now are notified again.
+func (ag *aggrGroup) repeatInterval(now time.Time) time.Duration {
+	if !ag.opts.AlignIntervals {
+		return ag.opts.RepeatInterval
+	}
+	// The notification log entries expire half a group interval before the
+	// boundary, so that the flush at the boundary repeats the notification
+	// even if the entries were written a bit later than now.
+	margin := ag.opts.GroupInterval / 2
+	next := alignedAfter(now.Add(margin), ag.opts.RepeatInterval, ag.opts.AlignLocation)
+	return next.Sub(now) - margin
+}
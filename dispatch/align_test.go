@@ -0,0 +1,112 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlignedAfter(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		title string
+		t     string
+		d     time.Duration
+		loc   *time.Location
+
+		exp string
+	}{
+		{
+			title: "next half hour",
+			t:     "2024-03-01T10:07:00Z",
+			d:     30 * time.Minute,
+			exp:   "2024-03-01T10:30:00Z",
+		},
+		{
+			title: "on a boundary",
+			t:     "2024-03-01T10:30:00Z",
+			d:     30 * time.Minute,
+			exp:   "2024-03-01T11:00:00Z",
+		},
+		{
+			title: "next day",
+			t:     "2024-03-01T23:59:00Z",
+			d:     time.Hour,
+			exp:   "2024-03-02T00:00:00Z",
+		},
+		{
+			title: "interval not dividing the day restarts at midnight",
+			t:     "2024-03-01T23:00:00Z",
+			d:     7 * time.Hour,
+			exp:   "2024-03-02T00:00:00Z",
+		},
+		{
+			title: "midnight in location",
+			t:     "2024-03-01T20:00:00Z",
+			d:     24 * time.Hour,
+			loc:   berlin,
+			exp:   "2024-03-01T23:00:00Z",
+		},
+		{
+			title: "wall clock on daylight saving time change",
+			t:     "2024-03-31T00:30:00Z",
+			d:     6 * time.Hour,
+			loc:   berlin,
+			exp:   "2024-03-31T04:00:00Z",
+		},
+	} {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, tc.t)
+			require.NoError(t, err)
+			exp, err := time.Parse(time.RFC3339, tc.exp)
+			require.NoError(t, err)
+			require.True(t, exp.Equal(alignedAfter(at, tc.d, tc.loc)), "got %s", alignedAfter(at, tc.d, tc.loc))
+		})
+	}
+}
+
+func TestAggrGroupAlignedIntervals(t *testing.T) {
+	opts := &RouteOpts{
+		GroupInterval:  5 * time.Minute,
+		RepeatInterval: time.Hour,
+	}
+	ag := &aggrGroup{opts: opts}
+	now, err := time.Parse(time.RFC3339, "2024-03-01T10:07:00Z")
+	require.NoError(t, err)
+
+	require.Equal(t, 5*time.Minute, ag.groupInterval(now))
+	require.Equal(t, time.Hour, ag.repeatInterval(now))
+
+	opts.AlignIntervals = true
+	require.Equal(t, 3*time.Minute, ag.groupInterval(now))
+	// The notification log entries expire half a group interval before
+	// 11:00.
+	require.Equal(t, 53*time.Minute-150*time.Second, ag.repeatInterval(now))
+
+	// A flush slightly before a boundary skips it.
+	now = now.Add(-2*time.Minute - time.Second)
+	require.Equal(t, 5*time.Minute+time.Second, ag.groupInterval(now))
+
+	// Alerts notified shortly before the hour are repeated on the next
+	// hour.
+	now, err = time.Parse(time.RFC3339, "2024-03-01T10:59:00Z")
+	require.NoError(t, err)
+	require.Equal(t, 61*time.Minute-150*time.Second, ag.repeatInterval(now))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
 
+	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/store"
@@ -35,7 +36,11 @@ import (
 type DispatcherMetrics struct {
 	aggrGroups            prometheus.Gauge
 	processingDuration    prometheus.Summary
-	aggrGroupLimitReached prometheus.Counter
+	aggrGroupLimitReached *prometheus.CounterVec
+	alertLimitReached     *prometheus.CounterVec
+	stormMode             prometheus.Gauge
+	stormModeTransitions  *prometheus.CounterVec
+	stormDigests          prometheus.Counter
 }
 
 // NewDispatcherMetrics returns a new registered DispatchMetrics.
@@ -53,18 +58,45 @@ func NewDispatcherMetrics(registerLimitMetrics bool, r prometheus.Registerer) *D
 				Help: "Summary of latencies for the processing of alerts.",
 			},
 		),
-		aggrGroupLimitReached: prometheus.NewCounter(
+		aggrGroupLimitReached: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "alertmanager_dispatcher_aggregation_group_limit_reached_total",
 				Help: "Number of times when dispatcher failed to create new aggregation group due to limit.",
 			},
+			[]string{"route"},
+		),
+		alertLimitReached: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "alertmanager_dispatcher_aggregation_group_alert_limit_reached_total",
+				Help: "Number of times when dispatcher failed to add an alert to an aggregation group due to limit.",
+			},
+			[]string{"route"},
+		),
+		stormMode: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "alertmanager_dispatcher_storm_mode",
+				Help: "Whether the dispatcher is in storm mode (1) or not (0).",
+			},
+		),
+		stormModeTransitions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "alertmanager_dispatcher_storm_mode_transitions_total",
+				Help: "Number of times the dispatcher entered or left storm mode.",
+			},
+			[]string{"mode"},
+		),
+		stormDigests: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "alertmanager_dispatcher_storm_digests_total",
+				Help: "Number of digests sent to receivers in storm mode.",
+			},
 		),
 	}
 
 	if r != nil {
-		r.MustRegister(m.aggrGroups, m.processingDuration)
+		r.MustRegister(m.aggrGroups, m.processingDuration, m.stormMode, m.stormModeTransitions, m.stormDigests)
 		if registerLimitMetrics {
-			r.MustRegister(m.aggrGroupLimitReached)
+			r.MustRegister(m.aggrGroupLimitReached, m.alertLimitReached)
 		}
 	}
 
@@ -79,12 +111,18 @@ type Dispatcher struct {
 	stage   notify.Stage
 	metrics *DispatcherMetrics
 	limits  Limits
+	storm   *storm
 
 	timeout func(time.Duration) time.Duration
 
+	// mtx protects the map of routes to their groups, which are
+	// partitioned into shards with their own locks. Readers only hold the
+	// locks long enough to take snapshots, so that they don't stall the
+	// ingestion of alerts.
 	mtx                sync.RWMutex
-	aggrGroupsPerRoute map[*Route]map[model.Fingerprint]*aggrGroup
-	aggrGroupsNum      int
+	aggrGroupsPerRoute map[*Route]*routeGroups
+	// aggrGroupsNum is only accessed by the goroutine processing alerts.
+	aggrGroupsNum int
 
 	done   chan struct{}
 	ctx    context.Context
@@ -97,10 +135,25 @@ type Dispatcher struct {
 type Limits interface {
 	// MaxNumberOfAggregationGroups returns max number of aggregation groups that dispatcher can have.
 	// 0 or negative value = unlimited.
-	// If dispatcher hits this limit, it will not create additional groups, but will log an error instead.
+	// If dispatcher hits this limit, it will not create additional groups, but will add
+	// the alert to the overflow group of its route instead.
 	MaxNumberOfAggregationGroups() int
+	// MaxNumberOfAlertsPerAggregationGroup returns max number of alerts that a single
+	// aggregation group can have, unless overridden by the route.
+	// 0 or negative value = unlimited.
+	// If a group hits this limit, new alerts are added to the overflow group of its route.
+	MaxNumberOfAlertsPerAggregationGroup() int
 }
 
+// OverflowLabel is the only group label of the per-route overflow groups,
+// which collect the alerts that exceed the dispatcher limits.
+const OverflowLabel = model.LabelName("alertmanager_overflow")
+
+var (
+	overflowLabels      = model.LabelSet{OverflowLabel: "true"}
+	overflowFingerprint = overflowLabels.Fingerprint()
+)
+
 // NewDispatcher returns a new Dispatcher.
 func NewDispatcher(
 	ap provider.Alerts,
@@ -128,14 +181,33 @@ func NewDispatcher(
 	return disp
 }
 
+// SetStormMode enables the storm mode of the dispatcher. A nil configuration
+// disables it. It must be called before Run.
+func (d *Dispatcher) SetStormMode(c *config.StormModeConfig) {
+	if c == nil {
+		d.storm = nil
+		return
+	}
+	d.storm = newStorm(*c)
+}
+
+// StormStatus returns the storm mode status of the dispatcher.
+func (d *Dispatcher) StormStatus() StormStatus {
+	if d == nil || d.storm == nil {
+		return StormStatus{}
+	}
+	return d.storm.status()
+}
+
 // Run starts dispatching alerts incoming via the updates channel.
 func (d *Dispatcher) Run() {
 	d.done = make(chan struct{})
 
 	d.mtx.Lock()
-	d.aggrGroupsPerRoute = map[*Route]map[model.Fingerprint]*aggrGroup{}
+	d.aggrGroupsPerRoute = map[*Route]*routeGroups{}
 	d.aggrGroupsNum = 0
 	d.metrics.aggrGroups.Set(0)
+	d.metrics.stormMode.Set(0)
 	d.ctx, d.cancel = context.WithCancel(context.Background())
 	d.mtx.Unlock()
 
@@ -147,6 +219,14 @@ func (d *Dispatcher) run(it provider.AlertIterator) {
 	cleanup := time.NewTicker(30 * time.Second)
 	defer cleanup.Stop()
 
+	// The storm mode is only evaluated if it is enabled.
+	var stormC <-chan time.Time
+	if d.storm != nil {
+		stormTicker := time.NewTicker(stormEvalInterval)
+		defer stormTicker.Stop()
+		stormC = stormTicker.C
+	}
+
 	defer it.Close()
 
 	for {
@@ -169,26 +249,29 @@ func (d *Dispatcher) run(it provider.AlertIterator) {
 			}
 
 			now := time.Now()
+			if d.storm != nil {
+				d.storm.observeAlert(now)
+			}
 			for _, r := range d.route.Match(alert.Labels) {
 				d.processAlert(alert, r)
 			}
 			d.metrics.processingDuration.Observe(time.Since(now).Seconds())
 
 		case <-cleanup.C:
-			d.mtx.Lock()
-
-			for _, groups := range d.aggrGroupsPerRoute {
-				for _, ag := range groups {
-					if ag.empty() {
-						ag.stop()
-						delete(groups, ag.fingerprint())
+			for _, groups := range d.routeGroups() {
+				// The groups are stopped after they are removed to not
+				// hold the lock of their shard while they finish.
+				for _, ag := range groups.deleteEmpty() {
+					ag.stop()
+					if !ag.overflow {
 						d.aggrGroupsNum--
-						d.metrics.aggrGroups.Dec()
 					}
+					d.metrics.aggrGroups.Dec()
 				}
 			}
 
-			d.mtx.Unlock()
+		case now := <-stormC:
+			d.evaluateStorm(now)
 
 		case <-d.ctx.Done():
 			return
@@ -218,21 +301,18 @@ func (ag AlertGroups) Len() int { return len(ag) }
 func (d *Dispatcher) Groups(routeFilter func(*Route) bool, alertFilter func(*types.Alert, time.Time) bool) (AlertGroups, map[model.Fingerprint][]string) {
 	groups := AlertGroups{}
 
-	d.mtx.RLock()
-	defer d.mtx.RUnlock()
-
 	// Keep a list of receivers for an alert to prevent checking each alert
 	// again against all routes. The alert has already matched against this
 	// route on ingestion.
 	receivers := map[model.Fingerprint][]string{}
 
 	now := time.Now()
-	for route, ags := range d.aggrGroupsPerRoute {
+	for route, rg := range d.routeGroups() {
 		if !routeFilter(route) {
 			continue
 		}
 
-		for _, ag := range ags {
+		for _, ag := range rg.list() {
 			receiver := route.RouteOpts.Receiver
 			alertGroup := &AlertGroup{
 				Labels:   ag.labels,
@@ -306,53 +386,281 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *Route) {
 	groupLabels := getGroupLabels(alert, route)
 
 	fp := groupLabels.Fingerprint()
+	routeGroups := d.groupsOf(route)
 
-	d.mtx.Lock()
-	defer d.mtx.Unlock()
-
-	routeGroups, ok := d.aggrGroupsPerRoute[route]
-	if !ok {
-		routeGroups = map[model.Fingerprint]*aggrGroup{}
-		d.aggrGroupsPerRoute[route] = routeGroups
-	}
-
-	ag, ok := routeGroups[fp]
+	ag, ok := routeGroups.get(fp)
 	if ok {
+		// Alerts already in the group are always updated, new alerts are
+		// only added if the group is below its alert limit.
+		if limit := d.maxAlertsPerGroup(route); limit > 0 && !ag.overflow && !ag.contains(alert) && ag.len() >= limit {
+			d.metrics.alertLimitReached.WithLabelValues(route.Key()).Inc()
+			level.Warn(d.logger).Log("msg", "Too many alerts in aggregation group, adding alert to overflow group", "aggrGroup", ag.GroupKey(), "limit", limit, "alert", alert.Name())
+			d.insertOverflow(alert, route, routeGroups)
+			return
+		}
+		d.leaveOverflow(alert, routeGroups)
 		ag.insert(alert)
 		return
 	}
 
-	// If the group does not exist, create it. But check the limit first.
+	// If the group does not exist, create it. But check the limits first.
 	if limit := d.limits.MaxNumberOfAggregationGroups(); limit > 0 && d.aggrGroupsNum >= limit {
-		d.metrics.aggrGroupLimitReached.Inc()
-		level.Error(d.logger).Log("msg", "Too many aggregation groups, cannot create new group for alert", "groups", d.aggrGroupsNum, "limit", limit, "alert", alert.Name())
+		d.metrics.aggrGroupLimitReached.WithLabelValues(route.Key()).Inc()
+		level.Warn(d.logger).Log("msg", "Too many aggregation groups, adding alert to overflow group", "groups", d.aggrGroupsNum, "limit", limit, "alert", alert.Name())
+		d.insertOverflow(alert, route, routeGroups)
+		return
+	}
+	if limit := route.RouteOpts.MaxAggregationGroups; limit > 0 && routeGroups.len() >= limit {
+		d.metrics.aggrGroupLimitReached.WithLabelValues(route.Key()).Inc()
+		level.Warn(d.logger).Log("msg", "Too many aggregation groups for route, adding alert to overflow group", "route", route.Key(), "limit", limit, "alert", alert.Name())
+		d.insertOverflow(alert, route, routeGroups)
 		return
 	}
 
+	d.leaveOverflow(alert, routeGroups)
 	ag = newAggrGroup(d.ctx, groupLabels, route, d.timeout, d.logger)
-	routeGroups[fp] = ag
 	d.aggrGroupsNum++
 	d.metrics.aggrGroups.Inc()
 
 	// Insert the 1st alert in the group before starting the group's run()
 	// function, to make sure that when the run() will be executed the 1st
-	// alert is already there.
+	// alert is already there. The group is only made visible to readers
+	// afterwards.
+	ag.insert(alert)
+	routeGroups.set(fp, ag)
+
+	go ag.run(d.notify)
+}
+
+// insertOverflow inserts the alert into the overflow group of the route,
+// creating the group if needed. Overflow groups are not subject to any limit.
+func (d *Dispatcher) insertOverflow(alert *types.Alert, route *Route, routeGroups *routeGroups) {
+	if ag, ok := routeGroups.get(overflowFingerprint); ok && ag.overflow {
+		ag.insert(alert)
+		return
+	}
+
+	ag := newAggrGroup(d.ctx, overflowLabels.Clone(), route, d.timeout, d.logger)
+	ag.overflow = true
+	d.metrics.aggrGroups.Inc()
+
 	ag.insert(alert)
+	routeGroups.set(overflowFingerprint, ag)
+
+	go ag.run(d.notify)
+}
+
+// leaveOverflow removes the alert from the overflow group of the route, if
+// any, once the alert fits into a regular group again, so that it isn't
+// notified by both groups.
+func (d *Dispatcher) leaveOverflow(alert *types.Alert, routeGroups *routeGroups) {
+	ag, ok := routeGroups.get(overflowFingerprint)
+	if !ok || !ag.overflow || !ag.contains(alert) {
+		return
+	}
+	if err := ag.alerts.Delete(alert.Fingerprint()); err != nil {
+		level.Error(d.logger).Log("msg", "error on delete alert from overflow group", "err", err, "alert", alert.String())
+	}
+}
+
+// groupsOf returns the aggregation groups of the route, creating them if
+// needed.
+func (d *Dispatcher) groupsOf(route *Route) *routeGroups {
+	d.mtx.RLock()
+	rg, ok := d.aggrGroupsPerRoute[route]
+	d.mtx.RUnlock()
+	if ok {
+		return rg
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if rg, ok = d.aggrGroupsPerRoute[route]; !ok {
+		rg = newRouteGroups()
+		d.aggrGroupsPerRoute[route] = rg
+	}
+	return rg
+}
+
+// routeGroups returns a snapshot of the routes and their aggregation groups.
+func (d *Dispatcher) routeGroups() map[*Route]*routeGroups {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	res := make(map[*Route]*routeGroups, len(d.aggrGroupsPerRoute))
+	for route, rg := range d.aggrGroupsPerRoute {
+		res[route] = rg
+	}
+	return res
+}
+
+// maxAlertsPerGroup returns the alert limit for the groups of the given
+// route. The route option takes precedence over the dispatcher limits.
+func (d *Dispatcher) maxAlertsPerGroup(route *Route) int {
+	if route.RouteOpts.MaxAlertsPerGroup > 0 {
+		return route.RouteOpts.MaxAlertsPerGroup
+	}
+	return d.limits.MaxNumberOfAlertsPerAggregationGroup()
+}
+
+// aggrGroupShards is the number of partitions of the aggregation groups of a
+// route. It must be a power of two.
+const aggrGroupShards = 32
+
+// routeGroups holds the aggregation groups of a route partitioned by their
+// fingerprint, so that ingestion only locks a single shard and readers
+// iterate over snapshots instead of holding locks.
+type routeGroups struct {
+	shards [aggrGroupShards]aggrGroupShard
+}
+
+type aggrGroupShard struct {
+	mtx    sync.RWMutex
+	groups map[model.Fingerprint]*aggrGroup
+}
+
+func newRouteGroups() *routeGroups {
+	rg := &routeGroups{}
+	for i := range rg.shards {
+		rg.shards[i].groups = map[model.Fingerprint]*aggrGroup{}
+	}
+	return rg
+}
+
+func (rg *routeGroups) shard(fp model.Fingerprint) *aggrGroupShard {
+	return &rg.shards[uint64(fp)&(aggrGroupShards-1)]
+}
+
+// get returns the aggregation group with the given fingerprint.
+func (rg *routeGroups) get(fp model.Fingerprint) (*aggrGroup, bool) {
+	sh := rg.shard(fp)
+	sh.mtx.RLock()
+	defer sh.mtx.RUnlock()
+
+	ag, ok := sh.groups[fp]
+	return ag, ok
+}
+
+// set adds the aggregation group with the given fingerprint.
+func (rg *routeGroups) set(fp model.Fingerprint, ag *aggrGroup) {
+	sh := rg.shard(fp)
+	sh.mtx.Lock()
+	defer sh.mtx.Unlock()
+
+	sh.groups[fp] = ag
+}
+
+// list returns a snapshot of the aggregation groups.
+func (rg *routeGroups) list() []*aggrGroup {
+	var res []*aggrGroup
+	for i := range rg.shards {
+		sh := &rg.shards[i]
+		sh.mtx.RLock()
+		for _, ag := range sh.groups {
+			res = append(res, ag)
+		}
+		sh.mtx.RUnlock()
+	}
+	return res
+}
+
+// len returns the number of aggregation groups, not counting the overflow
+// group.
+func (rg *routeGroups) len() int {
+	var n int
+	for i := range rg.shards {
+		sh := &rg.shards[i]
+		sh.mtx.RLock()
+		n += len(sh.groups)
+		if ag, ok := sh.groups[overflowFingerprint]; ok && ag.overflow {
+			n--
+		}
+		sh.mtx.RUnlock()
+	}
+	return n
+}
 
-	go ag.run(func(ctx context.Context, alerts ...*types.Alert) bool {
-		_, _, err := d.stage.Exec(ctx, d.logger, alerts...)
-		if err != nil {
-			lvl := level.Error(d.logger)
-			if ctx.Err() == context.Canceled {
-				// It is expected for the context to be canceled on
-				// configuration reload or shutdown. In this case, the
-				// message should only be logged at the debug level.
-				lvl = level.Debug(d.logger)
+// deleteEmpty removes the empty aggregation groups and returns them.
+func (rg *routeGroups) deleteEmpty() []*aggrGroup {
+	var res []*aggrGroup
+	for i := range rg.shards {
+		sh := &rg.shards[i]
+		sh.mtx.Lock()
+		for fp, ag := range sh.groups {
+			if ag.empty() {
+				delete(sh.groups, fp)
+				res = append(res, ag)
 			}
-			lvl.Log("msg", "Notify for alerts failed", "num_alerts", len(alerts), "err", err)
 		}
-		return err == nil
-	})
+		sh.mtx.Unlock()
+	}
+	return res
+}
+
+// evaluateStorm updates the storm mode and sends the digests which are due.
+func (d *Dispatcher) evaluateStorm(now time.Time) {
+	if d.storm.evaluate(now) {
+		if d.storm.status().Active {
+			level.Warn(d.logger).Log("msg", "Entering storm mode, notifications are coalesced into digests")
+			d.metrics.stormMode.Set(1)
+			d.metrics.stormModeTransitions.WithLabelValues("storm").Inc()
+		} else {
+			level.Info(d.logger).Log("msg", "Leaving storm mode")
+			d.metrics.stormMode.Set(0)
+			d.metrics.stormModeTransitions.WithLabelValues("normal").Inc()
+		}
+	}
+
+	for receiver, digest := range d.storm.dueDigests(now) {
+		go d.sendDigest(receiver, digest, now)
+	}
+}
+
+// sendDigest notifies the receiver about the alerts collected in the digest.
+func (d *Dispatcher) sendDigest(receiver string, digest *stormDigest, now time.Time) {
+	interval := time.Duration(d.storm.conf.DigestInterval)
+	timeout := interval
+	if d.timeout != nil {
+		timeout = d.timeout(interval)
+	}
+	ctx, cancel := context.WithTimeout(d.ctx, timeout)
+	defer cancel()
+
+	ctx = notify.WithNow(ctx, now)
+	// Every digest has its own group key so that it is never deduplicated
+	// against the previous one.
+	ctx = notify.WithGroupKey(ctx, fmt.Sprintf("storm:%s:%d", receiver, digest.start.Unix()))
+	ctx = notify.WithGroupLabels(ctx, stormDigestLabels)
+	ctx = notify.WithReceiverName(ctx, receiver)
+	ctx = notify.WithRepeatInterval(ctx, interval)
+
+	if d.exec(ctx, digest.alert(now, d.storm.conf.TopGroups)) {
+		d.metrics.stormDigests.Inc()
+	}
+}
+
+func (d *Dispatcher) notify(ctx context.Context, alerts ...*types.Alert) bool {
+	// In storm mode, the alerts pass the muting and deduplication stages and
+	// are then collected into the digest of the receiver.
+	if d.storm != nil && d.storm.observeFlush(time.Now()) {
+		ctx = notify.WithDigester(ctx, d.storm)
+	}
+	return d.exec(ctx, alerts...)
+}
+
+func (d *Dispatcher) exec(ctx context.Context, alerts ...*types.Alert) bool {
+	_, _, err := d.stage.Exec(ctx, d.logger, alerts...)
+	if err != nil {
+		lvl := level.Error(d.logger)
+		if ctx.Err() == context.Canceled {
+			// It is expected for the context to be canceled on
+			// configuration reload or shutdown. In this case, the
+			// message should only be logged at the debug level.
+			lvl = level.Debug(d.logger)
+		}
+		lvl.Log("msg", "Notify for alerts failed", "num_alerts", len(alerts), "err", err)
+	}
+	return err == nil
 }
 
 func getGroupLabels(alert *types.Alert, route *Route) model.LabelSet {
@@ -384,6 +692,10 @@ type aggrGroup struct {
 
 	mtx        sync.RWMutex
 	hasFlushed bool
+
+	// overflow is true for the group collecting the alerts of a route that
+	// exceed the dispatcher limits.
+	overflow bool
 }
 
 // newAggrGroup returns a new aggregation group.
@@ -443,13 +755,16 @@ func (ag *aggrGroup) run(nf notifyFunc) {
 			ctx = notify.WithGroupKey(ctx, ag.GroupKey())
 			ctx = notify.WithGroupLabels(ctx, ag.labels)
 			ctx = notify.WithReceiverName(ctx, ag.opts.Receiver)
-			ctx = notify.WithRepeatInterval(ctx, ag.opts.RepeatInterval)
+			ctx = notify.WithRepeatInterval(ctx, ag.repeatInterval(now))
 			ctx = notify.WithMuteTimeIntervals(ctx, ag.opts.MuteTimeIntervals)
 			ctx = notify.WithActiveTimeIntervals(ctx, ag.opts.ActiveTimeIntervals)
+			ctx = notify.WithEnricherName(ctx, ag.opts.Enricher)
+			ctx = notify.WithNotifyMode(ctx, ag.opts.NotifyMode)
+			ctx = notify.WithTimeIntervalMode(ctx, ag.opts.TimeIntervalMode)
 
 			// Wait the configured interval before calling flush again.
 			ag.mtx.Lock()
-			ag.next.Reset(ag.opts.GroupInterval)
+			ag.next.Reset(ag.groupInterval(now))
 			ag.hasFlushed = true
 			ag.mtx.Unlock()
 
@@ -491,6 +806,16 @@ func (ag *aggrGroup) empty() bool {
 	return ag.alerts.Empty()
 }
 
+func (ag *aggrGroup) len() int {
+	return ag.alerts.Len()
+}
+
+// contains returns true if the alert is already part of the aggregation group.
+func (ag *aggrGroup) contains(alert *types.Alert) bool {
+	_, err := ag.alerts.Get(alert.Fingerprint())
+	return err == nil
+}
+
 // flush sends notifications for all new alerts.
 func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
 	if ag.empty() {
@@ -536,4 +861,23 @@ func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
 
 type nilLimits struct{}
 
-func (n nilLimits) MaxNumberOfAggregationGroups() int { return 0 }
+func (n nilLimits) MaxNumberOfAggregationGroups() int         { return 0 }
+func (n nilLimits) MaxNumberOfAlertsPerAggregationGroup() int { return 0 }
+
+// NewConfigLimits returns Limits based on the limits configuration. A nil
+// configuration means no limits.
+func NewConfigLimits(c *config.Limits) Limits {
+	if c == nil {
+		return nilLimits{}
+	}
+	return configLimits{c: *c}
+}
+
+type configLimits struct {
+	c config.Limits
+}
+
+func (l configLimits) MaxNumberOfAggregationGroups() int { return l.c.MaxAggregationGroups }
+func (l configLimits) MaxNumberOfAlertsPerAggregationGroup() int {
+	return l.c.MaxAlertsPerGroup
+}
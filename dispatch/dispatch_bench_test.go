@@ -0,0 +1,174 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatch
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	benchmarkAlerts = 50000
+	benchmarkGroups = 1000
+)
+
+// newBenchmarkDispatcher returns a dispatcher holding benchmarkAlerts alerts
+// spread over benchmarkGroups groups, and the alerts. The groups never flush
+// during the benchmarks.
+func newBenchmarkDispatcher(b *testing.B) (*Dispatcher, *Route, []*types.Alert) {
+	conf, err := config.Load(`
+receivers:
+- name: 'default'
+
+route:
+  receiver: 'default'
+  group_by: ['group']
+  group_wait: 1h
+  group_interval: 1h
+`)
+	if err != nil {
+		b.Fatal(err)
+	}
+	route := NewRoute(conf.Route, nil)
+	d := NewDispatcher(
+		nil,
+		route,
+		&recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)},
+		types.NewMarker(prometheus.NewRegistry()),
+		nil,
+		nil,
+		log.NewNopLogger(),
+		NewDispatcherMetrics(false, prometheus.NewRegistry()),
+	)
+	d.aggrGroupsPerRoute = map[*Route]*routeGroups{}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	b.Cleanup(d.cancel)
+
+	now := time.Now()
+	alerts := make([]*types.Alert, 0, benchmarkAlerts)
+	for i := 0; i < benchmarkAlerts; i++ {
+		a := &types.Alert{
+			Alert: model.Alert{
+				Labels: model.LabelSet{
+					"alertname": "BenchmarkAlert",
+					"group":     model.LabelValue(strconv.Itoa(i % benchmarkGroups)),
+					"instance":  model.LabelValue(strconv.Itoa(i)),
+				},
+				StartsAt: now,
+				EndsAt:   now.Add(time.Hour),
+			},
+			UpdatedAt: now,
+		}
+		alerts = append(alerts, a)
+		d.processAlert(a, route)
+	}
+	return d, route, alerts
+}
+
+// queryGroups calls Groups in a loop until stop is closed.
+func queryGroups(d *Dispatcher, stop <-chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		d.Groups(
+			func(*Route) bool { return true },
+			func(*types.Alert, time.Time) bool { return true },
+		)
+	}
+}
+
+// BenchmarkProcessAlert measures the ingestion of alert updates, optionally
+// while API clients query the groups concurrently.
+func BenchmarkProcessAlert(b *testing.B) {
+	for _, queriers := range []int{0, 1, 4} {
+		b.Run("queriers="+strconv.Itoa(queriers), func(b *testing.B) {
+			d, route, alerts := newBenchmarkDispatcher(b)
+
+			var (
+				stop = make(chan struct{})
+				wg   sync.WaitGroup
+			)
+			for i := 0; i < queriers; i++ {
+				wg.Add(1)
+				go queryGroups(d, stop, &wg)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				d.processAlert(alerts[i%len(alerts)], route)
+			}
+			b.StopTimer()
+
+			close(stop)
+			wg.Wait()
+		})
+	}
+}
+
+// BenchmarkGroups measures the queries of the groups, optionally while
+// alerts are ingested concurrently.
+func BenchmarkGroups(b *testing.B) {
+	for _, ingest := range []bool{false, true} {
+		b.Run("ingest="+strconv.FormatBool(ingest), func(b *testing.B) {
+			d, route, alerts := newBenchmarkDispatcher(b)
+
+			var (
+				stop = make(chan struct{})
+				wg   sync.WaitGroup
+			)
+			if ingest {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; ; i++ {
+						select {
+						case <-stop:
+							return
+						default:
+						}
+						d.processAlert(alerts[i%len(alerts)], route)
+					}
+				}()
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				d.Groups(
+					func(*Route) bool { return true },
+					func(*types.Alert, time.Time) bool { return true },
+				)
+			}
+			b.StopTimer()
+
+			close(stop)
+			wg.Wait()
+		})
+	}
+}
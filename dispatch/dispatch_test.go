@@ -549,23 +549,213 @@ route:
 	alertGroups, _ := dispatcher.Groups(routeFilter, alertFilter)
 	require.Len(t, alertGroups, 6)
 
-	require.Equal(t, 0.0, testutil.ToFloat64(m.aggrGroupLimitReached))
+	prodRoute := route.Routes[1]
+	limitReached := m.aggrGroupLimitReached.WithLabelValues(prodRoute.Key())
+	require.Equal(t, 0.0, testutil.ToFloat64(limitReached))
 
 	// Try to store new alert. This time, we will hit limit for number of groups.
-	err = alerts.Put(newAlert(model.LabelSet{"env": "prod", "alertname": "NewAlert", "cluster": "new-cluster", "service": "db"}))
+	newAlert := newAlert(model.LabelSet{"env": "prod", "alertname": "NewAlert", "cluster": "new-cluster", "service": "db"})
+	err = alerts.Put(newAlert)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// Let alert get processed.
-	for i := 0; testutil.ToFloat64(m.aggrGroupLimitReached) == 0 && i < 10; i++ {
+	for i := 0; testutil.ToFloat64(limitReached) == 0 && i < 10; i++ {
 		time.Sleep(200 * time.Millisecond)
 	}
-	require.Equal(t, 1.0, testutil.ToFloat64(m.aggrGroupLimitReached))
+	require.Equal(t, 1.0, testutil.ToFloat64(limitReached))
 
-	// Verify there are still only 6 groups.
+	// Verify the alert has been added to the overflow group of its route
+	// instead of being dropped.
 	alertGroups, _ = dispatcher.Groups(routeFilter, alertFilter)
-	require.Len(t, alertGroups, 6)
+	require.Len(t, alertGroups, 7)
+	var overflow *AlertGroup
+	for _, ag := range alertGroups {
+		if ag.Labels.Equal(overflowLabels) {
+			overflow = ag
+		}
+	}
+	require.NotNil(t, overflow)
+	require.Equal(t, "prod", overflow.Receiver)
+	require.Equal(t, types.AlertSlice{newAlert}, overflow.Alerts)
+}
+
+func TestGroupsWithRouteLimits(t *testing.T) {
+	confData := `receivers:
+- name: 'prod'
+- name: 'testing'
+
+limits:
+  max_alerts_per_group: 2
+
+route:
+  group_by: ['alertname']
+  group_wait: 10ms
+  group_interval: 10ms
+  receiver: 'prod'
+  routes:
+  - match:
+      env: 'testing'
+    receiver: 'testing'
+    max_aggregation_groups: 1
+    max_alerts_per_group: 1`
+	conf, err := config.Load(confData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := log.NewNopLogger()
+	route := NewRoute(conf.Route, nil)
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer alerts.Close()
+
+	timeout := func(d time.Duration) time.Duration { return time.Duration(0) }
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	m := NewDispatcherMetrics(true, prometheus.NewRegistry())
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, NewConfigLimits(conf.Limits), logger, m)
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	inputAlerts := []*types.Alert{
+		// Matches the root route, the third alert exceeds the global alert limit.
+		newAlert(model.LabelSet{"alertname": "OtherAlert", "instance": "inst1"}),
+		newAlert(model.LabelSet{"alertname": "OtherAlert", "instance": "inst2"}),
+		newAlert(model.LabelSet{"alertname": "OtherAlert", "instance": "inst3"}),
+		// Matches the sub-route, the second alert exceeds the route alert
+		// limit and the third one the route group limit.
+		newAlert(model.LabelSet{"env": "testing", "alertname": "TestingAlert", "instance": "inst1"}),
+		newAlert(model.LabelSet{"env": "testing", "alertname": "TestingAlert", "instance": "inst2"}),
+		newAlert(model.LabelSet{"env": "testing", "alertname": "OtherTestingAlert", "instance": "inst1"}),
+	}
+	// Insert the alerts one by one since the limits depend on the order
+	// of ingestion.
+	for n, a := range inputAlerts {
+		if err := alerts.Put(a); err != nil {
+			t.Fatal(err)
+		}
+		// Let alert get processed.
+		for i := 0; len(recorder.Alerts()) != n+1 && i < 10; i++ {
+			time.Sleep(200 * time.Millisecond)
+		}
+		require.Equal(t, n+1, len(recorder.Alerts()))
+	}
+
+	routeFilter := func(*Route) bool { return true }
+	alertFilter := func(*types.Alert, time.Time) bool { return true }
+
+	alertGroups, _ := dispatcher.Groups(routeFilter, alertFilter)
+	require.Equal(t, AlertGroups{
+		&AlertGroup{
+			Alerts:   []*types.Alert{inputAlerts[0], inputAlerts[1]},
+			Labels:   model.LabelSet{"alertname": "OtherAlert"},
+			Receiver: "prod",
+		},
+		&AlertGroup{
+			Alerts:   []*types.Alert{inputAlerts[3]},
+			Labels:   model.LabelSet{"alertname": "TestingAlert"},
+			Receiver: "testing",
+		},
+		&AlertGroup{
+			Alerts:   []*types.Alert{inputAlerts[2]},
+			Labels:   overflowLabels,
+			Receiver: "prod",
+		},
+		&AlertGroup{
+			Alerts:   []*types.Alert{inputAlerts[5], inputAlerts[4]},
+			Labels:   overflowLabels,
+			Receiver: "testing",
+		},
+	}, alertGroups)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.alertLimitReached.WithLabelValues(route.Key())))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.alertLimitReached.WithLabelValues(route.Routes[0].Key())))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.aggrGroupLimitReached.WithLabelValues(route.Routes[0].Key())))
+}
+
+func TestOverflowAlertLeavesOverflowGroup(t *testing.T) {
+	confData := `receivers:
+- name: 'prod'
+
+route:
+  group_by: ['alertname']
+  group_wait: 10ms
+  group_interval: 10ms
+  receiver: 'prod'
+  max_alerts_per_group: 1`
+	conf, err := config.Load(confData)
+	require.NoError(t, err)
+
+	logger := log.NewNopLogger()
+	route := NewRoute(conf.Route, nil)
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	timeout := func(d time.Duration) time.Duration { return time.Duration(0) }
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	routeFilter := func(*Route) bool { return true }
+	alertFilter := func(*types.Alert, time.Time) bool { return true }
+	groups := func() AlertGroups {
+		alertGroups, _ := dispatcher.Groups(routeFilter, alertFilter)
+		return alertGroups
+	}
+
+	// The second alert exceeds the alert limit of the group.
+	a1 := newAlert(model.LabelSet{"alertname": "TestAlert", "instance": "inst1"})
+	a2 := newAlert(model.LabelSet{"alertname": "TestAlert", "instance": "inst2"})
+	for n, a := range []*types.Alert{a1, a2} {
+		require.NoError(t, alerts.Put(a))
+		for i := 0; len(recorder.Alerts()) != n+1 && i < 10; i++ {
+			time.Sleep(200 * time.Millisecond)
+		}
+		require.Equal(t, n+1, len(recorder.Alerts()))
+	}
+	require.Equal(t, AlertGroups{
+		&AlertGroup{
+			Alerts:   []*types.Alert{a1},
+			Labels:   model.LabelSet{"alertname": "TestAlert"},
+			Receiver: "prod",
+		},
+		&AlertGroup{
+			Alerts:   []*types.Alert{a2},
+			Labels:   overflowLabels,
+			Receiver: "prod",
+		},
+	}, groups())
+
+	// Once the group has room again, the alert joins it and leaves the
+	// overflow group.
+	ag, ok := dispatcher.groupsOf(route).get(model.LabelSet{"alertname": "TestAlert"}.Fingerprint())
+	require.True(t, ok)
+	require.NoError(t, ag.alerts.Delete(a1.Fingerprint()))
+
+	a2 = newAlert(a2.Labels)
+	a2.UpdatedAt = t1
+	require.NoError(t, alerts.Put(a2))
+	joined := func() bool {
+		g := groups()
+		return len(g) == 1 && !g[0].Labels.Equal(overflowLabels)
+	}
+	for i := 0; !joined() && i < 10; i++ {
+		time.Sleep(200 * time.Millisecond)
+	}
+	require.Equal(t, AlertGroups{
+		&AlertGroup{
+			Alerts:   []*types.Alert{a2},
+			Labels:   model.LabelSet{"alertname": "TestAlert"},
+			Receiver: "prod",
+		},
+	}, groups())
 }
 
 type recordStage struct {
@@ -691,3 +881,7 @@ type limits struct {
 func (l limits) MaxNumberOfAggregationGroups() int {
 	return l.groups
 }
+
+func (l limits) MaxNumberOfAlertsPerAggregationGroup() int {
+	return 0
+}
@@ -88,6 +88,27 @@ func NewRoute(cr *config.Route, parent *Route) *Route {
 	if cr.RepeatInterval != nil {
 		opts.RepeatInterval = time.Duration(*cr.RepeatInterval)
 	}
+	if cr.AlignIntervals != nil {
+		opts.AlignIntervals = *cr.AlignIntervals
+	}
+	if cr.AlignLocation != nil {
+		opts.AlignLocation = cr.AlignLocation.Location
+	}
+	if cr.Enricher != "" {
+		opts.Enricher = cr.Enricher
+	}
+	if cr.NotifyMode != "" {
+		opts.NotifyMode = cr.NotifyMode
+	}
+	if cr.TimeIntervalMode != "" {
+		opts.TimeIntervalMode = cr.TimeIntervalMode
+	}
+	if cr.MaxAggregationGroups != nil {
+		opts.MaxAggregationGroups = *cr.MaxAggregationGroups
+	}
+	if cr.MaxAlertsPerGroup != nil {
+		opts.MaxAlertsPerGroup = *cr.MaxAlertsPerGroup
+	}
 
 	// Build matchers.
 	var matchers labels.Matchers
@@ -206,11 +227,31 @@ type RouteOpts struct {
 	GroupInterval  time.Duration
 	RepeatInterval time.Duration
 
+	// Whether group flushes and repeated notifications are aligned to
+	// wall-clock boundaries in the location, UTC if nil.
+	AlignIntervals bool
+	AlignLocation  *time.Location
+
 	// A list of time intervals for which the route is muted.
 	MuteTimeIntervals []string
 
 	// A list of time intervals for which the route is active.
 	ActiveTimeIntervals []string
+
+	// The name of the enricher called before notifying the receiver.
+	Enricher string
+
+	// Which alerts of a group are notified.
+	NotifyMode config.NotifyMode
+
+	// Whether notifications suppressed by time intervals are dropped or
+	// deferred.
+	TimeIntervalMode config.TimeIntervalMode
+
+	// The maximum number of aggregation groups and of alerts per
+	// aggregation group of the route. 0 means unlimited.
+	MaxAggregationGroups int
+	MaxAlertsPerGroup    int
 }
 
 func (ro *RouteOpts) String() string {
@@ -383,6 +383,76 @@ routes:
 	require.Equal(t, child2.RouteOpts.GroupByAll, false)
 }
 
+func TestInheritParentNotifyModes(t *testing.T) {
+	in := `
+routes:
+- match:
+    env: 'parent'
+  notify_mode: delta
+  time_interval_mode: defer
+
+  routes:
+  - match:
+      env: 'child1'
+
+  - match:
+      env: 'child2'
+    notify_mode: full
+    time_interval_mode: drop
+`
+
+	var ctree config.Route
+	if err := yaml.UnmarshalStrict([]byte(in), &ctree); err != nil {
+		t.Fatal(err)
+	}
+
+	tree := NewRoute(&ctree, nil)
+	parent := tree.Routes[0]
+	child1 := parent.Routes[0]
+	child2 := parent.Routes[1]
+	require.Equal(t, config.NotifyMode(""), tree.RouteOpts.NotifyMode)
+	require.Equal(t, config.NotifyModeDelta, parent.RouteOpts.NotifyMode)
+	require.Equal(t, config.NotifyModeDelta, child1.RouteOpts.NotifyMode)
+	require.Equal(t, config.NotifyModeFull, child2.RouteOpts.NotifyMode)
+	require.Equal(t, config.TimeIntervalMode(""), tree.RouteOpts.TimeIntervalMode)
+	require.Equal(t, config.TimeIntervalModeDefer, child1.RouteOpts.TimeIntervalMode)
+	require.Equal(t, config.TimeIntervalModeDrop, child2.RouteOpts.TimeIntervalMode)
+}
+
+func TestInheritParentAlignIntervals(t *testing.T) {
+	in := `
+routes:
+- match:
+    env: 'parent'
+  align_intervals: true
+  align_location: Europe/Berlin
+
+  routes:
+  - match:
+      env: 'child1'
+
+  - match:
+      env: 'child2'
+    align_intervals: false
+`
+
+	var ctree config.Route
+	if err := yaml.UnmarshalStrict([]byte(in), &ctree); err != nil {
+		t.Fatal(err)
+	}
+
+	tree := NewRoute(&ctree, nil)
+	parent := tree.Routes[0]
+	child1 := parent.Routes[0]
+	child2 := parent.Routes[1]
+	require.False(t, tree.RouteOpts.AlignIntervals)
+	require.Nil(t, tree.RouteOpts.AlignLocation)
+	require.True(t, parent.RouteOpts.AlignIntervals)
+	require.True(t, child1.RouteOpts.AlignIntervals)
+	require.Equal(t, "Europe/Berlin", child1.RouteOpts.AlignLocation.String())
+	require.False(t, child2.RouteOpts.AlignIntervals)
+}
+
 func TestRouteMatchers(t *testing.T) {
 	in := `
 receiver: 'notify-def'
@@ -0,0 +1,324 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// StormLabel identifies the alerts sent as digests while the dispatcher is in
+// storm mode.
+const StormLabel = model.LabelName("alertmanager_storm")
+
+// StormAlertName is the alertname of storm digests.
+const StormAlertName = "AlertStorm"
+
+const (
+	// stormBucketWidth is the resolution of the rate measurements.
+	stormBucketWidth = 10 * time.Second
+	// stormBuckets is the number of buckets covering one minute.
+	stormBuckets = int64(time.Minute / stormBucketWidth)
+	// stormEvalInterval is the interval at which the storm mode is evaluated.
+	stormEvalInterval = stormBucketWidth
+)
+
+var stormDigestLabels = model.LabelSet{StormLabel: "digest"}
+
+// StormStatus describes the storm mode of a dispatcher.
+type StormStatus struct {
+	// Active is true while the dispatcher is in storm mode.
+	Active bool
+	// Since is the time of the last mode change. It is zero if the mode
+	// never changed.
+	Since time.Time
+}
+
+// rateCounter counts events over the last minute.
+type rateCounter struct {
+	buckets [stormBuckets]int
+	current int64
+}
+
+func (r *rateCounter) add(now time.Time) {
+	r.advance(now)
+	r.buckets[r.current%stormBuckets]++
+}
+
+// perMinute returns the number of events within the last minute.
+func (r *rateCounter) perMinute(now time.Time) int {
+	r.advance(now)
+	sum := 0
+	for _, b := range r.buckets {
+		sum += b
+	}
+	return sum
+}
+
+// advance clears the buckets which fell out of the last minute.
+func (r *rateCounter) advance(now time.Time) {
+	idx := now.UnixNano() / int64(stormBucketWidth)
+	if idx <= r.current {
+		return
+	}
+	for i := r.current + 1; i <= idx && i <= r.current+stormBuckets; i++ {
+		r.buckets[i%stormBuckets] = 0
+	}
+	r.current = idx
+}
+
+// storm detects alert storms and collects the digests of receivers while the
+// storm lasts.
+type storm struct {
+	conf config.StormModeConfig
+
+	mtx        sync.Mutex
+	alerts     rateCounter
+	flushes    rateCounter
+	active     bool
+	since      time.Time
+	calmSince  time.Time
+	lastDigest time.Time
+	digests    map[string]*stormDigest
+}
+
+func newStorm(conf config.StormModeConfig) *storm {
+	return &storm{
+		conf:    conf,
+		digests: map[string]*stormDigest{},
+	}
+}
+
+func (s *storm) observeAlert(now time.Time) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.alerts.add(now)
+}
+
+// observeFlush records the flush of an aggregation group and returns whether
+// storm mode is active.
+func (s *storm) observeFlush(now time.Time) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.flushes.add(now)
+	return s.active
+}
+
+// evaluate updates the mode according to the current rates and returns
+// whether it changed.
+func (s *storm) evaluate(now time.Time) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var (
+		alerts   = s.alerts.perMinute(now)
+		flushes  = s.flushes.perMinute(now)
+		exceeded = (s.conf.AlertsPerMinute > 0 && alerts > s.conf.AlertsPerMinute) ||
+			(s.conf.FlushesPerMinute > 0 && flushes > s.conf.FlushesPerMinute)
+	)
+	if exceeded {
+		s.calmSince = time.Time{}
+		if s.active {
+			return false
+		}
+		s.active = true
+		s.since = now
+		s.lastDigest = now
+		return true
+	}
+
+	if !s.active {
+		return false
+	}
+	if s.calmSince.IsZero() {
+		s.calmSince = now
+	}
+	if now.Sub(s.calmSince) < time.Duration(s.conf.Cooldown) {
+		return false
+	}
+	s.active = false
+	s.since = now
+	return true
+}
+
+// dueDigests returns the collected digests once the digest interval elapsed.
+// Outside of storm mode, the remaining digests are returned immediately.
+func (s *storm) dueDigests(now time.Time) map[string]*stormDigest {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if len(s.digests) == 0 {
+		return nil
+	}
+	if s.active && now.Sub(s.lastDigest) < time.Duration(s.conf.DigestInterval) {
+		return nil
+	}
+	digests := s.digests
+	s.digests = map[string]*stormDigest{}
+	s.lastDigest = now
+	return digests
+}
+
+func (s *storm) status() StormStatus {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return StormStatus{Active: s.active, Since: s.since}
+}
+
+// Digest implements the notify.Digester interface.
+func (s *storm) Digest(ctx context.Context, alerts ...*types.Alert) {
+	receiver, _ := notify.ReceiverName(ctx)
+	groupKey, _ := notify.GroupKey(ctx)
+	groupLabels, _ := notify.GroupLabels(ctx)
+	now, ok := notify.Now(ctx)
+	if !ok {
+		now = time.Now()
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	d, ok := s.digests[receiver]
+	if !ok {
+		d = newStormDigest(now)
+		s.digests[receiver] = d
+	}
+	d.add(groupKey, groupLabels, alerts...)
+}
+
+// stormDigest summarises the alerts flushed for a receiver during storm mode.
+type stormDigest struct {
+	start  time.Time
+	alerts map[model.Fingerprint]*types.Alert
+	groups map[string]*stormDigestGroup
+}
+
+type stormDigestGroup struct {
+	labels model.LabelSet
+	alerts map[model.Fingerprint]struct{}
+}
+
+func newStormDigest(start time.Time) *stormDigest {
+	return &stormDigest{
+		start:  start,
+		alerts: map[model.Fingerprint]*types.Alert{},
+		groups: map[string]*stormDigestGroup{},
+	}
+}
+
+func (d *stormDigest) add(groupKey string, groupLabels model.LabelSet, alerts ...*types.Alert) {
+	g, ok := d.groups[groupKey]
+	if !ok {
+		g = &stormDigestGroup{labels: groupLabels, alerts: map[model.Fingerprint]struct{}{}}
+		d.groups[groupKey] = g
+	}
+	for _, a := range alerts {
+		fp := a.Fingerprint()
+		d.alerts[fp] = a
+		g.alerts[fp] = struct{}{}
+	}
+}
+
+// alert returns the alert notifying about the digest. It lists the number of
+// alerts by alertname and severity and the largest groups.
+func (d *stormDigest) alert(now time.Time, topGroups int) *types.Alert {
+	type count struct {
+		key              string
+		firing, resolved int
+	}
+	var (
+		counts           = map[string]*count{}
+		firing, resolved int
+	)
+	for _, a := range d.alerts {
+		key := fmt.Sprintf("alertname=%q, severity=%q", a.Labels[model.AlertNameLabel], a.Labels["severity"])
+		c, ok := counts[key]
+		if !ok {
+			c = &count{key: key}
+			counts[key] = c
+		}
+		if a.ResolvedAt(now) {
+			c.resolved++
+			resolved++
+		} else {
+			c.firing++
+			firing++
+		}
+	}
+	sortedCounts := make([]*count, 0, len(counts))
+	for _, c := range counts {
+		sortedCounts = append(sortedCounts, c)
+	}
+	sort.Slice(sortedCounts, func(i, j int) bool {
+		ci, cj := sortedCounts[i], sortedCounts[j]
+		if ci.firing+ci.resolved != cj.firing+cj.resolved {
+			return ci.firing+ci.resolved > cj.firing+cj.resolved
+		}
+		return ci.key < cj.key
+	})
+	var countLines []string
+	for _, c := range sortedCounts {
+		countLines = append(countLines, fmt.Sprintf("%s: %d firing, %d resolved", c.key, c.firing, c.resolved))
+	}
+
+	groups := make([]*stormDigestGroup, 0, len(d.groups))
+	for _, g := range d.groups {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].alerts) != len(groups[j].alerts) {
+			return len(groups[i].alerts) > len(groups[j].alerts)
+		}
+		return groups[i].labels.Before(groups[j].labels)
+	})
+	if len(groups) > topGroups {
+		groups = groups[:topGroups]
+	}
+	var groupLines []string
+	for _, g := range groups {
+		groupLines = append(groupLines, fmt.Sprintf("%s: %d alerts", g.labels, len(g.alerts)))
+	}
+
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{
+				model.AlertNameLabel: StormAlertName,
+				StormLabel:           "digest",
+			},
+			Annotations: model.LabelSet{
+				"summary": model.LabelValue(fmt.Sprintf(
+					"Alert storm: %d firing and %d resolved alerts in %d groups since %s",
+					firing, resolved, len(d.groups), d.start.UTC().Format(time.RFC3339),
+				)),
+				"counts":     model.LabelValue(strings.Join(countLines, "\n")),
+				"top_groups": model.LabelValue(strings.Join(groupLines, "\n")),
+			},
+			StartsAt: d.start,
+		},
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestStormConfig() config.StormModeConfig {
+	conf := config.DefaultStormModeConfig
+	conf.FlushesPerMinute = 2
+	conf.Cooldown = model.Duration(time.Minute)
+	return conf
+}
+
+func TestStormEvaluate(t *testing.T) {
+	s := newStorm(newTestStormConfig())
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		require.False(t, s.observeFlush(now))
+	}
+	require.True(t, s.evaluate(now))
+	require.Equal(t, StormStatus{Active: true, Since: now}, s.status())
+
+	// The flushes are still within the last minute.
+	require.False(t, s.evaluate(now.Add(30*time.Second)))
+	require.True(t, s.observeFlush(now.Add(30*time.Second)))
+
+	// The rate dropped, but storm mode is only left after the cooldown.
+	calm := now.Add(100 * time.Second)
+	require.False(t, s.evaluate(calm))
+	require.True(t, s.status().Active)
+	require.True(t, s.evaluate(calm.Add(time.Minute)))
+	require.Equal(t, StormStatus{Active: false, Since: calm.Add(time.Minute)}, s.status())
+}
+
+func TestStormEvaluateAlertsPerMinute(t *testing.T) {
+	conf := newTestStormConfig()
+	conf.FlushesPerMinute = 0
+	conf.AlertsPerMinute = 5
+	s := newStorm(conf)
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		s.observeAlert(now)
+		s.observeFlush(now)
+	}
+	require.False(t, s.evaluate(now))
+
+	s.observeAlert(now)
+	require.True(t, s.evaluate(now))
+	require.True(t, s.status().Active)
+}
+
+func TestStormDigest(t *testing.T) {
+	conf := newTestStormConfig()
+	conf.TopGroups = 1
+	s := newStorm(conf)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		s.observeFlush(now)
+	}
+	require.True(t, s.evaluate(now))
+
+	digest := func(groupLabels model.LabelSet, alerts ...*types.Alert) {
+		ctx := notify.WithReceiverName(context.Background(), "team-db")
+		ctx = notify.WithGroupKey(ctx, groupLabels.String())
+		ctx = notify.WithGroupLabels(ctx, groupLabels)
+		ctx = notify.WithNow(ctx, now)
+		s.Digest(ctx, alerts...)
+	}
+	resolved := newAlert(model.LabelSet{"alertname": "DBDown", "severity": "critical", "instance": "3"})
+	resolved.EndsAt = now.Add(-time.Minute)
+	digest(model.LabelSet{"cluster": "a"},
+		newAlert(model.LabelSet{"alertname": "DBDown", "severity": "critical", "instance": "1"}),
+		newAlert(model.LabelSet{"alertname": "DBDown", "severity": "critical", "instance": "2"}),
+		resolved,
+	)
+	digest(model.LabelSet{"cluster": "b"},
+		newAlert(model.LabelSet{"alertname": "HighLatency", "severity": "warning", "instance": "1"}),
+	)
+	// Alerts flushed again are only counted once.
+	digest(model.LabelSet{"cluster": "b"},
+		newAlert(model.LabelSet{"alertname": "HighLatency", "severity": "warning", "instance": "1"}),
+	)
+
+	require.Nil(t, s.dueDigests(now.Add(time.Minute)))
+
+	digests := s.dueDigests(now.Add(time.Duration(conf.DigestInterval)))
+	require.Len(t, digests, 1)
+	require.Empty(t, s.digests)
+
+	a := digests["team-db"].alert(now, conf.TopGroups)
+	require.Equal(t, model.LabelSet{"alertname": StormAlertName, StormLabel: "digest"}, a.Labels)
+	require.Equal(t,
+		model.LabelValue("Alert storm: 3 firing and 1 resolved alerts in 2 groups since "+now.UTC().Format(time.RFC3339)),
+		a.Annotations["summary"],
+	)
+	require.Equal(t,
+		model.LabelValue("alertname=\"DBDown\", severity=\"critical\": 2 firing, 1 resolved\nalertname=\"HighLatency\", severity=\"warning\": 1 firing, 0 resolved"),
+		a.Annotations["counts"],
+	)
+	require.Equal(t, model.LabelValue("{cluster=\"a\"}: 3 alerts"), a.Annotations["top_groups"])
+}
+
+func TestDispatcherStormMode(t *testing.T) {
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	m := NewDispatcherMetrics(false, prometheus.NewRegistry())
+	d := NewDispatcher(nil, nil, notify.MultiStage{notify.NewDigestStage(), recorder}, nil, nil, nil, log.NewNopLogger(), m)
+	conf := newTestStormConfig()
+	d.SetStormMode(&conf)
+	d.ctx = context.Background()
+
+	now := time.Now()
+	ctx := notify.WithReceiverName(context.Background(), "team-db")
+	ctx = notify.WithGroupKey(ctx, "{}:{cluster=\"a\"}")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"cluster": "a"})
+
+	// The flushes before storm mode notify as usual.
+	for i := 0; i < 3; i++ {
+		require.True(t, d.notify(ctx, newAlert(model.LabelSet{"alertname": "DBDown"})))
+	}
+	require.Len(t, recorder.Alerts(), 1)
+
+	d.evaluateStorm(now)
+	require.True(t, d.StormStatus().Active)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.stormMode))
+
+	// In storm mode, the alerts are collected into the digest.
+	require.True(t, d.notify(ctx, newAlert(model.LabelSet{"alertname": "HighLatency"})))
+	require.Len(t, recorder.Alerts(), 1)
+
+	// Leaving storm mode sends the remaining digest.
+	d.evaluateStorm(now.Add(2 * time.Minute))
+	d.evaluateStorm(now.Add(3 * time.Minute))
+	require.False(t, d.StormStatus().Active)
+	require.Eventually(t, func() bool { return len(recorder.Alerts()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 0.0, testutil.ToFloat64(m.stormMode))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.stormModeTransitions.WithLabelValues("normal")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.stormDigests))
+}
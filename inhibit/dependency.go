@@ -0,0 +1,119 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inhibit
+
+import (
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// A DependencyRule inhibits the alerts of a service while a service it
+// depends on, directly or transitively, has a firing source alert. This
+// avoids expressing each pair of dependent services as an InhibitRule.
+type DependencyRule struct {
+	// The name of the label holding the service of an alert.
+	Label model.LabelName
+	// Upstreams maps each service to the services it depends on.
+	Upstreams map[string][]string
+
+	// The source alerts are cached by a rule whose equal labels include the
+	// service label, so that they can be looked up by upstream service.
+	rule *InhibitRule
+}
+
+// NewDependencyRule returns a new DependencyRule based on a configuration
+// definition and the service dependency graph.
+func NewDependencyRule(c *config.DependenciesConfig, graph map[string][]string) *DependencyRule {
+	equal := make(model.LabelNames, 0, len(c.Equal)+1)
+	equal = append(equal, c.Label)
+	equal = append(equal, c.Equal...)
+
+	return &DependencyRule{
+		Label:     c.Label,
+		Upstreams: graph,
+		rule: NewInhibitRule(config.InhibitRule{
+			SourceMatchers: c.SourceMatchers,
+			TargetMatchers: c.TargetMatchers,
+			Equal:          equal,
+		}),
+	}
+}
+
+// inhibition returns the inhibition of the given label set by the alerts of
+// the services it depends on, if any. The closest upstream service with a
+// firing source alert is reported.
+func (d *DependencyRule) inhibition(lset model.LabelSet, now time.Time) (*types.Inhibition, bool) {
+	service := string(lset[d.Label])
+	if service == "" || len(d.Upstreams[service]) == 0 {
+		return nil, false
+	}
+	if !d.rule.TargetMatchers.Matches(lset) {
+		return nil, false
+	}
+
+	// Walk the graph breadth-first, remembering how each upstream service
+	// was reached to report the dependency path.
+	var (
+		parents = map[string]string{service: ""}
+		queue   = []string{service}
+		lookup  = lset.Clone()
+	)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, upstream := range d.Upstreams[current] {
+			if _, ok := parents[upstream]; ok {
+				continue
+			}
+			parents[upstream] = current
+
+			lookup[d.Label] = model.LabelValue(upstream)
+			if source, ok := d.rule.hasEqual(lookup, false, now); ok {
+				return &types.Inhibition{
+					RuleIndex:      -1,
+					Equal:          d.equal(lset),
+					Source:         source,
+					DependencyPath: path(parents, upstream),
+				}, true
+			}
+			queue = append(queue, upstream)
+		}
+	}
+	return nil, false
+}
+
+// equal returns the values of the equal labels, other than the service
+// label, in the label set.
+func (d *DependencyRule) equal(lset model.LabelSet) model.LabelSet {
+	equal := make(model.LabelSet, len(d.rule.Equal))
+	for n := range d.rule.Equal {
+		if v, ok := lset[n]; ok && n != d.Label {
+			equal[n] = v
+		}
+	}
+	return equal
+}
+
+// path returns the services from the root of the walk to the given service.
+func path(parents map[string]string, service string) []string {
+	var p []string
+	for s := service; s != ""; s = parents[s] {
+		p = append([]string{s}, p...)
+	}
+	return p
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inhibit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestDependencyRule(t *testing.T) {
+	t.Parallel()
+
+	critical, err := labels.NewMatcher(labels.MatchEqual, "severity", "critical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDependencyRule(&config.DependenciesConfig{
+		Label:          "service",
+		SourceMatchers: config.Matchers{critical},
+		Equal:          model.LabelNames{"cluster"},
+	}, map[string][]string{
+		"frontend": {"api", "cache"},
+		"api":      {"db"},
+		"worker":   {"db"},
+	})
+
+	m := types.NewMarker(prometheus.NewRegistry())
+	ih := NewInhibitor(nil, nil, nil, m, nopLogger)
+	ih.SetDependencies(d)
+
+	now := time.Now()
+	source := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"service": "db", "severity": "critical", "cluster": "c1"},
+			StartsAt: now.Add(-time.Minute),
+			EndsAt:   now.Add(time.Hour),
+		},
+	}
+	if err := d.rule.set(source); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		target model.LabelSet
+		path   []string
+	}{
+		{
+			// Transitive dependency.
+			target: model.LabelSet{"service": "frontend", "cluster": "c1"},
+			path:   []string{"frontend", "api", "db"},
+		},
+		{
+			// Direct dependency.
+			target: model.LabelSet{"service": "worker", "cluster": "c1"},
+			path:   []string{"worker", "db"},
+		},
+		{
+			// The failing service itself.
+			target: model.LabelSet{"service": "db", "cluster": "c1"},
+		},
+		{
+			// Not depending on the failing service.
+			target: model.LabelSet{"service": "cache", "cluster": "c1"},
+		},
+		{
+			// Equal label doesn't match.
+			target: model.LabelSet{"service": "frontend", "cluster": "c2"},
+		},
+		{
+			// Missing service label.
+			target: model.LabelSet{"cluster": "c1"},
+		},
+	} {
+		muted := ih.Mutes(c.target)
+		if muted != (c.path != nil) {
+			t.Errorf("Unexpected result %t for %v", muted, c.target)
+			continue
+		}
+		inh := m.Status(c.target.Fingerprint()).Inhibition
+		if !muted {
+			if inh != nil {
+				t.Errorf("Expected no inhibition for %v, got %v", c.target, inh)
+			}
+			continue
+		}
+		if inh.RuleIndex != -1 {
+			t.Errorf("Expected rule index -1, got %d", inh.RuleIndex)
+		}
+		if inh.Source != source {
+			t.Errorf("Expected source alert %v, got %v", source, inh.Source)
+		}
+		if !reflect.DeepEqual(inh.DependencyPath, c.path) {
+			t.Errorf("Expected dependency path %v, got %v", c.path, inh.DependencyPath)
+		}
+		if !inh.Equal.Equal(model.LabelSet{"cluster": "c1"}) {
+			t.Errorf("Expected equal labels %v, got %v", model.LabelSet{"cluster": "c1"}, inh.Equal)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inhibit
+
+import (
+	"sync"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// sourceIndex stores the source alerts of an inhibition rule by the
+// fingerprint of their values for the rule's Equal labels.
+type sourceIndex interface {
+	// set adds the alert under the given key, replacing any previous
+	// version of it.
+	set(key model.Fingerprint, a *types.Alert)
+	// delete removes the alert with the given fingerprint.
+	delete(key, fp model.Fingerprint)
+	// deleteFunc removes all alerts for which fn returns true.
+	deleteFunc(fn func(*types.Alert) bool)
+	// find returns the first alert stored under the given key for which fn
+	// returns true.
+	find(key model.Fingerprint, fn func(*types.Alert) bool) (*types.Alert, bool)
+}
+
+// index holds the cached source alerts of an inhibition rule, grouped by the
+// fingerprint of their values for the rule's Equal labels. It allows looking
+// up the source alerts that can inhibit a target alert without scanning the
+// whole cache.
+type index struct {
+	mtx   sync.RWMutex
+	items map[model.Fingerprint]map[model.Fingerprint]*types.Alert
+}
+
+func newIndex() *index {
+	return &index{
+		items: map[model.Fingerprint]map[model.Fingerprint]*types.Alert{},
+	}
+}
+
+// set adds the alert to the index under the given key, replacing any previous
+// version of it.
+func (i *index) set(key model.Fingerprint, a *types.Alert) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	alerts, ok := i.items[key]
+	if !ok {
+		alerts = map[model.Fingerprint]*types.Alert{}
+		i.items[key] = alerts
+	}
+	alerts[a.Fingerprint()] = a
+}
+
+// delete removes the alert with the given fingerprint from the index.
+func (i *index) delete(key, fp model.Fingerprint) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	alerts, ok := i.items[key]
+	if !ok {
+		return
+	}
+	delete(alerts, fp)
+	if len(alerts) == 0 {
+		delete(i.items, key)
+	}
+}
+
+// deleteFunc removes all alerts from the index for which fn returns true.
+func (i *index) deleteFunc(fn func(*types.Alert) bool) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	for key, alerts := range i.items {
+		for fp, a := range alerts {
+			if fn(a) {
+				delete(alerts, fp)
+			}
+		}
+		if len(alerts) == 0 {
+			delete(i.items, key)
+		}
+	}
+}
+
+// find returns the first alert stored under the given key for which fn
+// returns true.
+func (i *index) find(key model.Fingerprint, fn func(*types.Alert) bool) (*types.Alert, bool) {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+
+	for _, a := range i.items[key] {
+		if fn(a) {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// len returns the number of alerts in the index.
+func (i *index) len() int {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+
+	var n int
+	for _, alerts := range i.items {
+		n += len(alerts)
+	}
+	return n
+}
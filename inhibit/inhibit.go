@@ -15,6 +15,11 @@ package inhibit
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,11 +27,13 @@ import (
 	"github.com/go-kit/log/level"
 	"github.com/oklog/run"
 	"github.com/prometheus/common/model"
+	"github.com/redis/go-redis/v9"
 
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/store"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -36,17 +43,26 @@ import (
 type Inhibitor struct {
 	alerts provider.Alerts
 	rules  []*InhibitRule
+	deps   *DependencyRule
+	times  map[string][]timeinterval.TimeInterval
 	marker types.Marker
 	logger log.Logger
 
+	// Redis client and organization of the source alerts shared with other
+	// instances. If nil, the source alerts are only cached in memory.
+	rdb   redis.Cmdable
+	orgId int64
+
 	mtx    sync.RWMutex
 	cancel func()
 }
 
-// NewInhibitor returns a new Inhibitor.
-func NewInhibitor(ap provider.Alerts, rs []config.InhibitRule, mk types.Marker, logger log.Logger) *Inhibitor {
+// NewInhibitor returns a new Inhibitor. The time intervals are looked up by
+// the rules' active and mute time intervals.
+func NewInhibitor(ap provider.Alerts, rs []config.InhibitRule, ti map[string][]timeinterval.TimeInterval, mk types.Marker, logger log.Logger) *Inhibitor {
 	ih := &Inhibitor{
 		alerts: ap,
+		times:  ti,
 		marker: mk,
 		logger: logger,
 	}
@@ -57,6 +73,41 @@ func NewInhibitor(ap provider.Alerts, rs []config.InhibitRule, mk types.Marker,
 	return ih
 }
 
+// SetDependencies sets the rule inhibiting alerts based on the service
+// dependencies. It must be called before Run.
+func (ih *Inhibitor) SetDependencies(d *DependencyRule) {
+	ih.deps = d
+	if d != nil && ih.rdb != nil {
+		ih.share(d.rule)
+	}
+}
+
+// SetSharedCache shares the source alerts of all rules with the other
+// instances of the organization through Redis, so that target alerts are
+// inhibited regardless of the instance which received the source alerts. It
+// must be called before Run.
+func (ih *Inhibitor) SetSharedCache(rdb redis.Cmdable, orgId int64) {
+	ih.rdb = rdb
+	ih.orgId = orgId
+	for _, r := range ih.sourceRules() {
+		ih.share(r)
+	}
+}
+
+func (ih *Inhibitor) share(r *InhibitRule) {
+	r.sindex = newRedisIndex(ih.rdb, ih.orgId, r, ih.logger)
+}
+
+// sourceRules returns all the rules caching source alerts.
+func (ih *Inhibitor) sourceRules() []*InhibitRule {
+	if ih.deps == nil {
+		return ih.rules
+	}
+	rules := make([]*InhibitRule, 0, len(ih.rules)+1)
+	rules = append(rules, ih.rules...)
+	return append(rules, ih.deps.rule)
+}
+
 func (ih *Inhibitor) run(ctx context.Context) {
 	it := ih.alerts.Subscribe()
 	defer it.Close()
@@ -71,9 +122,9 @@ func (ih *Inhibitor) run(ctx context.Context) {
 				continue
 			}
 			// Update the inhibition rules' cache.
-			for _, r := range ih.rules {
+			for _, r := range ih.sourceRules() {
 				if r.SourceMatchers.Matches(a.Labels) {
-					if err := r.scache.Set(a); err != nil {
+					if err := r.set(a); err != nil {
 						level.Error(ih.logger).Log("msg", "error on set alert", "err", err)
 					}
 				}
@@ -94,7 +145,7 @@ func (ih *Inhibitor) Run() {
 	ih.mtx.Unlock()
 	runCtx, runCancel := context.WithCancel(ctx)
 
-	for _, rule := range ih.rules {
+	for _, rule := range ih.sourceRules() {
 		go rule.scache.Run(runCtx, 15*time.Minute)
 	}
 
@@ -127,16 +178,36 @@ func (ih *Inhibitor) Stop() {
 // interface.
 func (ih *Inhibitor) Mutes(lset model.LabelSet) bool {
 	fp := lset.Fingerprint()
+	now := time.Now()
 
-	for _, r := range ih.rules {
+	for i, r := range ih.rules {
 		if !r.TargetMatchers.Matches(lset) {
 			// If target side of rule doesn't match, we don't need to look any further.
 			continue
 		}
+		if !ih.activeAt(r, now) {
+			continue
+		}
 		// If we are here, the target side matches. If the source side matches, too, we
 		// need to exclude inhibiting alerts for which the same is true.
-		if inhibitedByFP, eq := r.hasEqual(lset, r.SourceMatchers.Matches(lset)); eq {
-			ih.marker.SetInhibited(fp, inhibitedByFP.String())
+		if source, eq := r.hasEqual(lset, r.SourceMatchers.Matches(lset), now); eq {
+			equal := make(model.LabelSet, len(r.Equal))
+			for n := range r.Equal {
+				if v, ok := lset[n]; ok {
+					equal[n] = v
+				}
+			}
+			ih.marker.SetInhibition(fp, &types.Inhibition{
+				RuleIndex: i,
+				Equal:     equal,
+				Source:    source,
+			})
+			return true
+		}
+	}
+	if ih.deps != nil {
+		if inhibition, ok := ih.deps.inhibition(lset, now); ok {
+			ih.marker.SetInhibition(fp, inhibition)
 			return true
 		}
 	}
@@ -145,6 +216,29 @@ func (ih *Inhibitor) Mutes(lset model.LabelSet) bool {
 	return false
 }
 
+// activeAt returns true if the rule is in effect at the given time according
+// to its active and mute time intervals.
+func (ih *Inhibitor) activeAt(r *InhibitRule, now time.Time) bool {
+	if len(r.ActiveTimeIntervals) > 0 && !ih.inTimeIntervals(now, r.ActiveTimeIntervals) {
+		return false
+	}
+	return !ih.inTimeIntervals(now, r.MuteTimeIntervals)
+}
+
+// inTimeIntervals returns true if the given time is contained in one of the
+// named time intervals. Unknown names are ignored as the configuration
+// validation rejects them.
+func (ih *Inhibitor) inTimeIntervals(now time.Time, names []string) bool {
+	for _, name := range names {
+		for _, ti := range ih.times[name] {
+			if ti.ContainsTime(now.UTC()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // An InhibitRule specifies that a class of (source) alerts should inhibit
 // notifications for another class of (target) alerts if all specified matching
 // labels are equal between the two alerts. This may be used to inhibit alerts
@@ -160,9 +254,25 @@ type InhibitRule struct {
 	// A set of label names whose label values need to be identical in source and
 	// target alerts in order for the inhibition to take effect.
 	Equal map[model.LabelName]struct{}
+	// How long source alerts must have been firing before they inhibit
+	// target alerts.
+	SourceMinDuration time.Duration
+	// How long source alerts keep inhibiting target alerts after they
+	// resolved.
+	HoldAfterResolve time.Duration
+	// The names of the time intervals during which the rule is in effect. If
+	// empty, the rule is always in effect.
+	ActiveTimeIntervals []string
+	// The names of the time intervals during which the rule is not in effect.
+	MuteTimeIntervals []string
 
 	// Cache of alerts matching source labels.
 	scache *store.Alerts
+	// Identifier of the rule, derived from its matchers and equal labels, so
+	// that it is the same on all instances sharing the source alerts.
+	id string
+	// Index of the cached source alerts by their Equal label values.
+	sindex sourceIndex
 }
 
 // NewInhibitRule returns a new InhibitRule based on a configuration definition.
@@ -218,34 +328,112 @@ func NewInhibitRule(cr config.InhibitRule) *InhibitRule {
 		equal[ln] = struct{}{}
 	}
 
-	return &InhibitRule{
-		SourceMatchers: sourcem,
-		TargetMatchers: targetm,
-		Equal:          equal,
-		scache:         store.NewAlerts(),
+	r := &InhibitRule{
+		SourceMatchers:      sourcem,
+		TargetMatchers:      targetm,
+		Equal:               equal,
+		SourceMinDuration:   time.Duration(cr.SourceMinDuration),
+		HoldAfterResolve:    time.Duration(cr.HoldAfterResolve),
+		ActiveTimeIntervals: cr.ActiveTimeIntervals,
+		MuteTimeIntervals:   cr.MuteTimeIntervals,
+		scache:              store.NewAlerts(),
+		sindex:              newIndex(),
 	}
+	r.id = ruleID(r)
+	r.scache.SetGCCallback(func(_ []*types.Alert) {
+		// Resolved alerts may still be held in the index.
+		now := time.Now()
+		r.sindex.deleteFunc(func(a *types.Alert) bool {
+			return r.expiredAt(a, now)
+		})
+	})
+	return r
 }
 
-// hasEqual checks whether the source cache contains alerts matching the equal
-// labels for the given label set. If so, the fingerprint of one of those alerts
-// is returned. If excludeTwoSidedMatch is true, alerts that match both the
-// source and the target side of the rule are disregarded.
-func (r *InhibitRule) hasEqual(lset model.LabelSet, excludeTwoSidedMatch bool) (model.Fingerprint, bool) {
-Outer:
-	for _, a := range r.scache.List() {
-		// The cache might be stale and contain resolved alerts.
-		if a.Resolved() {
-			continue
+// set updates the source cache and its index with the given alert. Resolved
+// alerts are evicted from the cache right away instead of waiting for the
+// next garbage collection, and from the index once they are no longer held.
+func (r *InhibitRule) set(a *types.Alert) error {
+	fp := a.Fingerprint()
+	key := r.equalFingerprint(a.Labels)
+	if a.Resolved() {
+		if r.expiredAt(a, time.Now()) {
+			r.sindex.delete(key, fp)
+		} else {
+			r.sindex.set(key, a)
 		}
-		for n := range r.Equal {
-			if a.Labels[n] != lset[n] {
-				continue Outer
-			}
+		return r.scache.Delete(fp)
+	}
+	if err := r.scache.Set(a); err != nil {
+		return err
+	}
+	r.sindex.set(key, a)
+	return nil
+}
+
+// expiredAt returns true if the source alert no longer inhibits target alerts
+// at the given time, taking the hold after resolve into account.
+func (r *InhibitRule) expiredAt(a *types.Alert, now time.Time) bool {
+	return a.ResolvedAt(now.Add(-r.HoldAfterResolve))
+}
+
+// ruleID returns a stable identifier of the rule, independent of the order
+// of its matchers, equal labels and time intervals in the configuration.
+// Rules only share their source alerts if they select and hold them alike.
+func ruleID(r *InhibitRule) string {
+	h := sha256.New()
+	for _, ms := range []labels.Matchers{r.SourceMatchers, r.TargetMatchers} {
+		strs := make([]string, 0, len(ms))
+		for _, m := range ms {
+			strs = append(strs, m.String())
 		}
-		if excludeTwoSidedMatch && r.TargetMatchers.Matches(a.Labels) {
-			continue Outer
+		sort.Strings(strs)
+		h.Write([]byte(strings.Join(strs, ",")))
+		h.Write([]byte{0xff})
+	}
+	names := make([]string, 0, len(r.Equal))
+	for n := range r.Equal {
+		names = append(names, string(n))
+	}
+	sort.Strings(names)
+	h.Write([]byte(strings.Join(names, ",")))
+	h.Write([]byte{0xff})
+	fmt.Fprintf(h, "%d,%d", r.SourceMinDuration, r.HoldAfterResolve)
+	for _, intervals := range [][]string{r.ActiveTimeIntervals, r.MuteTimeIntervals} {
+		h.Write([]byte{0xff})
+		names := append([]string(nil), intervals...)
+		sort.Strings(names)
+		h.Write([]byte(strings.Join(names, ",")))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// equalFingerprint returns the fingerprint of the values of the rule's Equal
+// labels in the label set. A missing label is equivalent to an empty one.
+func (r *InhibitRule) equalFingerprint(lset model.LabelSet) model.Fingerprint {
+	equal := make(model.LabelSet, len(r.Equal))
+	for n := range r.Equal {
+		if v := lset[n]; v != "" {
+			equal[n] = v
 		}
-		return a.Fingerprint(), true
 	}
-	return model.Fingerprint(0), false
+	return equal.Fingerprint()
+}
+
+// hasEqual checks whether the source cache contains alerts matching the equal
+// labels for the given label set which inhibit at the given time. If so, one
+// of those alerts is returned. If excludeTwoSidedMatch is true, alerts that
+// match both the source and the target side of the rule are disregarded.
+func (r *InhibitRule) hasEqual(lset model.LabelSet, excludeTwoSidedMatch bool, now time.Time) (*types.Alert, bool) {
+	return r.sindex.find(r.equalFingerprint(lset), func(a *types.Alert) bool {
+		// The cache might be stale and contain alerts which resolved since
+		// they were last updated.
+		if r.expiredAt(a, now) {
+			return false
+		}
+		if now.Sub(a.StartsAt) < r.SourceMinDuration {
+			return false
+		}
+		return !excludeTwoSidedMatch || !r.TargetMatchers.Matches(a.Labels)
+	})
 }
@@ -0,0 +1,68 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inhibit
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// BenchmarkMutes measures the cost of evaluating a target alert against a
+// single rule with an increasing number of cached source alerts.
+func BenchmarkMutes(b *testing.B) {
+	for _, n := range []int{1, 100, 1000, 10000} {
+		b.Run("inhibited/sources="+strconv.Itoa(n), func(b *testing.B) {
+			benchmarkMutes(b, n, model.LabelSet{"t": "1", "instance": model.LabelValue(strconv.Itoa(n - 1))}, true)
+		})
+		b.Run("not inhibited/sources="+strconv.Itoa(n), func(b *testing.B) {
+			benchmarkMutes(b, n, model.LabelSet{"t": "1", "instance": "unknown"}, false)
+		})
+	}
+}
+
+func benchmarkMutes(b *testing.B, sources int, target model.LabelSet, expected bool) {
+	ih := NewInhibitor(nil, []config.InhibitRule{{
+		SourceMatch: map[string]string{"s": "1"},
+		TargetMatch: map[string]string{"t": "1"},
+		Equal:       model.LabelNames{"instance"},
+	}}, nil, types.NewMarker(prometheus.NewRegistry()), nopLogger)
+
+	now := time.Now()
+	for i := 0; i < sources; i++ {
+		if err := ih.rules[0].set(&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"s": "1", "instance": model.LabelValue(strconv.Itoa(i))},
+				StartsAt: now.Add(-time.Minute),
+				EndsAt:   now.Add(time.Hour),
+			},
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ih.Mutes(target) != expected {
+			b.Fatalf("Expected %v to be inhibited: %t", target, expected)
+		}
+	}
+}
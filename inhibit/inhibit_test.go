@@ -14,6 +14,7 @@
 package inhibit
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/store"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -124,20 +126,299 @@ func TestInhibitRuleHasEqual(t *testing.T) {
 		r := &InhibitRule{
 			Equal:  map[model.LabelName]struct{}{},
 			scache: store.NewAlerts(),
+			sindex: newIndex(),
 		}
 		for _, ln := range c.equal {
 			r.Equal[ln] = struct{}{}
 		}
 		for _, v := range c.initial {
-			r.scache.Set(v)
+			r.set(v)
 		}
 
-		if _, have := r.hasEqual(c.input, false); have != c.result {
+		if _, have := r.hasEqual(c.input, false, now); have != c.result {
 			t.Errorf("Unexpected result %t, expected %t", have, c.result)
 		}
 	}
 }
 
+func TestInhibitRuleSourceIndex(t *testing.T) {
+	t.Parallel()
+
+	r := NewInhibitRule(config.InhibitRule{
+		SourceMatch: map[string]string{"s": "1"},
+		TargetMatch: map[string]string{"t": "1"},
+		Equal:       model.LabelNames{"cluster", "service"},
+	})
+	now := time.Now()
+	source := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"s": "1", "cluster": "c1", "service": "api"},
+			StartsAt: now.Add(-time.Minute),
+			EndsAt:   now.Add(time.Hour),
+		},
+	}
+	if err := r.set(source); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		target   model.LabelSet
+		expected bool
+	}{
+		{
+			target:   model.LabelSet{"t": "1", "cluster": "c1", "service": "api"},
+			expected: true,
+		},
+		{
+			target:   model.LabelSet{"t": "1", "cluster": "c1", "service": "db"},
+			expected: false,
+		},
+		{
+			target:   model.LabelSet{"t": "1", "cluster": "c1"},
+			expected: false,
+		},
+	} {
+		if _, have := r.hasEqual(c.target, false, now); have != c.expected {
+			t.Errorf("Unexpected result %t for %v, expected %t", have, c.target, c.expected)
+		}
+	}
+
+	// A resolved update evicts the alert from the cache and the index.
+	resolved := *source
+	resolved.EndsAt = now.Add(-time.Second)
+	if err := r.set(&resolved); err != nil {
+		t.Fatal(err)
+	}
+	if n := r.sindex.(*index).len(); n != 0 {
+		t.Errorf("Expected an empty index, got %d alerts", n)
+	}
+	if n := r.scache.Len(); n != 0 {
+		t.Errorf("Expected an empty cache, got %d alerts", n)
+	}
+
+	// Alerts removed by the cache garbage collection are evicted from the
+	// index as well.
+	stale := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"s": "1", "cluster": "c2"},
+			StartsAt: now.Add(-time.Minute),
+			EndsAt:   now.Add(time.Hour),
+		},
+	}
+	if err := r.set(stale); err != nil {
+		t.Fatal(err)
+	}
+	stale.EndsAt = now.Add(-time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.scache.Run(ctx, 10*time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for r.sindex.(*index).len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected an empty index, got %d alerts", r.sindex.(*index).len())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestInhibitRuleHoldDown(t *testing.T) {
+	t.Parallel()
+
+	r := NewInhibitRule(config.InhibitRule{
+		SourceMatch:       map[string]string{"s": "1"},
+		TargetMatch:       map[string]string{"t": "1"},
+		SourceMinDuration: model.Duration(5 * time.Minute),
+		HoldAfterResolve:  model.Duration(10 * time.Minute),
+	})
+	now := time.Now()
+	target := model.LabelSet{"t": "1"}
+
+	source := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"s": "1"},
+			StartsAt: now.Add(-time.Minute),
+			EndsAt:   now.Add(time.Hour),
+		},
+	}
+	if err := r.set(source); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{
+			name:     "source firing for less than source_min_duration",
+			at:       now,
+			expected: false,
+		},
+		{
+			name:     "source firing for source_min_duration",
+			at:       now.Add(4 * time.Minute),
+			expected: true,
+		},
+	} {
+		if _, have := r.hasEqual(target, false, c.at); have != c.expected {
+			t.Errorf("%s: unexpected result %t, expected %t", c.name, have, c.expected)
+		}
+	}
+
+	// The source resolves after having fired for 10 minutes.
+	resolved := *source
+	resolved.EndsAt = now.Add(-time.Second)
+	resolved.StartsAt = now.Add(-10 * time.Minute)
+	if err := r.set(&resolved); err != nil {
+		t.Fatal(err)
+	}
+	if n := r.scache.Len(); n != 0 {
+		t.Errorf("Expected an empty cache, got %d alerts", n)
+	}
+
+	for _, c := range []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{
+			name:     "source resolved within hold_after_resolve",
+			at:       now.Add(9 * time.Minute),
+			expected: true,
+		},
+		{
+			name:     "hold_after_resolve elapsed",
+			at:       now.Add(10 * time.Minute),
+			expected: false,
+		},
+	} {
+		if _, have := r.hasEqual(target, false, c.at); have != c.expected {
+			t.Errorf("%s: unexpected result %t, expected %t", c.name, have, c.expected)
+		}
+	}
+}
+
+func TestInhibitorTimeIntervals(t *testing.T) {
+	t.Parallel()
+
+	thisYear := time.Now().UTC().Year()
+	times := map[string][]timeinterval.TimeInterval{
+		"always": {{}},
+		"never": {{
+			Years: []timeinterval.YearRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: thisYear - 2, End: thisYear - 1}}},
+		}},
+	}
+
+	for _, c := range []struct {
+		active   []string
+		mute     []string
+		expected bool
+	}{
+		{
+			expected: true,
+		},
+		{
+			active:   []string{"always"},
+			expected: true,
+		},
+		{
+			active:   []string{"never"},
+			expected: false,
+		},
+		{
+			active:   []string{"never", "always"},
+			expected: true,
+		},
+		{
+			mute:     []string{"always"},
+			expected: false,
+		},
+		{
+			mute:     []string{"never"},
+			expected: true,
+		},
+		{
+			active:   []string{"always"},
+			mute:     []string{"always"},
+			expected: false,
+		},
+	} {
+		ih := NewInhibitor(nil, []config.InhibitRule{{
+			SourceMatch:         map[string]string{"s": "1"},
+			TargetMatch:         map[string]string{"t": "1"},
+			ActiveTimeIntervals: c.active,
+			MuteTimeIntervals:   c.mute,
+		}}, times, types.NewMarker(prometheus.NewRegistry()), nopLogger)
+		if err := ih.rules[0].set(&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"s": "1"},
+				StartsAt: time.Now().Add(-time.Minute),
+				EndsAt:   time.Now().Add(time.Hour),
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if have := ih.Mutes(model.LabelSet{"t": "1"}); have != c.expected {
+			t.Errorf("Unexpected result %t for active %v and mute %v, expected %t", have, c.active, c.mute, c.expected)
+		}
+	}
+}
+
+func TestInhibitRuleID(t *testing.T) {
+	t.Parallel()
+
+	r1 := NewInhibitRule(config.InhibitRule{
+		SourceMatch: map[string]string{"s1": "1", "s2": "1"},
+		TargetMatch: map[string]string{"t": "1"},
+		Equal:       model.LabelNames{"a", "b"},
+	})
+	r2 := NewInhibitRule(config.InhibitRule{
+		SourceMatch: map[string]string{"s2": "1", "s1": "1"},
+		TargetMatch: map[string]string{"t": "1"},
+		Equal:       model.LabelNames{"b", "a"},
+	})
+	if r1.id != r2.id {
+		t.Errorf("Expected the same id for equivalent rules, got %s and %s", r1.id, r2.id)
+	}
+
+	// Swapping the source and target matchers makes a different rule.
+	r3 := NewInhibitRule(config.InhibitRule{
+		SourceMatch: map[string]string{"t": "1"},
+		TargetMatch: map[string]string{"s1": "1", "s2": "1"},
+		Equal:       model.LabelNames{"a", "b"},
+	})
+	if r1.id == r3.id {
+		t.Errorf("Expected different ids for different rules, got %s", r1.id)
+	}
+
+	// Rules selecting or holding their source alerts differently don't share
+	// them.
+	base := config.InhibitRule{
+		SourceMatch:         map[string]string{"s": "1"},
+		TargetMatch:         map[string]string{"t": "1"},
+		ActiveTimeIntervals: []string{"business-hours", "weekdays"},
+	}
+	id := NewInhibitRule(base).id
+	for name, modify := range map[string]func(*config.InhibitRule){
+		"hold_after_resolve":    func(c *config.InhibitRule) { c.HoldAfterResolve = model.Duration(time.Minute) },
+		"source_min_duration":   func(c *config.InhibitRule) { c.SourceMinDuration = model.Duration(time.Minute) },
+		"active_time_intervals": func(c *config.InhibitRule) { c.ActiveTimeIntervals = []string{"business-hours"} },
+		"mute_time_intervals":   func(c *config.InhibitRule) { c.MuteTimeIntervals = []string{"business-hours", "weekdays"} },
+	} {
+		c := base
+		modify(&c)
+		if other := NewInhibitRule(c).id; other == id {
+			t.Errorf("Expected a different id for a different %s, got %s", name, id)
+		}
+	}
+	reordered := base
+	reordered.ActiveTimeIntervals = []string{"weekdays", "business-hours"}
+	if other := NewInhibitRule(reordered).id; other != id {
+		t.Errorf("Expected the same id for reordered time intervals, got %s and %s", id, other)
+	}
+}
+
 func TestInhibitRuleMatches(t *testing.T) {
 	t.Parallel()
 
@@ -153,7 +434,7 @@ func TestInhibitRuleMatches(t *testing.T) {
 	}
 
 	m := types.NewMarker(prometheus.NewRegistry())
-	ih := NewInhibitor(nil, []config.InhibitRule{rule1, rule2}, m, nopLogger)
+	ih := NewInhibitor(nil, []config.InhibitRule{rule1, rule2}, nil, m, nopLogger)
 	now := time.Now()
 	// Active alert that matches the source filter of rule1.
 	sourceAlert1 := &types.Alert{
@@ -172,10 +453,8 @@ func TestInhibitRuleMatches(t *testing.T) {
 		},
 	}
 
-	ih.rules[0].scache = store.NewAlerts()
-	ih.rules[0].scache.Set(sourceAlert1)
-	ih.rules[1].scache = store.NewAlerts()
-	ih.rules[1].scache.Set(sourceAlert2)
+	ih.rules[0].set(sourceAlert1)
+	ih.rules[1].set(sourceAlert2)
 
 	cases := []struct {
 		target   model.LabelSet
@@ -234,6 +513,67 @@ func TestInhibitRuleMatches(t *testing.T) {
 	}
 }
 
+func TestInhibitionExplanation(t *testing.T) {
+	t.Parallel()
+
+	rules := []config.InhibitRule{
+		{
+			SourceMatch: map[string]string{"s1": "1"},
+			TargetMatch: map[string]string{"t1": "1"},
+			Equal:       model.LabelNames{"e"},
+		},
+		{
+			SourceMatch: map[string]string{"s2": "1"},
+			TargetMatch: map[string]string{"t2": "1"},
+			Equal:       model.LabelNames{"cluster", "e"},
+		},
+	}
+
+	m := types.NewMarker(prometheus.NewRegistry())
+	ih := NewInhibitor(nil, rules, nil, m, nopLogger)
+	now := time.Now()
+	source := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"s2": "1", "e": "1"},
+			StartsAt: now.Add(-time.Minute),
+			EndsAt:   now.Add(time.Hour),
+		},
+	}
+	ih.rules[1].set(source)
+
+	target := model.LabelSet{"t2": "1", "e": "1"}
+	if !ih.Mutes(target) {
+		t.Fatalf("Expected %v to be inhibited", target)
+	}
+	status := m.Status(target.Fingerprint())
+	inh := status.Inhibition
+	if inh == nil {
+		t.Fatalf("Expected an inhibition to be recorded for %v", target)
+	}
+	if inh.RuleIndex != 1 {
+		t.Errorf("Expected rule index 1, got %d", inh.RuleIndex)
+	}
+	// The cluster label is missing in both alerts.
+	if !inh.Equal.Equal(model.LabelSet{"e": "1"}) {
+		t.Errorf("Expected equal labels %v, got %v", model.LabelSet{"e": "1"}, inh.Equal)
+	}
+	if inh.Source != source {
+		t.Errorf("Expected source alert %v, got %v", source, inh.Source)
+	}
+	if len(status.InhibitedBy) != 1 || status.InhibitedBy[0] != source.Fingerprint().String() {
+		t.Errorf("Expected the alert to be inhibited by %s, got %v", source.Fingerprint(), status.InhibitedBy)
+	}
+
+	// The explanation is cleared once the alert is no longer inhibited.
+	source.EndsAt = now.Add(-time.Second)
+	if ih.Mutes(target) {
+		t.Fatalf("Expected %v not to be inhibited", target)
+	}
+	if inh := m.Status(target.Fingerprint()).Inhibition; inh != nil {
+		t.Errorf("Expected no inhibition to be recorded, got %v", inh)
+	}
+}
+
 func TestInhibitRuleMatchers(t *testing.T) {
 	t.Parallel()
 
@@ -249,7 +589,7 @@ func TestInhibitRuleMatchers(t *testing.T) {
 	}
 
 	m := types.NewMarker(prometheus.NewRegistry())
-	ih := NewInhibitor(nil, []config.InhibitRule{rule1, rule2}, m, nopLogger)
+	ih := NewInhibitor(nil, []config.InhibitRule{rule1, rule2}, nil, m, nopLogger)
 	now := time.Now()
 	// Active alert that matches the source filter of rule1.
 	sourceAlert1 := &types.Alert{
@@ -268,10 +608,8 @@ func TestInhibitRuleMatchers(t *testing.T) {
 		},
 	}
 
-	ih.rules[0].scache = store.NewAlerts()
-	ih.rules[0].scache.Set(sourceAlert1)
-	ih.rules[1].scache = store.NewAlerts()
-	ih.rules[1].scache.Set(sourceAlert2)
+	ih.rules[0].set(sourceAlert1)
+	ih.rules[1].set(sourceAlert2)
 
 	cases := []struct {
 		target   model.LabelSet
@@ -452,7 +790,7 @@ func TestInhibit(t *testing.T) {
 	} {
 		ap := newFakeAlerts(tc.alerts)
 		mk := types.NewMarker(prometheus.NewRegistry())
-		inhibitor := NewInhibitor(ap, []config.InhibitRule{inhibitRule()}, mk, nopLogger)
+		inhibitor := NewInhibitor(ap, []config.InhibitRule{inhibitRule()}, nil, mk, nopLogger)
 
 		go func() {
 			for ap.finished != nil {
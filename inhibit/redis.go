@@ -0,0 +1,145 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inhibit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	// orgInhibitSources is the Redis hash holding the source alerts of a
+	// rule with the same equal label values, keyed by their fingerprints.
+	orgInhibitSources = "%d_inhibit_%s_%016x"
+)
+
+// redisIndex is an index of the source alerts of a rule shared by all
+// Alertmanager instances through Redis, so that target alerts are inhibited
+// whichever instance received the source alerts. The source alerts with the
+// same equal label values are kept in one hash, so that they are looked up in
+// a single round trip. The hash expires once all its alerts resolved and are
+// no longer held, alerts expired before are removed when they are looked up.
+type redisIndex struct {
+	rdb    redis.Cmdable
+	orgId  int64
+	rule   string
+	hold   time.Duration
+	now    func() time.Time
+	logger log.Logger
+}
+
+func newRedisIndex(rdb redis.Cmdable, orgId int64, r *InhibitRule, logger log.Logger) *redisIndex {
+	return &redisIndex{
+		rdb:    rdb,
+		orgId:  orgId,
+		rule:   r.id,
+		hold:   r.HoldAfterResolve,
+		now:    time.Now,
+		logger: log.With(logger, "rule", r.id),
+	}
+}
+
+func (i *redisIndex) sourcesKey(key model.Fingerprint) string {
+	return fmt.Sprintf(orgInhibitSources, i.orgId, i.rule, uint64(key))
+}
+
+// set stores the alert in the hash of its equal label values. The hash
+// expires with the last of its alerts.
+func (i *redisIndex) set(key model.Fingerprint, a *types.Alert) {
+	ttl := a.EndsAt.Add(i.hold).Sub(i.now())
+	if ttl <= 0 {
+		i.delete(key, a.Fingerprint())
+		return
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		level.Error(i.logger).Log("msg", "Marshal inhibition source alert failed", "alert", a, "err", err)
+		return
+	}
+
+	ctx := context.Background()
+	sKey := i.sourcesKey(key)
+	// The TTL is only ever extended. Plain EXPIRE is used as its NX and GT
+	// options require Redis 7. A concurrent update by another instance may
+	// shorten it, which is corrected by the next update of the alert.
+	cur, err := i.rdb.PTTL(ctx, sKey).Result()
+	if err != nil {
+		level.Error(i.logger).Log("msg", "Get TTL of inhibition source alerts from redis failed", "key", sKey, "err", err)
+		return
+	}
+	if _, err := i.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sKey, a.Fingerprint().String(), b)
+		// A negative TTL means the hash doesn't exist or doesn't expire.
+		if cur < 0 || ttl > cur {
+			pipe.PExpire(ctx, sKey, ttl)
+		}
+		return nil
+	}); err != nil {
+		level.Error(i.logger).Log("msg", "Set inhibition source alert to redis failed", "alert", a, "err", err)
+	}
+}
+
+// delete removes the alert with the given fingerprint.
+func (i *redisIndex) delete(key, fp model.Fingerprint) {
+	if err := i.rdb.HDel(context.Background(), i.sourcesKey(key), fp.String()).Err(); err != nil {
+		level.Error(i.logger).Log("msg", "Del inhibition source alert from redis failed", "fingerprint", fp, "err", err)
+	}
+}
+
+// deleteFunc is a no-op as the alerts expire in Redis.
+func (i *redisIndex) deleteFunc(_ func(*types.Alert) bool) {}
+
+// find returns the first alert with the given equal label values for which
+// fn returns true. Alerts which are no longer held are removed.
+func (i *redisIndex) find(key model.Fingerprint, fn func(*types.Alert) bool) (*types.Alert, bool) {
+	ctx := context.Background()
+	sKey := i.sourcesKey(key)
+	values, err := i.rdb.HGetAll(ctx, sKey).Result()
+	if err != nil {
+		level.Error(i.logger).Log("msg", "Get inhibition source alerts from redis failed", "key", sKey, "err", err)
+		return nil, false
+	}
+
+	now := i.now()
+	var expired []string
+	for fp, v := range values {
+		var a types.Alert
+		if err := json.Unmarshal([]byte(v), &a); err != nil {
+			level.Error(i.logger).Log("msg", "Unmarshal inhibition source alert from redis failed", "fingerprint", fp, "err", err)
+			continue
+		}
+		if a.ResolvedAt(now.Add(-i.hold)) {
+			expired = append(expired, fp)
+			continue
+		}
+		if fn(&a) {
+			return &a, true
+		}
+	}
+	if len(expired) > 0 {
+		if err := i.rdb.HDel(ctx, sKey, expired...).Err(); err != nil {
+			level.Error(i.logger).Log("msg", "Del expired inhibition source alerts from redis failed", "key", sKey, "err", err)
+		}
+	}
+	return nil, false
+}
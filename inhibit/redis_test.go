@@ -0,0 +1,127 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inhibit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/test/redistest"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestRedisIndex(t *testing.T) {
+	t.Parallel()
+
+	srv := redistest.Run(t)
+	r := NewInhibitRule(config.InhibitRule{
+		SourceMatch:      map[string]string{"s": "1"},
+		TargetMatch:      map[string]string{"t": "1"},
+		Equal:            model.LabelNames{"cluster"},
+		HoldAfterResolve: model.Duration(10 * time.Minute),
+	})
+	// Two instances sharing the source alerts of the rule.
+	a := newRedisIndex(srv.Client(t), 1, r, nopLogger)
+	b := newRedisIndex(srv.Client(t), 1, r, nopLogger)
+	other := newRedisIndex(srv.Client(t), 2, r, nopLogger)
+
+	now := time.Now()
+	// The clock of the instances moves along with the one of Redis.
+	var offset time.Duration
+	clock := func() time.Time { return time.Now().Add(offset) }
+	a.now, b.now = clock, clock
+	fastForward := func(d time.Duration) {
+		offset += d
+		srv.FastForward(d)
+	}
+	newSource := func(name string, endsAt time.Time) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"s": "1", "cluster": "c1", "name": model.LabelValue(name)},
+				StartsAt: now.Add(-time.Minute),
+				EndsAt:   endsAt,
+			},
+		}
+	}
+	key := r.equalFingerprint(model.LabelSet{"cluster": "c1"})
+	anyAlert := func(*types.Alert) bool { return true }
+	named := func(name string) func(*types.Alert) bool {
+		return func(a *types.Alert) bool { return a.Labels["name"] == model.LabelValue(name) }
+	}
+
+	firing := newSource("firing", now.Add(time.Hour))
+	a.set(key, firing)
+	found, ok := b.find(key, anyAlert)
+	if !ok {
+		t.Fatal("Expected the source alert to be shared with the other instance")
+	}
+	if found.Fingerprint() != firing.Fingerprint() || !found.EndsAt.Equal(firing.EndsAt) {
+		t.Errorf("Unexpected source alert %v", found)
+	}
+	if _, ok := b.find(r.equalFingerprint(model.LabelSet{"cluster": "c2"}), anyAlert); ok {
+		t.Error("Expected no source alert for other equal label values")
+	}
+	if _, ok := other.find(key, anyAlert); ok {
+		t.Error("Expected no source alert for another organization")
+	}
+
+	// Resolved alerts are kept for the hold after resolve.
+	resolved := newSource("resolved", now.Add(-time.Minute))
+	b.set(key, resolved)
+	if _, ok := a.find(key, named("resolved")); !ok {
+		t.Error("Expected the resolved source alert to be held")
+	}
+
+	// Alerts resolved longer than the hold after resolve are deleted.
+	expired := newSource("resolved", now.Add(-time.Hour))
+	a.set(key, expired)
+	if _, ok := b.find(key, named("resolved")); ok {
+		t.Error("Expected the resolved source alert to be deleted")
+	}
+	if err := srv.Client(t).HGet(context.Background(), a.sourcesKey(key), expired.Fingerprint().String()).Err(); err != redis.Nil {
+		t.Error("Expected the resolved source alert to be deleted from Redis")
+	}
+
+	b.delete(key, firing.Fingerprint())
+	if _, ok := a.find(key, anyAlert); ok {
+		t.Error("Expected no source alert after the deletion")
+	}
+
+	// The hash expires with the last source alert, also if a shorter one is
+	// updated afterwards, and expired alerts are removed from it.
+	short := newSource("short", now.Add(time.Minute))
+	long := newSource("long", now.Add(time.Hour))
+	a.set(key, short)
+	a.set(key, long)
+	a.set(key, short)
+	fastForward(15 * time.Minute)
+	if _, ok := b.find(key, named("short")); ok {
+		t.Error("Expected the short source alert to be expired")
+	}
+	if _, ok := b.find(key, named("long")); !ok {
+		t.Error("Expected the long source alert to be found")
+	}
+	if n := srv.Client(t).HLen(context.Background(), a.sourcesKey(key)).Val(); n != 1 {
+		t.Errorf("Expected the expired source alert to be removed from the hash, got %d alerts", n)
+	}
+	fastForward(time.Hour)
+	if keys := srv.Keys(); len(keys) != 0 {
+		t.Errorf("Expected all keys to expire, got %v", keys)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/test/redistest"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestDedupStageDeltaRepeats(t *testing.T) {
+	srv := redistest.Run(t)
+	recv := &nflogpb.Receiver{GroupName: "team-db", Integration: "webhook"}
+	stage := NewDedupStage(srv.Client(t), sendResolved(true), recv)
+
+	ctx := WithGroupKey(context.Background(), "group")
+	ctx = WithRepeatInterval(ctx, time.Hour)
+	ctx = WithNotifyMode(ctx, config.NotifyModeDelta)
+
+	newAlert := func(name string) *types.Alert {
+		return &types.Alert{Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": model.LabelValue(name)},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(24 * time.Hour),
+		}}
+	}
+	exec := func(alerts ...*types.Alert) (*Delta, []*types.Alert) {
+		t.Helper()
+		ctx, res, err := stage.Exec(ctx, log.NewNopLogger(), alerts...)
+		require.NoError(t, err)
+		delta, ok := DeltaAlerts(ctx)
+		require.True(t, ok)
+		return delta, res
+	}
+
+	a := newAlert("A")
+	delta, res := exec(a)
+	require.Equal(t, []*types.Alert{a}, res)
+	require.Equal(t, []*types.Alert{a}, delta.NewFiring)
+	require.Equal(t, int64(1), a.SentCount)
+
+	// Not repeated within the repeat interval.
+	delta, res = exec(a)
+	require.Empty(t, res)
+	require.Equal(t, []*types.Alert{a}, delta.StillFiring)
+
+	// A repeat is not sent along with a new alert, and stays due.
+	srv.FastForward(time.Hour)
+	b := newAlert("B")
+	delta, res = exec(a, b)
+	require.Equal(t, []*types.Alert{b}, res)
+	require.Equal(t, []*types.Alert{b}, delta.NewFiring)
+	require.Equal(t, []*types.Alert{a}, delta.StillFiring)
+	require.Equal(t, int64(1), a.SentCount)
+	sKey := stateKey("group", recv, hashAlert(a))
+	require.False(t, srv.Exists(sKey))
+
+	// Without other changes, the repeat is sent.
+	delta, res = exec(a, b)
+	require.Equal(t, []*types.Alert{a}, res)
+	require.Empty(t, delta.NewFiring)
+	require.Equal(t, []*types.Alert{a, b}, delta.StillFiring)
+	require.Equal(t, int64(2), a.SentCount)
+	require.True(t, srv.Exists(sKey))
+	require.InDelta(t, time.Hour, srv.TTL(sKey), float64(time.Second))
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// deferredRetention is how long the deferred alerts of an aggregation group
+// are kept after they were last deferred, in case the route is never
+// unmuted again.
+const deferredRetention = 7 * 24 * time.Hour
+
+// deferredKey returns the key of the deferred alerts of the receiver and
+// aggregation group of the context.
+func deferredKey(ctx context.Context) (string, error) {
+	recv, ok := ReceiverName(ctx)
+	if !ok {
+		return "", errors.New("receiver missing")
+	}
+	gkey, ok := GroupKey(ctx)
+	if !ok {
+		return "", errors.New("group key missing")
+	}
+	return fmt.Sprintf("deferred:%s:%s", recv, gkey), nil
+}
+
+// deferredAlerts persists the alerts whose notifications were suppressed by
+// time intervals, as a hash of the alerts by fingerprint.
+type deferredAlerts struct {
+	rdb redis.Cmdable
+}
+
+// add queues the alerts, replacing the previously queued state of the same
+// alerts.
+func (d deferredAlerts) add(ctx context.Context, key string, alerts []*types.Alert) error {
+	values := make([]interface{}, 0, 2*len(alerts))
+	for _, a := range alerts {
+		b, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		values = append(values, a.Fingerprint().String(), b)
+	}
+	_, err := d.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, values...)
+		pipe.Expire(ctx, key, deferredRetention)
+		return nil
+	})
+	return err
+}
+
+// get returns the queued alerts. They stay queued until they are removed
+// after their delivery.
+func (d deferredAlerts) get(ctx context.Context, key string) ([]*types.Alert, error) {
+	values, err := d.rdb.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*types.Alert, 0, len(values))
+	for fp, s := range values {
+		var a types.Alert
+		if err := json.Unmarshal([]byte(s), &a); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal deferred alert %s", fp)
+		}
+		alerts = append(alerts, &a)
+	}
+	return alerts, nil
+}
+
+// remove removes the alerts with the given fingerprints from the queue.
+// Alerts queued in the meantime stay queued.
+func (d deferredAlerts) remove(ctx context.Context, key string, fps map[model.Fingerprint]struct{}) error {
+	fields := make([]string, 0, len(fps))
+	for fp := range fps {
+		fields = append(fields, fp.String())
+	}
+	return d.rdb.HDel(ctx, key, fields...).Err()
+}
+
+// mergeDeferred adds the deferred alerts which are no longer part of the
+// aggregation group to the alerts, and returns the fingerprints of the
+// deferred alerts. Deferred alerts which left the group while still firing
+// are resolved at now.
+func mergeDeferred(alerts, deferred []*types.Alert, now time.Time) ([]*types.Alert, map[model.Fingerprint]struct{}) {
+	current := make(map[model.Fingerprint]struct{}, len(alerts))
+	for _, a := range alerts {
+		current[a.Fingerprint()] = struct{}{}
+	}
+
+	var (
+		fps    = make(map[model.Fingerprint]struct{}, len(deferred))
+		merged = make(types.AlertSlice, 0, len(alerts)+len(deferred))
+	)
+	merged = append(merged, alerts...)
+	for _, a := range deferred {
+		fp := a.Fingerprint()
+		fps[fp] = struct{}{}
+		if _, ok := current[fp]; ok {
+			continue
+		}
+		if !a.ResolvedAt(now) {
+			a.EndsAt = now
+		}
+		merged = append(merged, a)
+	}
+	sort.Stable(merged)
+
+	return merged, fps
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/test/redistest"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newDeferTestAlert(name string, endsAt time.Time) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": model.LabelValue(name)},
+			StartsAt: time.Unix(100, 0),
+			EndsAt:   endsAt,
+		},
+	}
+}
+
+func TestMergeDeferred(t *testing.T) {
+	now := time.Unix(1000, 0)
+	var (
+		// Still part of the group, the current state wins.
+		a = newDeferTestAlert("a", time.Time{})
+		b = newDeferTestAlert("b", now.Add(-time.Minute))
+		// Resolved while the notifications were deferred.
+		c = newDeferTestAlert("c", now.Add(-time.Minute))
+		// Left the group while still firing.
+		d = newDeferTestAlert("d", time.Time{})
+	)
+	deferred := []*types.Alert{
+		newDeferTestAlert("a", time.Time{}),
+		newDeferTestAlert("b", time.Time{}),
+		c,
+		d,
+	}
+
+	merged, fps := mergeDeferred([]*types.Alert{a, b}, deferred, now)
+	require.Equal(t, []*types.Alert{a, b, c, d}, merged)
+	require.Equal(t, now.Add(-time.Minute), c.EndsAt)
+	require.Equal(t, now, d.EndsAt)
+	require.True(t, d.ResolvedAt(now))
+	require.Equal(t, map[model.Fingerprint]struct{}{
+		a.Fingerprint(): {},
+		b.Fingerprint(): {},
+		c.Fingerprint(): {},
+		d.Fingerprint(): {},
+	}, fps)
+}
+
+func TestTimeStagesDropMode(t *testing.T) {
+	// Outside of the active time interval.
+	times := map[string][]timeinterval.TimeInterval{
+		"never": {{Years: []timeinterval.YearRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 1970, End: 1970}}}}},
+	}
+	alerts := []*types.Alert{newDeferTestAlert("a", time.Time{})}
+
+	ctx := WithNow(context.Background(), time.Unix(1000000000, 0))
+	ctx = WithActiveTimeIntervals(ctx, []string{"never"})
+	ctx = WithMuteTimeIntervals(ctx, nil)
+
+	// Without a time interval mode the notifications are dropped and the
+	// deferred alerts are never touched.
+	tas := NewTimeActiveStage(nil, times)
+	_, res, err := tas.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	tms := NewTimeMuteStage(nil, times)
+	_, res, err = tms.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Equal(t, alerts, res)
+}
+
+func TestDeferredAlertsClearedAfterDelivery(t *testing.T) {
+	srv := redistest.Run(t)
+	rdb := srv.Client(t)
+	now := time.Unix(1000000000, 0)
+
+	ctx := WithNow(context.Background(), now)
+	ctx = WithReceiverName(ctx, "team-db")
+	ctx = WithGroupKey(ctx, "group")
+	ctx = WithTimeIntervalMode(ctx, config.TimeIntervalModeDefer)
+	ctx = WithActiveTimeIntervals(ctx, nil)
+	key, err := deferredKey(ctx)
+	require.NoError(t, err)
+
+	d := deferredAlerts{rdb: rdb}
+	deferred := newDeferTestAlert("deferred", now.Add(-time.Minute))
+	require.NoError(t, d.add(ctx, key, []*types.Alert{deferred}))
+
+	var (
+		fail      = true
+		delivered []*types.Alert
+	)
+	stage := MultiStage{
+		NewTimeActiveStage(rdb, nil),
+		NewClearDeferredStage(rdb, StageFunc(func(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+			if fail {
+				return ctx, nil, errors.New("delivery failed")
+			}
+			delivered = append(delivered, alerts...)
+			return ctx, nil, nil
+		})),
+	}
+	firing := newDeferTestAlert("firing", time.Time{})
+
+	// The deferred alerts are kept if the delivery failed.
+	_, _, err = stage.Exec(ctx, log.NewNopLogger(), firing)
+	require.Error(t, err)
+	queued, err := d.get(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+
+	// Once delivered, they are removed from the queue.
+	fail = false
+	_, _, err = stage.Exec(ctx, log.NewNopLogger(), firing)
+	require.NoError(t, err)
+	require.Len(t, delivered, 2)
+	require.Equal(t, deferred.Fingerprint(), delivered[0].Fingerprint())
+	require.False(t, srv.Exists(key))
+}
@@ -0,0 +1,273 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// EnrichRequest is the JSON object sent to enrichers.
+type EnrichRequest struct {
+	Receiver    string         `json:"receiver"`
+	GroupKey    string         `json:"groupKey"`
+	GroupLabels model.LabelSet `json:"groupLabels"`
+	Alerts      []EnrichAlert  `json:"alerts"`
+}
+
+// EnrichAlert holds the labels and annotations of a single alert. It is used
+// both in requests to and responses from enrichers.
+type EnrichAlert struct {
+	Fingerprint string         `json:"fingerprint"`
+	Labels      model.LabelSet `json:"labels,omitempty"`
+	Annotations model.LabelSet `json:"annotations,omitempty"`
+}
+
+// EnrichResponse is the JSON object expected from enrichers. Alerts are
+// matched by fingerprint; alerts missing from the response are not enriched.
+type EnrichResponse struct {
+	Alerts []EnrichAlert `json:"alerts"`
+}
+
+// enrichment is a cached enricher result for a single alert.
+type enrichment struct {
+	labels      model.LabelSet
+	annotations model.LabelSet
+	expiresAt   time.Time
+}
+
+// Enricher calls an external service to retrieve additional labels and
+// annotations of alerts. Results are cached by alert fingerprint.
+type Enricher struct {
+	conf   *config.EnricherConfig
+	client *http.Client
+	now    func() time.Time
+
+	mtx       sync.Mutex
+	cache     map[model.Fingerprint]*enrichment
+	lastPrune time.Time
+}
+
+// NewEnricher returns a new Enricher for the given configuration.
+func NewEnricher(conf *config.EnricherConfig, httpOpts ...commoncfg.HTTPClientOption) (*Enricher, error) {
+	httpConfig := commoncfg.DefaultHTTPClientConfig
+	if conf.HTTPConfig != nil {
+		httpConfig = *conf.HTTPConfig
+	}
+	client, err := commoncfg.NewClientFromConfig(httpConfig, "enricher", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Enricher{
+		conf:   conf,
+		client: client,
+		now:    now,
+		cache:  map[model.Fingerprint]*enrichment{},
+	}, nil
+}
+
+// Enrich returns copies of the alerts with the labels and annotations
+// returned by the enricher merged into them. Labels already present on an
+// alert are kept, annotations are overwritten. If the enricher cannot be
+// reached, the last known enrichment of an alert is used, if any.
+func (e *Enricher) Enrich(ctx context.Context, alerts ...*types.Alert) ([]*types.Alert, error) {
+	now := e.now()
+
+	e.mtx.Lock()
+	e.prune(now)
+	var missing []*types.Alert
+	for _, a := range alerts {
+		if en, ok := e.cache[a.Fingerprint()]; !ok || now.After(en.expiresAt) {
+			missing = append(missing, a)
+		}
+	}
+	e.mtx.Unlock()
+
+	var err error
+	if len(missing) > 0 {
+		err = e.fetch(ctx, now, missing...)
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	res := make([]*types.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		en, ok := e.cache[a.Fingerprint()]
+		if !ok {
+			res = append(res, a)
+			continue
+		}
+		res = append(res, en.apply(a))
+	}
+	return res, err
+}
+
+// fetch requests the enrichment of the given alerts and stores the result
+// in the cache.
+func (e *Enricher) fetch(ctx context.Context, now time.Time, alerts ...*types.Alert) error {
+	req := EnrichRequest{
+		Alerts: make([]EnrichAlert, 0, len(alerts)),
+	}
+	req.Receiver, _ = ReceiverName(ctx)
+	req.GroupKey, _ = GroupKey(ctx)
+	req.GroupLabels, _ = GroupLabels(ctx)
+	for _, a := range alerts {
+		req.Alerts = append(req.Alerts, EnrichAlert{
+			Fingerprint: a.Fingerprint().String(),
+			Labels:      a.Labels,
+			Annotations: a.Annotations,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(e.conf.Timeout))
+	defer cancel()
+
+	resp, err := PostJSON(ctx, e.client, e.conf.URL.String(), &buf)
+	if err != nil {
+		return RedactURL(err)
+	}
+	defer Drain(resp)
+
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+
+	var res EnrichResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return errors.Wrap(err, "failed to decode enricher response")
+	}
+
+	expiresAt := now.Add(time.Duration(e.conf.CacheTTL))
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	// Alerts missing from the response are cached as well to not request
+	// them again before the cache expires.
+	for _, a := range alerts {
+		e.cache[a.Fingerprint()] = &enrichment{expiresAt: expiresAt}
+	}
+	for _, ea := range res.Alerts {
+		fp, err := model.FingerprintFromString(ea.Fingerprint)
+		if err != nil {
+			continue
+		}
+		en, ok := e.cache[fp]
+		if !ok {
+			continue
+		}
+		en.labels = ea.Labels
+		en.annotations = ea.Annotations
+	}
+	return nil
+}
+
+// prune removes cache entries which expired more than a cache TTL ago. Those
+// are kept in between to serve as fallback if the enricher is unavailable.
+// The caller must hold the lock.
+func (e *Enricher) prune(now time.Time) {
+	ttl := time.Duration(e.conf.CacheTTL)
+	if now.Sub(e.lastPrune) < ttl {
+		return
+	}
+	for fp, en := range e.cache {
+		if now.Sub(en.expiresAt) > ttl {
+			delete(e.cache, fp)
+		}
+	}
+	e.lastPrune = now
+}
+
+// apply returns a copy of the alert with the enrichment merged into it.
+func (en *enrichment) apply(a *types.Alert) *types.Alert {
+	if len(en.labels) == 0 && len(en.annotations) == 0 {
+		return a
+	}
+	res := *a
+	res.Labels = en.labels.Merge(a.Labels)
+	res.Annotations = a.Annotations.Merge(en.annotations)
+	return &res
+}
+
+// EnrichStage merges the labels and annotations returned by the enricher of
+// the route into the alerts. Failing enrichers do not block notifications.
+type EnrichStage struct {
+	enrichers map[string]*Enricher
+	metrics   *Metrics
+}
+
+// NewEnrichStage returns a new EnrichStage.
+func NewEnrichStage(enrichers map[string]*Enricher, metrics *Metrics) *EnrichStage {
+	return &EnrichStage{
+		enrichers: enrichers,
+		metrics:   metrics,
+	}
+}
+
+// Exec implements the Stage interface.
+func (n *EnrichStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	name, ok := EnricherName(ctx)
+	if !ok || name == "" {
+		return ctx, alerts, nil
+	}
+	e, ok := n.enrichers[name]
+	if !ok {
+		return ctx, alerts, errors.Errorf("enricher %s doesn't exist in config", name)
+	}
+
+	enriched, err := e.Enrich(ctx, alerts...)
+	if err != nil {
+		n.metrics.numEnrichmentRequestsFailedTotal.WithLabelValues(name).Inc()
+		level.Warn(l).Log("msg", "Enrichment failed, notifying with unenriched alerts", "enricher", name, "err", err)
+	}
+
+	orig := make(map[model.Fingerprint]model.LabelSet, len(enriched))
+	for i, a := range enriched {
+		if a != alerts[i] {
+			orig[a.Fingerprint()] = alerts[i].Labels
+		}
+	}
+	return WithOriginalLabels(ctx, orig), enriched, nil
+}
+
+// unenriched returns the alert with the labels it had before enrichment.
+func unenriched(ctx context.Context, a *types.Alert) *types.Alert {
+	orig, _ := OriginalLabels(ctx)
+	ls, ok := orig[a.Fingerprint()]
+	if !ok {
+		return a
+	}
+	res := *a
+	res.Labels = ls
+	return &res
+}
@@ -0,0 +1,180 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestEnricher(t *testing.T, u string, timeout time.Duration) *Enricher {
+	t.Helper()
+
+	pu, err := url.Parse(u)
+	require.NoError(t, err)
+
+	conf := config.DefaultEnricherConfig
+	conf.Name = "cmdb"
+	conf.URL = &config.SecretURL{URL: pu}
+	conf.Timeout = model.Duration(timeout)
+
+	e, err := NewEnricher(&conf)
+	require.NoError(t, err)
+	return e
+}
+
+func newEnrichTestAlert(name string) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name), "service": "db"},
+			Annotations: model.LabelSet{"summary": "down"},
+		},
+	}
+}
+
+func TestEnrichStage(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		var req EnrichRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "team-db", req.Receiver)
+
+		var res EnrichResponse
+		for _, a := range req.Alerts {
+			res.Alerts = append(res.Alerts, EnrichAlert{
+				Fingerprint: a.Fingerprint,
+				Labels:      model.LabelSet{"owner": "team-db", "service": "ignored"},
+				Annotations: model.LabelSet{"runbook": "https://runbooks/db", "summary": "database down"},
+			})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	defer srv.Close()
+
+	stage := NewEnrichStage(
+		map[string]*Enricher{"cmdb": newTestEnricher(t, srv.URL, time.Second)},
+		NewMetrics(prometheus.NewRegistry()),
+	)
+
+	ctx := WithReceiverName(context.Background(), "team-db")
+	ctx = WithEnricherName(ctx, "cmdb")
+
+	alert := newEnrichTestAlert("DBDown")
+	for i := 0; i < 2; i++ {
+		resCtx, res, err := stage.Exec(ctx, log.NewNopLogger(), alert)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, model.LabelSet{"alertname": "DBDown", "service": "db", "owner": "team-db"}, res[0].Labels)
+		require.Equal(t, model.LabelSet{"summary": "database down", "runbook": "https://runbooks/db"}, res[0].Annotations)
+		// The notification state is keyed by the labels before enrichment.
+		require.Equal(t, hashAlert(alert), hashAlert(unenriched(resCtx, res[0])))
+	}
+
+	// The second execution is served from the cache.
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	// The original alert is not modified.
+	require.Equal(t, model.LabelSet{"alertname": "DBDown", "service": "db"}, alert.Labels)
+}
+
+func TestEnrichStageFailOpen(t *testing.T) {
+	var fail int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&fail) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var req EnrichRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		res := EnrichResponse{}
+		for _, a := range req.Alerts {
+			res.Alerts = append(res.Alerts, EnrichAlert{
+				Fingerprint: a.Fingerprint,
+				Labels:      model.LabelSet{"owner": "team-db"},
+			})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	defer srv.Close()
+
+	e := newTestEnricher(t, srv.URL, time.Second)
+	current := time.Now()
+	e.now = func() time.Time { return current }
+	stage := NewEnrichStage(map[string]*Enricher{"cmdb": e}, NewMetrics(prometheus.NewRegistry()))
+	ctx := WithEnricherName(context.Background(), "cmdb")
+
+	cached, other := newEnrichTestAlert("Cached"), newEnrichTestAlert("Other")
+	_, _, err := stage.Exec(ctx, log.NewNopLogger(), cached)
+	require.NoError(t, err)
+
+	// Once the cache expired and the enricher fails, the stale enrichment
+	// is used and unknown alerts pass unmodified.
+	atomic.StoreInt32(&fail, 1)
+	current = current.Add(time.Duration(config.DefaultEnricherConfig.CacheTTL) + time.Second)
+
+	_, res, err := stage.Exec(ctx, log.NewNopLogger(), cached, other)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, model.LabelValue("team-db"), res[0].Labels["owner"])
+	require.Equal(t, other, res[1])
+}
+
+func TestEnrichStageTimeout(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	stage := NewEnrichStage(
+		map[string]*Enricher{"cmdb": newTestEnricher(t, srv.URL, 50*time.Millisecond)},
+		NewMetrics(prometheus.NewRegistry()),
+	)
+	ctx := WithEnricherName(context.Background(), "cmdb")
+
+	alert := newEnrichTestAlert("DBDown")
+	start := time.Now()
+	_, res, err := stage.Exec(ctx, log.NewNopLogger(), alert)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, []*types.Alert{alert}, res)
+}
+
+func TestEnrichStageWithoutEnricher(t *testing.T) {
+	stage := NewEnrichStage(map[string]*Enricher{}, NewMetrics(prometheus.NewRegistry()))
+	alert := newEnrichTestAlert("DBDown")
+
+	_, res, err := stage.Exec(context.Background(), log.NewNopLogger(), alert)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{alert}, res)
+
+	_, _, err = stage.Exec(WithEnricherName(context.Background(), "missing"), log.NewNopLogger(), alert)
+	require.EqualError(t, err, "enricher missing doesn't exist in config")
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/redis/go-redis/v9"
 
+	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/inhibit"
 	"github.com/prometheus/alertmanager/nflog/nflogpb"
 	"github.com/prometheus/alertmanager/silence"
@@ -135,6 +136,14 @@ const (
 	keyMuteTimeIntervals
 	keyActiveTimeIntervals
 	keyRuleUID
+	keyEnricherName
+	keyDigester
+	keyNotifyMode
+	keyDelta
+	keyTimeIntervalMode
+	keyDeferredAlerts
+	keyOriginalLabels
+	keyMutedIntegrations
 )
 
 // WithRuleUID populates a context with a receiver name.
@@ -186,6 +195,52 @@ func WithActiveTimeIntervals(ctx context.Context, at []string) context.Context {
 	return context.WithValue(ctx, keyActiveTimeIntervals, at)
 }
 
+// WithEnricherName populates a context with the name of an enricher.
+func WithEnricherName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, keyEnricherName, name)
+}
+
+// WithDigester populates a context with a digester collecting the alerts
+// instead of notifying about them.
+func WithDigester(ctx context.Context, d Digester) context.Context {
+	return context.WithValue(ctx, keyDigester, d)
+}
+
+// WithNotifyMode populates a context with the notify mode of a route.
+func WithNotifyMode(ctx context.Context, m config.NotifyMode) context.Context {
+	return context.WithValue(ctx, keyNotifyMode, m)
+}
+
+// WithTimeIntervalMode populates a context with the time interval mode of a
+// route.
+func WithTimeIntervalMode(ctx context.Context, m config.TimeIntervalMode) context.Context {
+	return context.WithValue(ctx, keyTimeIntervalMode, m)
+}
+
+// WithDeferredAlerts populates a context with the fingerprints of the
+// alerts whose notifications were deferred by time intervals.
+func WithDeferredAlerts(ctx context.Context, fps map[model.Fingerprint]struct{}) context.Context {
+	return context.WithValue(ctx, keyDeferredAlerts, fps)
+}
+
+// WithOriginalLabels populates a context with the labels the alerts had
+// before enrichment, keyed by the fingerprint of the enriched alerts.
+func WithOriginalLabels(ctx context.Context, ls map[model.Fingerprint]model.LabelSet) context.Context {
+	return context.WithValue(ctx, keyOriginalLabels, ls)
+}
+
+// WithMutedIntegrations populates a context with the integrations of the
+// receiver each alert is silenced for, keyed by the fingerprint of the alert.
+func WithMutedIntegrations(ctx context.Context, m map[model.Fingerprint][]string) context.Context {
+	return context.WithValue(ctx, keyMutedIntegrations, m)
+}
+
+// WithDelta populates a context with the changes of a group since its
+// previous notification.
+func WithDelta(ctx context.Context, d *Delta) context.Context {
+	return context.WithValue(ctx, keyDelta, d)
+}
+
 // RepeatInterval extracts a repeat interval from the context. Iff none exists, the
 // second argument is false.
 func RepeatInterval(ctx context.Context) (time.Duration, bool) {
@@ -254,6 +309,73 @@ func ActiveTimeIntervalNames(ctx context.Context) ([]string, bool) {
 	return v, ok
 }
 
+// EnricherName extracts the name of an enricher from the context. Iff none exists, the
+// second argument is false.
+func EnricherName(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(keyEnricherName).(string)
+	return v, ok
+}
+
+// digester extracts a digester from the context. Iff none exists, the
+// second argument is false.
+func digester(ctx context.Context) (Digester, bool) {
+	v, ok := ctx.Value(keyDigester).(Digester)
+	return v, ok
+}
+
+// NotifyMode extracts the notify mode from the context. Iff none exists, the
+// second argument is false.
+func NotifyMode(ctx context.Context) (config.NotifyMode, bool) {
+	v, ok := ctx.Value(keyNotifyMode).(config.NotifyMode)
+	return v, ok
+}
+
+// TimeIntervalMode extracts the time interval mode from the context. Iff none
+// exists, the second argument is false.
+func TimeIntervalMode(ctx context.Context) (config.TimeIntervalMode, bool) {
+	v, ok := ctx.Value(keyTimeIntervalMode).(config.TimeIntervalMode)
+	return v, ok
+}
+
+// DeferredAlerts extracts the fingerprints of the deferred alerts from the
+// context. Iff none exists, the second argument is false.
+func DeferredAlerts(ctx context.Context) (map[model.Fingerprint]struct{}, bool) {
+	v, ok := ctx.Value(keyDeferredAlerts).(map[model.Fingerprint]struct{})
+	return v, ok
+}
+
+// OriginalLabels extracts the labels the alerts had before enrichment from
+// the context. Iff none exist, the second argument is false.
+func OriginalLabels(ctx context.Context) (map[model.Fingerprint]model.LabelSet, bool) {
+	v, ok := ctx.Value(keyOriginalLabels).(map[model.Fingerprint]model.LabelSet)
+	return v, ok
+}
+
+// MutedIntegrations extracts the integrations each alert is silenced for from
+// the context. Iff none exist, the second argument is false.
+func MutedIntegrations(ctx context.Context) (map[model.Fingerprint][]string, bool) {
+	v, ok := ctx.Value(keyMutedIntegrations).(map[model.Fingerprint][]string)
+	return v, ok
+}
+
+// DeltaAlerts extracts the changes of a group since its previous notification
+// from the context. Iff none exists, the second argument is false.
+func DeltaAlerts(ctx context.Context) (*Delta, bool) {
+	v, ok := ctx.Value(keyDelta).(*Delta)
+	return v, ok
+}
+
+// Delta holds the changes of an aggregation group since its previous
+// notification, as computed by the DedupStage in delta notify mode.
+type Delta struct {
+	// The firing alerts which were not notified before or changed stage.
+	NewFiring []*types.Alert
+	// The firing alerts which were already notified.
+	StillFiring []*types.Alert
+	// The resolved alerts which were notified while firing.
+	NewlyResolved []*types.Alert
+}
+
 // A Stage processes alerts under the constraints of the given context.
 type Stage interface {
 	Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error)
@@ -273,6 +395,7 @@ type Metrics struct {
 	numNotificationRequestsTotal       *prometheus.CounterVec
 	numNotificationRequestsFailedTotal *prometheus.CounterVec
 	notificationLatencySeconds         *prometheus.HistogramVec
+	numEnrichmentRequestsFailedTotal   *prometheus.CounterVec
 }
 
 func NewMetrics(r prometheus.Registerer) *Metrics {
@@ -303,6 +426,11 @@ func NewMetrics(r prometheus.Registerer) *Metrics {
 			Help:      "The latency of notifications in seconds.",
 			Buckets:   []float64{1, 5, 10, 15, 20},
 		}, []string{"integration"}),
+		numEnrichmentRequestsFailedTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "enrichment_requests_failed_total",
+			Help:      "The total number of failed enrichment requests.",
+		}, []string{"enricher"}),
 	}
 	for _, integration := range []string{
 		"email",
@@ -332,7 +460,7 @@ func NewMetrics(r prometheus.Registerer) *Metrics {
 	r.MustRegister(
 		m.numNotifications, m.numTotalFailedNotifications,
 		m.numNotificationRequestsTotal, m.numNotificationRequestsFailedTotal,
-		m.notificationLatencySeconds,
+		m.notificationLatencySeconds, m.numEnrichmentRequestsFailedTotal,
 	)
 	return m
 }
@@ -353,17 +481,33 @@ func (pb *PipelineBuilder) New(
 	receivers []*Receiver,
 	inhibitor *inhibit.Inhibitor,
 	silencer *silence.Silencer,
+	flaps FlapDetector,
 	times map[string][]timeinterval.TimeInterval,
+	enrichers map[string]*Enricher,
 ) RoutingStage {
 	rs := make(RoutingStage, len(receivers))
 	is := NewMuteStage(inhibitor)
-	tas := NewTimeActiveStage(times)
-	tms := NewTimeMuteStage(times)
-	ss := NewMuteStage(silencer)
+	tas := NewTimeActiveStage(rdb, times)
+	tms := NewTimeMuteStage(rdb, times)
+	ss := NewSilenceStage(silencer)
+	fs := NewFlapStage(flaps)
+	es := NewEnrichStage(enrichers, pb.metrics)
 
 	for _, r := range receivers {
 		st := createReceiverStage(r, pb.metrics, rdb)
-		rs[r.groupName] = MultiStage{is, tas, tms, ss, st}
+		rs[r.groupName] = MultiStage{is, tas, tms, ss, fs, es, st}
+	}
+	return rs
+}
+
+// NewReceiverStages returns a RoutingStage which sends notifications straight
+// to the integrations of the receivers, without inhibiting, time muting or
+// silencing them. It is meant for notifications about Alertmanager itself,
+// such as the silence reminders.
+func (pb *PipelineBuilder) NewReceiverStages(rdb redis.Cmdable, receivers []*Receiver) RoutingStage {
+	rs := make(RoutingStage, len(receivers))
+	for _, r := range receivers {
+		rs[r.groupName] = createReceiverStage(r, pb.metrics, rdb)
 	}
 	return rs
 }
@@ -382,12 +526,14 @@ func createReceiverStage(
 			Idx:         uint32(receiver.integrations[i].Index()),
 		}
 		var s MultiStage
+		s = append(s, NewIntegrationSilenceStage(receiver.integrations[i].Name()))
 		s = append(s, NewDedupStage(rdb, receiver.integrations[i], recv))
+		s = append(s, NewDigestStage())
 		s = append(s, NewRetryStage(receiver.integrations[i], receiver.groupName, metrics))
 
 		fs = append(fs, s)
 	}
-	return fs
+	return NewClearDeferredStage(rdb, fs)
 }
 
 // RoutingStage executes the inner stages based on the receiver specified in
@@ -482,6 +628,146 @@ func (n *MuteStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Ale
 	return ctx, filtered, nil
 }
 
+// ReceiverMuter determines the integrations of a receiver alerts are muted
+// for.
+type ReceiverMuter interface {
+	// MutedIntegrations returns whether the label set is muted for all
+	// integrations of the receiver and, if not, the integrations it is
+	// muted for.
+	MutedIntegrations(lset model.LabelSet, receiver string) (bool, []string)
+}
+
+// SilenceStage filters alerts through the silences applying to the receiver
+// in the context. The integrations alerts are silenced for are stored in the
+// context for the IntegrationSilenceStage of each integration, so that the
+// silences are queried once per notification.
+type SilenceStage struct {
+	muter ReceiverMuter
+}
+
+// NewSilenceStage returns a new SilenceStage.
+func NewSilenceStage(m ReceiverMuter) *SilenceStage {
+	return &SilenceStage{muter: m}
+}
+
+// Exec implements the Stage interface.
+func (n *SilenceStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	receiver, ok := ReceiverName(ctx)
+	if !ok {
+		return ctx, nil, errors.New("receiver missing")
+	}
+	var (
+		filtered []*types.Alert
+		muted    = map[model.Fingerprint][]string{}
+	)
+	for _, a := range alerts {
+		all, integrations := n.muter.MutedIntegrations(a.Labels, receiver)
+		if all {
+			continue
+		}
+		if len(integrations) > 0 {
+			muted[a.Fingerprint()] = integrations
+		}
+		filtered = append(filtered, a)
+	}
+	return WithMutedIntegrations(ctx, muted), filtered, nil
+}
+
+// IntegrationSilenceStage filters alerts silenced for an integration of the
+// receiver, as determined by the SilenceStage.
+type IntegrationSilenceStage struct {
+	integration string
+}
+
+// NewIntegrationSilenceStage returns a new IntegrationSilenceStage.
+func NewIntegrationSilenceStage(integration string) *IntegrationSilenceStage {
+	return &IntegrationSilenceStage{integration: integration}
+}
+
+// Exec implements the Stage interface.
+func (n *IntegrationSilenceStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	muted, ok := MutedIntegrations(ctx)
+	if !ok || len(muted) == 0 {
+		return ctx, alerts, nil
+	}
+	var filtered []*types.Alert
+alerts:
+	for _, a := range alerts {
+		// Alerts are keyed on their labels before enrichment.
+		for _, integration := range muted[unenriched(ctx, a).Fingerprint()] {
+			if integration == n.integration {
+				continue alerts
+			}
+		}
+		filtered = append(filtered, a)
+	}
+	return ctx, filtered, nil
+}
+
+// FlapDetector detects alerts which toggle between firing and resolved.
+type FlapDetector interface {
+	// Flapping returns whether the alert is flapping and whether it has been
+	// flapping recently.
+	Flapping(model.Fingerprint) (flapping, recently bool)
+}
+
+// FlapStage holds back firing flapping alerts until they stabilise. Resolved
+// alerts always pass so that an already notified alert is resolved. Alerts
+// which have been flapping recently are marked as such for templating.
+type FlapStage struct {
+	detector FlapDetector
+}
+
+// NewFlapStage returns a new FlapStage.
+func NewFlapStage(d FlapDetector) *FlapStage {
+	return &FlapStage{detector: d}
+}
+
+// Exec implements the Stage interface.
+func (n *FlapStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	var filtered []*types.Alert
+	for _, a := range alerts {
+		flapping, recently := n.detector.Flapping(a.Fingerprint())
+		if flapping && !a.Resolved() {
+			level.Debug(l).Log("msg", "Notification for flapping alert held back", "alert", a)
+			continue
+		}
+		if recently {
+			fa := *a
+			fa.Flapping = true
+			a = &fa
+		}
+		filtered = append(filtered, a)
+	}
+	return ctx, filtered, nil
+}
+
+// Digester collects alerts whose notifications are coalesced into digests.
+type Digester interface {
+	Digest(ctx context.Context, alerts ...*types.Alert)
+}
+
+// DigestStage hands the alerts to the digester of the context, if any, and
+// stops their notification. It runs after the DedupStage so that digested
+// alerts are recorded as notified and aren't notified again once the digest
+// mode ends.
+type DigestStage struct{}
+
+// NewDigestStage returns a new DigestStage.
+func NewDigestStage() *DigestStage {
+	return &DigestStage{}
+}
+
+// Exec implements the Stage interface.
+func (n *DigestStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	d, ok := digester(ctx)
+	if !ok {
+		return ctx, alerts, nil
+	}
+	d.Digest(ctx, alerts...)
+	return ctx, nil, nil
+}
+
 // WaitStage waits for a certain amount of time before continuing or until the
 // context is done.
 type WaitStage struct {
@@ -566,12 +852,23 @@ func (n *DedupStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Al
 	if !ok {
 		return ctx, nil, errors.New("repeat interval missing")
 	}
+	mode, _ := NotifyMode(ctx)
+	deferred, _ := DeferredAlerts(ctx)
 	var firing []uint64
 	var resolved []uint64
 	needsUpdateAlerts := make([]*types.Alert, 0)
+	var delta Delta
+	// The already notified alerts whose repeat interval elapsed, their
+	// hashes and state keys.
+	var repeats []*types.Alert
+	var repeatsFiring []uint64
+	var repeatsKeys []string
 	var hash uint64
 	for _, a := range alerts {
-		hash = n.hash(a)
+		// The notification state is keyed by the labels the alert was
+		// received with, as enrichments may vary between flushes.
+		orig := unenriched(ctx, a)
+		hash = n.hash(orig)
 		sKey := stateKey(gkey, n.recv, hash)
 		if a.Resolved() {
 			resolved = append(resolved, hash)
@@ -581,42 +878,102 @@ func (n *DedupStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Al
 				continue
 			}
 			// If the firing alert send, need send resolved message, otherwise, no need.
-			if exist == 1 {
+			// Alerts which resolved while their notifications were deferred
+			// are sent in the catch-up notification as well.
+			_, wasDeferred := deferred[orig.Fingerprint()]
+			if exist == 1 || wasDeferred {
 				if count, err := n.rdb.Get(ctx, AlertSentPrefix+sKey).Int64(); err == nil {
 					a.SentCount = count
 				}
 				needsUpdateAlerts = append(needsUpdateAlerts, a)
-
+				delta.NewlyResolved = append(delta.NewlyResolved, a)
 			}
 		} else {
 			preStage, err := n.rdb.Get(ctx, sKey).Result()
-			if err != nil && errors.Is(err, redis.Nil) {
-				preStage = a.Stage
-			}
-			if preStage != a.Stage {
-				n.rdb.Del(ctx, sKey)
-			}
-			needsUpdate, err := n.rdb.SetNX(ctx, sKey, a.Stage, repeatInterval).Result()
-			if err != nil {
-				level.Error(l).Log("msg", "Set stateKey to redis failed", "stateKey", sKey, "stage", a.Stage, "err", err)
+			notified := err == nil
+			if err != nil && !errors.Is(err, redis.Nil) {
+				level.Error(l).Log("msg", "Get stateKey from redis failed", "stateKey", sKey, "err", err)
 				continue
 			}
-			if needsUpdate {
-				firing = append(firing, hash)
-				if count, err := n.rdb.Incr(ctx, AlertSentPrefix+sKey).Result(); err == nil {
-					a.SentCount = count
+			var sent int64
+			if !notified && mode == config.NotifyModeDelta {
+				sent = n.sentCount(ctx, sKey)
+			}
+			switch {
+			case notified && preStage == a.Stage:
+				delta.StillFiring = append(delta.StillFiring, a)
+			case sent > 0:
+				// The repeat interval of the notified alert elapsed.
+				// Whether it is repeated depends on the other alerts, so
+				// its state is only updated once it is sent.
+				a.SentCount = sent
+				delta.StillFiring = append(delta.StillFiring, a)
+				repeats = append(repeats, a)
+				repeatsFiring = append(repeatsFiring, hash)
+				repeatsKeys = append(repeatsKeys, sKey)
+			default:
+				if notified {
+					// The alert changed stage.
+					n.rdb.Del(ctx, sKey)
+				}
+				if n.markSent(ctx, l, sKey, a, repeatInterval) {
+					firing = append(firing, hash)
+					needsUpdateAlerts = append(needsUpdateAlerts, a)
+					delta.NewFiring = append(delta.NewFiring, a)
+				} else {
+					delta.StillFiring = append(delta.StillFiring, a)
 				}
-				needsUpdateAlerts = append(needsUpdateAlerts, a)
 			}
 		}
 		ctx = WithRuleUID(ctx, a.RuleUID)
 	}
+	if mode == config.NotifyModeDelta {
+		// Repeated notifications are only sent if nothing else changed,
+		// otherwise the repeated alerts are only summarized as still firing.
+		if len(needsUpdateAlerts) == 0 {
+			for i, a := range repeats {
+				if n.markSent(ctx, l, repeatsKeys[i], a, repeatInterval) {
+					firing = append(firing, repeatsFiring[i])
+					needsUpdateAlerts = append(needsUpdateAlerts, a)
+				}
+			}
+		}
+		ctx = WithDelta(ctx, &delta)
+	}
 	ctx = WithFiringAlerts(ctx, firing)
 	ctx = WithResolvedAlerts(ctx, resolved)
 
 	return ctx, needsUpdateAlerts, nil
 }
 
+// sentCount returns the number of notifications sent about the alert with the
+// given state key.
+func (n *DedupStage) sentCount(ctx context.Context, sKey string) int64 {
+	count, err := n.rdb.Get(ctx, AlertSentPrefix+sKey).Int64()
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// markSent records the notification of the firing alert for the repeat
+// interval and counts it. It returns false if the alert was notified in the
+// meantime.
+func (n *DedupStage) markSent(ctx context.Context, l log.Logger, sKey string, a *types.Alert, repeatInterval time.Duration) bool {
+	ok, err := n.rdb.SetNX(ctx, sKey, a.Stage, repeatInterval).Result()
+	if err != nil {
+		level.Error(l).Log("msg", "Set stateKey to redis failed", "stateKey", sKey, "stage", a.Stage, "err", err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if count, err := n.rdb.Incr(ctx, AlertSentPrefix+sKey).Result(); err == nil {
+		a.SentCount = count
+	}
+	return true
+}
+
 // RetryStage notifies via passed integration with exponential backoff until it
 // succeeds. It aborts if the context is canceled or timed out.
 type RetryStage struct {
@@ -795,14 +1152,51 @@ func (n ClearSKeyStage) Exec(ctx context.Context, l log.Logger, alerts ...*types
 	return ctx, alerts, nil
 }
 
+// ClearDeferredStage executes the notification of a receiver and, once it
+// was delivered through all integrations, removes the deferred alerts added
+// to it from the queue. If the delivery failed, they are kept for the next
+// notification.
+type ClearDeferredStage struct {
+	stage    Stage
+	deferred deferredAlerts
+}
+
+// NewClearDeferredStage returns a new ClearDeferredStage executing the given
+// stage.
+func NewClearDeferredStage(rdb redis.Cmdable, s Stage) *ClearDeferredStage {
+	return &ClearDeferredStage{stage: s, deferred: deferredAlerts{rdb: rdb}}
+}
+
+// Exec implements the Stage interface.
+func (n *ClearDeferredStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	resCtx, res, err := n.stage.Exec(ctx, l, alerts...)
+	if err != nil {
+		return resCtx, res, err
+	}
+	fps, ok := DeferredAlerts(ctx)
+	if !ok || len(fps) == 0 {
+		return resCtx, res, nil
+	}
+	key, err := deferredKey(ctx)
+	if err != nil {
+		return resCtx, res, err
+	}
+	if err := n.deferred.remove(ctx, key, fps); err != nil {
+		// The deferred alerts are sent again with the next notification.
+		level.Error(l).Log("msg", "Del deferred notifications from redis failed", "key", key, "err", err)
+	}
+	return resCtx, res, nil
+}
+
 type timeStage struct {
-	Times map[string][]timeinterval.TimeInterval
+	Times    map[string][]timeinterval.TimeInterval
+	deferred deferredAlerts
 }
 
 type TimeMuteStage timeStage
 
-func NewTimeMuteStage(ti map[string][]timeinterval.TimeInterval) *TimeMuteStage {
-	return &TimeMuteStage{ti}
+func NewTimeMuteStage(rdb redis.Cmdable, ti map[string][]timeinterval.TimeInterval) *TimeMuteStage {
+	return &TimeMuteStage{Times: ti, deferred: deferredAlerts{rdb: rdb}}
 }
 
 // Exec implements the stage interface for TimeMuteStage.
@@ -825,15 +1219,15 @@ func (tms TimeMuteStage) Exec(ctx context.Context, l log.Logger, alerts ...*type
 	// If the current time is inside a mute time, all alerts are removed from the pipeline.
 	if muted {
 		level.Debug(l).Log("msg", "Notifications not sent, route is within mute time")
-		return ctx, nil, nil
+		return timeStage(tms).suppress(ctx, alerts)
 	}
-	return ctx, alerts, nil
+	return timeStage(tms).pass(ctx, l, now, alerts)
 }
 
 type TimeActiveStage timeStage
 
-func NewTimeActiveStage(ti map[string][]timeinterval.TimeInterval) *TimeActiveStage {
-	return &TimeActiveStage{ti}
+func NewTimeActiveStage(rdb redis.Cmdable, ti map[string][]timeinterval.TimeInterval) *TimeActiveStage {
+	return &TimeActiveStage{Times: ti, deferred: deferredAlerts{rdb: rdb}}
 }
 
 // Exec implements the stage interface for TimeActiveStage.
@@ -844,16 +1238,16 @@ func (tas TimeActiveStage) Exec(ctx context.Context, l log.Logger, alerts ...*ty
 		return ctx, alerts, nil
 	}
 
-	// if we don't have active time intervals at all it is always active.
-	if len(activeTimeIntervalNames) == 0 {
-		return ctx, alerts, nil
-	}
-
 	now, ok := Now(ctx)
 	if !ok {
 		return ctx, alerts, errors.New("missing now timestamp")
 	}
 
+	// if we don't have active time intervals at all it is always active.
+	if len(activeTimeIntervalNames) == 0 {
+		return timeStage(tas).pass(ctx, l, now, alerts)
+	}
+
 	active, err := inTimeIntervals(now, tas.Times, activeTimeIntervalNames)
 	if err != nil {
 		return ctx, alerts, err
@@ -862,10 +1256,60 @@ func (tas TimeActiveStage) Exec(ctx context.Context, l log.Logger, alerts ...*ty
 	// If the current time is not inside an active time, all alerts are removed from the pipeline
 	if !active {
 		level.Debug(l).Log("msg", "Notifications not sent, route is not within active time")
+		return timeStage(tas).suppress(ctx, alerts)
+	}
+
+	return timeStage(tas).pass(ctx, l, now, alerts)
+}
+
+// suppress removes the alerts from the pipeline. In defer mode, the alerts
+// are queued until the route is no longer muted.
+func (ts timeStage) suppress(ctx context.Context, alerts []*types.Alert) (context.Context, []*types.Alert, error) {
+	if mode, _ := TimeIntervalMode(ctx); mode != config.TimeIntervalModeDefer {
 		return ctx, nil, nil
 	}
+	key, err := deferredKey(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	// Failing the pipeline keeps the resolved alerts in the aggregation
+	// group until they are deferred.
+	if err := ts.deferred.add(ctx, key, alerts); err != nil {
+		return ctx, nil, errors.Wrap(err, "defer notifications")
+	}
+	return ctx, nil, nil
+}
 
-	return ctx, alerts, nil
+// pass returns the alerts to continue the pipeline with. In defer mode, the
+// queued alerts of the aggregation group are added for a catch-up
+// notification. They are removed from the queue by the ClearDeferredStage
+// once the notification was delivered.
+func (ts timeStage) pass(ctx context.Context, l log.Logger, now time.Time, alerts []*types.Alert) (context.Context, []*types.Alert, error) {
+	if mode, _ := TimeIntervalMode(ctx); mode != config.TimeIntervalModeDefer {
+		return ctx, alerts, nil
+	}
+	key, err := deferredKey(ctx)
+	if err != nil {
+		return ctx, alerts, err
+	}
+	deferred, err := ts.deferred.get(ctx, key)
+	if err != nil {
+		// The deferred alerts are kept for the next notification.
+		level.Error(l).Log("msg", "Get deferred notifications from redis failed", "key", key, "err", err)
+		return ctx, alerts, nil
+	}
+	if len(deferred) == 0 {
+		return ctx, alerts, nil
+	}
+	level.Debug(l).Log("msg", "Sending deferred notifications", "num_alerts", len(deferred))
+
+	alerts, fps := mergeDeferred(alerts, deferred, now)
+	if prev, ok := DeferredAlerts(ctx); ok {
+		for fp := range prev {
+			fps[fp] = struct{}{}
+		}
+	}
+	return WithDeferredAlerts(ctx, fps), alerts, nil
 }
 
 // inTimeIntervals returns true if the current time is contained in one of the given time intervals.
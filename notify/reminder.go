@@ -0,0 +1,246 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Alert names of the reminders sent to the authors of silences.
+const (
+	SilenceExpiringAlertName = "SilenceExpiring"
+	LongSilenceAlertName     = "LongSilence"
+)
+
+const (
+	// orgSilenceExpiringReminder is the key recording the reminder about the
+	// expiry of a silence at the given end. It expires with the silence.
+	orgSilenceExpiringReminder = "%d_silence_reminder_expiring_%s_%d"
+	// orgLongSilenceReminder is the key recording the last reminder about a
+	// long silence. It expires after the reminder interval.
+	orgLongSilenceReminder = "%d_silence_reminder_long_%s"
+)
+
+// SilenceReminder reminds the authors of silences, through a receiver, that
+// their silences are about to expire and, periodically, that their long
+// silences are still in place. The reminders sent are recorded in Redis, so
+// that they are sent once by all instances and not repeated on restart.
+type SilenceReminder struct {
+	rdb      redis.Cmdable
+	silences *silence.Silences
+	logger   log.Logger
+
+	mtx         sync.Mutex
+	conf        *config.SilenceRemindersConfig
+	stage       Stage
+	externalURL *url.URL
+}
+
+// NewSilenceReminder returns a new SilenceReminder for the silences. It does
+// not send any reminders until it is configured by Update.
+func NewSilenceReminder(rdb redis.Cmdable, s *silence.Silences, l log.Logger) *SilenceReminder {
+	return &SilenceReminder{
+		rdb:      rdb,
+		silences: s,
+		logger:   l,
+	}
+}
+
+// Update sets the configuration, the stage the reminders are executed by and
+// the template providing the external URL of links to silences. The stage
+// must not mute the reminders, see PipelineBuilder.NewReceiverStages. A nil
+// configuration disables the reminders.
+func (r *SilenceReminder) Update(conf *config.SilenceRemindersConfig, stage Stage, tmpl *template.Template) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.conf = conf
+	r.stage = stage
+	r.externalURL = tmpl.ExternalURL
+}
+
+// Run sends the due reminders at the given interval until stopc is closed.
+func (r *SilenceReminder) Run(interval time.Duration, stopc <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := r.Remind(ctx); err != nil {
+				level.Error(r.logger).Log("msg", "Sending silence reminders failed", "err", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// Remind sends the reminders due for the active silences.
+func (r *SilenceReminder) Remind(ctx context.Context) error {
+	r.mtx.Lock()
+	conf, stage, externalURL := r.conf, r.stage, r.externalURL
+	r.mtx.Unlock()
+	if conf == nil {
+		return nil
+	}
+
+	sils, _, err := r.silences.Query(silence.QState(types.SilenceStateActive))
+	if err != nil {
+		return err
+	}
+	now := now()
+	for _, a := range r.due(ctx, conf, sils, now) {
+		sil := a.sil
+		ctx := WithNow(ctx, now)
+		ctx = WithGroupKey(ctx, reminderGroupKey(sil.Id))
+		ctx = WithGroupLabels(ctx, model.LabelSet{
+			model.AlertNameLabel: model.LabelValue(a.name),
+			"silence_id":         model.LabelValue(sil.Id),
+		})
+		ctx = WithReceiverName(ctx, conf.Receiver)
+		ctx = WithRepeatInterval(ctx, time.Duration(conf.LongSilenceInterval))
+
+		if _, _, err := stage.Exec(ctx, r.logger, reminderAlert(a.name, sil, conf, externalURL, now)); err != nil {
+			level.Error(r.logger).Log("msg", "Sending silence reminder failed", "silence", sil.Id, "reminder", a.name, "err", err)
+			// Release the reminder, so that it is sent again by the next run.
+			if err := r.rdb.Del(ctx, a.key).Err(); err != nil {
+				level.Error(r.logger).Log("msg", "Del silence reminder from redis failed", "key", a.key, "err", err)
+			}
+		}
+	}
+	return nil
+}
+
+// reminderGroupKey returns the group key of the reminders about a silence.
+// It is stable so that the notification state of the reminders is kept once
+// per silence.
+func reminderGroupKey(id string) string {
+	return fmt.Sprintf("silence-reminder:%s", id)
+}
+
+type dueReminder struct {
+	name string
+	key  string
+	sil  *silencepb.Silence
+}
+
+// due returns the reminders to send for the active silences and claims them
+// in Redis. A reminder claimed by another instance is not due. The claim of a
+// reminder which could not be sent must be deleted.
+func (r *SilenceReminder) due(ctx context.Context, conf *config.SilenceRemindersConfig, sils []*silencepb.Silence, now time.Time) []dueReminder {
+	orgId := r.silences.OrgId()
+
+	var res []dueReminder
+	for _, sil := range sils {
+		var (
+			name string
+			key  string
+			ttl  time.Duration
+		)
+		// Silences shorter than the reminder period are not worth a reminder.
+		beforeExpiry := time.Duration(conf.BeforeExpiry)
+		switch {
+		case beforeExpiry > 0 && sil.EndsAt.Sub(sil.StartsAt) > beforeExpiry && sil.EndsAt.Sub(now) <= beforeExpiry:
+			// The key contains the end of the silence, so that the author
+			// is reminded again if the silence was extended in the meantime.
+			name = SilenceExpiringAlertName
+			key = fmt.Sprintf(orgSilenceExpiringReminder, orgId, sil.Id, sil.EndsAt.Unix())
+			ttl = sil.EndsAt.Sub(now)
+		case conf.LongSilenceThreshold > 0 && sil.EndsAt.Sub(sil.StartsAt) > time.Duration(conf.LongSilenceThreshold):
+			if now.Sub(sil.StartsAt) < time.Duration(conf.LongSilenceInterval) {
+				continue
+			}
+			name = LongSilenceAlertName
+			key = fmt.Sprintf(orgLongSilenceReminder, orgId, sil.Id)
+			ttl = time.Duration(conf.LongSilenceInterval)
+		default:
+			continue
+		}
+		if ttl <= 0 {
+			continue
+		}
+		ok, err := r.rdb.SetNX(ctx, key, now.Unix(), ttl).Result()
+		if err != nil {
+			level.Error(r.logger).Log("msg", "Recording silence reminder in redis failed", "silence", sil.Id, "reminder", name, "err", err)
+			continue
+		}
+		if ok {
+			res = append(res, dueReminder{name: name, key: key, sil: sil})
+		}
+	}
+	return res
+}
+
+// reminderAlert returns the alert notifying the author of the silence. It
+// fires until the silence ends. Every reminder is a new stage of the alert, so
+// that the deduplication of notifications never holds back a due reminder.
+func reminderAlert(name string, sil *silencepb.Silence, conf *config.SilenceRemindersConfig, externalURL *url.URL, now time.Time) *types.Alert {
+	address, ok := conf.AuthorAddresses[sil.CreatedBy]
+	if !ok {
+		address = sil.CreatedBy
+	}
+	link := fmt.Sprintf("%s/#/silences/%s", externalURL, sil.Id)
+
+	var summary string
+	switch name {
+	case SilenceExpiringAlertName:
+		summary = fmt.Sprintf("Silence %s expires at %s", sil.Id, sil.EndsAt.UTC().Format(time.RFC3339))
+	default:
+		summary = fmt.Sprintf("Silence %s is in place since %s until %s", sil.Id,
+			sil.StartsAt.UTC().Format(time.RFC3339), sil.EndsAt.UTC().Format(time.RFC3339))
+	}
+	annotations := model.LabelSet{
+		"summary":     model.LabelValue(summary),
+		"comment":     model.LabelValue(sil.Comment),
+		"silence_url": model.LabelValue(link),
+	}
+	if ms, err := silence.CompileMatchers(sil); err == nil {
+		annotations["matchers"] = model.LabelValue(ms.String())
+	}
+
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{
+				model.AlertNameLabel: model.LabelValue(name),
+				"silence_id":         model.LabelValue(sil.Id),
+				"author":             model.LabelValue(sil.CreatedBy),
+				"address":            model.LabelValue(address),
+			},
+			Annotations:  annotations,
+			StartsAt:     now,
+			EndsAt:       sil.EndsAt,
+			GeneratorURL: link,
+			Stage:        now.UTC().Format(time.RFC3339Nano),
+		},
+		UpdatedAt: now,
+	}
+}
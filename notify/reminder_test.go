@@ -0,0 +1,220 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/test/redistest"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestSilenceReminder(t *testing.T, srv *redistest.Server) (*SilenceReminder, *silence.Silences) {
+	t.Helper()
+
+	s, err := silence.New(silence.Options{OrgId: 1, Rdb: srv.Client(t)})
+	require.NoError(t, err)
+	return NewSilenceReminder(srv.Client(t), s, log.NewNopLogger()), s
+}
+
+func TestSilenceReminderDue(t *testing.T) {
+	var (
+		now  = time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+		day  = 24 * time.Hour
+		conf = &config.SilenceRemindersConfig{
+			Receiver:             "team-X-mails",
+			BeforeExpiry:         model.Duration(time.Hour),
+			LongSilenceThreshold: model.Duration(7 * day),
+			LongSilenceInterval:  model.Duration(day),
+		}
+		expiring = &silencepb.Silence{Id: "expiring", StartsAt: now.Add(-day), EndsAt: now.Add(30 * time.Minute)}
+		short    = &silencepb.Silence{Id: "short", StartsAt: now.Add(-10 * time.Minute), EndsAt: now.Add(20 * time.Minute)}
+		long     = &silencepb.Silence{Id: "long", StartsAt: now.Add(-2 * day), EndsAt: now.Add(30 * day)}
+		fresh    = &silencepb.Silence{Id: "fresh", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(30 * day)}
+		srv      = redistest.Run(t)
+		r, _     = newTestSilenceReminder(t, srv)
+		other, _ = newTestSilenceReminder(t, srv)
+		ctx      = context.Background()
+	)
+	names := func(due []dueReminder) map[string]string {
+		res := map[string]string{}
+		for _, d := range due {
+			res[d.sil.Id] = d.name
+		}
+		return res
+	}
+
+	sils := []*silencepb.Silence{expiring, short, long, fresh}
+	require.Equal(t, map[string]string{
+		"expiring": SilenceExpiringAlertName,
+		"long":     LongSilenceAlertName,
+	}, names(r.due(ctx, conf, sils, now)))
+
+	// Reminders are not repeated before the interval, neither by the
+	// instance itself nor by other instances.
+	require.Empty(t, r.due(ctx, conf, sils, now.Add(time.Minute)))
+	require.Empty(t, other.due(ctx, conf, sils, now.Add(time.Minute)))
+
+	// An extended silence is reminded of again before its new end.
+	extended := *expiring
+	extended.EndsAt = now.Add(50 * time.Minute)
+	sils = []*silencepb.Silence{&extended, short, long, fresh}
+	require.Equal(t, map[string]string{
+		"expiring": SilenceExpiringAlertName,
+	}, names(r.due(ctx, conf, sils, now.Add(2*time.Minute))))
+
+	srv.FastForward(day)
+	require.Equal(t, map[string]string{
+		"long":  LongSilenceAlertName,
+		"fresh": LongSilenceAlertName,
+	}, names(r.due(ctx, conf, []*silencepb.Silence{long, fresh}, now.Add(day))))
+
+	// The recorded reminders expire.
+	srv.FastForward(day)
+	require.Empty(t, srv.Keys())
+}
+
+type sendResolved bool
+
+func (s sendResolved) SendResolved() bool { return bool(s) }
+
+func TestSilenceReminderRemind(t *testing.T) {
+	srv := redistest.Run(t)
+	r, silences := newTestSilenceReminder(t, srv)
+	other := NewSilenceReminder(srv.Client(t), silences, log.NewNopLogger())
+	ctx := context.Background()
+
+	now := time.Now()
+	sil := &silencepb.Silence{
+		Matchers:  []*silencepb.Matcher{{Name: "job", Pattern: "db", Type: silencepb.Matcher_EQUAL}},
+		StartsAt:  now.Add(-time.Hour),
+		EndsAt:    now.Add(30 * time.Minute),
+		CreatedBy: "alice",
+	}
+	id, err := silences.Set(ctx, sil)
+	require.NoError(t, err)
+
+	var (
+		groupKeys []string
+		sent      []*types.Alert
+		fail      bool
+	)
+	recv := &nflogpb.Receiver{GroupName: "team-X-mails", Integration: "email"}
+	stage := MultiStage{
+		NewDedupStage(srv.Client(t), sendResolved(false), recv),
+		StageFunc(func(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+			if fail {
+				return ctx, nil, errors.New("send failed")
+			}
+			gkey, _ := GroupKey(ctx)
+			groupKeys = append(groupKeys, gkey)
+			sent = append(sent, alerts...)
+			return ctx, alerts, nil
+		}),
+	}
+	conf := &config.SilenceRemindersConfig{
+		Receiver:            "team-X-mails",
+		BeforeExpiry:        model.Duration(time.Hour),
+		LongSilenceInterval: model.Duration(24 * time.Hour),
+	}
+	u, err := url.Parse("http://am.example.org")
+	require.NoError(t, err)
+	tmpl := &template.Template{ExternalURL: u}
+
+	// Without configuration, no reminders are sent.
+	require.NoError(t, r.Remind(ctx))
+	require.Empty(t, sent)
+
+	r.Update(conf, stage, tmpl)
+	other.Update(conf, stage, tmpl)
+
+	require.NoError(t, r.Remind(ctx))
+	require.NoError(t, other.Remind(ctx))
+	require.Len(t, sent, 1)
+	require.Equal(t, model.LabelValue(SilenceExpiringAlertName), sent[0].Labels[model.AlertNameLabel])
+	require.Equal(t, model.LabelValue(id), sent[0].Labels["silence_id"])
+
+	// The extended silence is reminded of again, although the previous
+	// reminder is still within the repeat interval of the deduplication.
+	sil, err = silences.QueryOne(silence.QIDs(id))
+	require.NoError(t, err)
+	sil.EndsAt = sil.EndsAt.Add(10 * time.Minute)
+	_, err = silences.Set(ctx, sil)
+	require.NoError(t, err)
+	require.NoError(t, other.Remind(ctx))
+	require.Len(t, sent, 2)
+	require.Equal(t, int64(2), sent[1].SentCount)
+
+	// All reminders about a silence share its group key, so that the
+	// notification state is kept once per silence.
+	require.Equal(t, []string{reminderGroupKey(id), reminderGroupKey(id)}, groupKeys)
+
+	// A reminder which could not be sent is sent by the next run.
+	sil.EndsAt = sil.EndsAt.Add(10 * time.Minute)
+	_, err = silences.Set(ctx, sil)
+	require.NoError(t, err)
+	fail = true
+	require.NoError(t, r.Remind(ctx))
+	require.Len(t, sent, 2)
+	fail = false
+	require.NoError(t, other.Remind(ctx))
+	require.Len(t, sent, 3)
+	require.Equal(t, model.LabelValue(SilenceExpiringAlertName), sent[2].Labels[model.AlertNameLabel])
+}
+
+func TestReminderAlert(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	u, err := url.Parse("http://am.example.org")
+	require.NoError(t, err)
+	conf := &config.SilenceRemindersConfig{
+		AuthorAddresses: map[string]string{"alice": "alice@example.org"},
+	}
+	sil := &silencepb.Silence{
+		Id:        "abc",
+		CreatedBy: "alice",
+		Comment:   "Maintenance",
+		Matchers:  []*silencepb.Matcher{{Name: "job", Pattern: "db", Type: silencepb.Matcher_EQUAL}},
+		StartsAt:  now.Add(-time.Hour),
+		EndsAt:    now.Add(30 * time.Minute),
+	}
+
+	a := reminderAlert(SilenceExpiringAlertName, sil, conf, u, now)
+	require.Equal(t, model.LabelSet{
+		"alertname":  SilenceExpiringAlertName,
+		"silence_id": "abc",
+		"author":     "alice",
+		"address":    "alice@example.org",
+	}, a.Labels)
+	require.Equal(t, model.LabelValue("http://am.example.org/#/silences/abc"), a.Annotations["silence_url"])
+	require.Equal(t, model.LabelValue(`{job="db"}`), a.Annotations["matchers"])
+	require.Equal(t, model.LabelValue("Silence abc expires at 2024-01-10T12:30:00Z"), a.Annotations["summary"])
+	require.False(t, a.ResolvedAt(now))
+
+	sil.CreatedBy = "bob"
+	a = reminderAlert(LongSilenceAlertName, sil, conf, u, now)
+	require.Equal(t, model.LabelValue("bob"), a.Labels["address"])
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+type fakeReceiverMuter struct {
+	calls int
+	muted map[model.LabelValue][]string
+}
+
+func (m *fakeReceiverMuter) MutedIntegrations(lset model.LabelSet, receiver string) (bool, []string) {
+	m.calls++
+	integrations, ok := m.muted[lset["alertname"]]
+	return ok && integrations == nil, integrations
+}
+
+func TestSilenceStages(t *testing.T) {
+	muter := &fakeReceiverMuter{muted: map[model.LabelValue][]string{
+		"All":   nil,
+		"Email": {"email"},
+	}}
+	all := newEnrichTestAlert("All")
+	email := newEnrichTestAlert("Email")
+	none := newEnrichTestAlert("None")
+
+	ctx := WithReceiverName(context.Background(), "team-db")
+	ctx, res, err := NewSilenceStage(muter).Exec(ctx, log.NewNopLogger(), all, email, none)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{email, none}, res)
+	require.Equal(t, 3, muter.calls)
+
+	// The integrations are checked without querying the silences again,
+	// also after enrichment changed the labels of an alert.
+	enriched := *email
+	enriched.Labels = email.Labels.Merge(model.LabelSet{"owner": "team-db"})
+	ctx = WithOriginalLabels(ctx, map[model.Fingerprint]model.LabelSet{enriched.Fingerprint(): email.Labels})
+
+	_, res, err = NewIntegrationSilenceStage("email").Exec(ctx, log.NewNopLogger(), &enriched, none)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{none}, res)
+
+	_, res, err = NewIntegrationSilenceStage("slack").Exec(ctx, log.NewNopLogger(), &enriched, none)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{&enriched, none}, res)
+	require.Equal(t, 3, muter.calls)
+
+	_, _, err = NewSilenceStage(muter).Exec(context.Background(), log.NewNopLogger(), none)
+	require.EqualError(t, err, "receiver missing")
+}
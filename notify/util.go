@@ -189,7 +189,11 @@ func GetTemplateData(ctx context.Context, tmpl *template.Template, alerts []*typ
 	if !ok {
 		level.Error(l).Log("msg", "Missing group labels")
 	}
-	return tmpl.Data(recv, groupLabels, alerts...)
+	data := tmpl.Data(recv, groupLabels, alerts...)
+	if d, ok := DeltaAlerts(ctx); ok {
+		data.SetDelta(d.NewFiring, d.StillFiring, d.NewlyResolved)
+	}
+	return data
 }
 
 func readAll(r io.Reader) string {
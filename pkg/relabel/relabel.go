@@ -0,0 +1,270 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package relabel implements the relabeling of label sets following the
+// semantics of Prometheus relabel_configs.
+package relabel
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/common/model"
+)
+
+var relabelTarget = regexp.MustCompile(`^(?:(?:[a-zA-Z_]|\$(?:\{\w+\}|\w+))+\w*)+$`)
+
+// Action is the action to be performed on relabeling.
+type Action string
+
+const (
+	// Replace performs a regex replacement.
+	Replace Action = "replace"
+	// Keep drops label sets for which the input does not match the regex.
+	Keep Action = "keep"
+	// Drop drops label sets for which the input does match the regex.
+	Drop Action = "drop"
+	// HashMod sets a label to the modulus of a hash of labels.
+	HashMod Action = "hashmod"
+	// LabelDrop drops any label matching the regex.
+	LabelDrop Action = "labeldrop"
+	// LabelKeep drops any label not matching the regex.
+	LabelKeep Action = "labelkeep"
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Action.
+func (a *Action) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	switch act := Action(strings.ToLower(s)); act {
+	case Replace, Keep, Drop, HashMod, LabelDrop, LabelKeep:
+		*a = act
+		return nil
+	}
+	return fmt.Errorf("unknown relabel action %q", s)
+}
+
+// DefaultRelabelConfig is the default relabel configuration.
+var DefaultRelabelConfig = Config{
+	Action:      Replace,
+	Separator:   ";",
+	Regex:       MustNewRegexp("(.*)"),
+	Replacement: "$1",
+}
+
+// Config is the configuration for relabeling of label sets.
+type Config struct {
+	// A list of labels from which values are taken and concatenated
+	// with the configured separator in order.
+	SourceLabels model.LabelNames `yaml:"source_labels,flow,omitempty" json:"source_labels,omitempty"`
+	// Separator is the string between concatenated values from the source labels.
+	Separator string `yaml:"separator,omitempty" json:"separator,omitempty"`
+	// Regex against which the concatenation is matched.
+	Regex Regexp `yaml:"regex,omitempty" json:"regex,omitempty"`
+	// Modulus to take of the hash of concatenated values from the source labels.
+	Modulus uint64 `yaml:"modulus,omitempty" json:"modulus,omitempty"`
+	// TargetLabel is the label to which the resulting string is written in a replacement.
+	// Regexp interpolation is allowed for the replace action.
+	TargetLabel string `yaml:"target_label,omitempty" json:"target_label,omitempty"`
+	// Replacement is the regex replacement pattern to be used.
+	Replacement string `yaml:"replacement,omitempty" json:"replacement,omitempty"`
+	// Action is the action to be performed for the relabeling.
+	Action Action `yaml:"action,omitempty" json:"action,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Config.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultRelabelConfig
+	type plain Config
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.Regex.Regexp == nil {
+		c.Regex = MustNewRegexp("")
+	}
+	return c.Validate()
+}
+
+// Validate returns an error if the configuration is inconsistent for its
+// action.
+func (c *Config) Validate() error {
+	if c.Action == "" {
+		return fmt.Errorf("relabel action cannot be empty")
+	}
+	if c.Modulus == 0 && c.Action == HashMod {
+		return fmt.Errorf("relabel configuration for hashmod requires non-zero modulus")
+	}
+	if (c.Action == Replace || c.Action == HashMod) && c.TargetLabel == "" {
+		return fmt.Errorf("relabel configuration for %s action requires 'target_label' value", c.Action)
+	}
+	if c.Action == Replace && !relabelTarget.MatchString(c.TargetLabel) {
+		return fmt.Errorf("%q is invalid 'target_label' for %s action", c.TargetLabel, c.Action)
+	}
+	if c.Action == HashMod && !model.LabelName(c.TargetLabel).IsValid() {
+		return fmt.Errorf("%q is invalid 'target_label' for %s action", c.TargetLabel, c.Action)
+	}
+	if c.Action == LabelDrop || c.Action == LabelKeep {
+		if c.SourceLabels != nil ||
+			c.TargetLabel != DefaultRelabelConfig.TargetLabel ||
+			c.Modulus != DefaultRelabelConfig.Modulus ||
+			c.Separator != DefaultRelabelConfig.Separator ||
+			c.Replacement != DefaultRelabelConfig.Replacement {
+			return fmt.Errorf("%s action requires only 'regex', and no other fields", c.Action)
+		}
+	}
+	return nil
+}
+
+// Regexp encapsulates a regexp.Regexp and makes it YAML and JSON
+// marshalable. The regular expression is always anchored.
+type Regexp struct {
+	*regexp.Regexp
+	original string
+}
+
+// NewRegexp creates a new anchored Regexp and returns an error if the
+// passed-in regular expression does not compile.
+func NewRegexp(s string) (Regexp, error) {
+	regex, err := regexp.Compile("^(?:" + s + ")$")
+	return Regexp{Regexp: regex, original: s}, err
+}
+
+// MustNewRegexp works like NewRegexp, but panics if the regular expression
+// does not compile.
+func MustNewRegexp(s string) Regexp {
+	re, err := NewRegexp(s)
+	if err != nil {
+		panic(err)
+	}
+	return re
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Regexp.
+func (re *Regexp) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	r, err := NewRegexp(s)
+	if err != nil {
+		return err
+	}
+	*re = r
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface for Regexp.
+func (re Regexp) MarshalYAML() (interface{}, error) {
+	if re.Regexp != nil {
+		return re.original, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Regexp.
+func (re *Regexp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	r, err := NewRegexp(s)
+	if err != nil {
+		return err
+	}
+	*re = r
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Regexp.
+func (re Regexp) MarshalJSON() ([]byte, error) {
+	if re.Regexp != nil {
+		return json.Marshal(re.original)
+	}
+	return []byte("null"), nil
+}
+
+// Process returns a relabeled copy of the given label set. The relabel
+// configurations are applied in order of input.
+// If a label set is dropped, nil is returned.
+func Process(lset model.LabelSet, cfgs ...*Config) model.LabelSet {
+	res := lset.Clone()
+	for _, cfg := range cfgs {
+		if keep := relabel(res, cfg); !keep {
+			return nil
+		}
+	}
+	return res
+}
+
+// relabel applies the configuration to the label set in place. It returns
+// false if the label set must be dropped.
+func relabel(lset model.LabelSet, cfg *Config) bool {
+	values := make([]string, 0, len(cfg.SourceLabels))
+	for _, ln := range cfg.SourceLabels {
+		values = append(values, string(lset[ln]))
+	}
+	val := strings.Join(values, cfg.Separator)
+
+	switch cfg.Action {
+	case Drop:
+		if cfg.Regex.MatchString(val) {
+			return false
+		}
+	case Keep:
+		if !cfg.Regex.MatchString(val) {
+			return false
+		}
+	case Replace:
+		indexes := cfg.Regex.FindStringSubmatchIndex(val)
+		// If there is no match no replacement must take place.
+		if indexes == nil {
+			break
+		}
+		target := model.LabelName(cfg.Regex.ExpandString([]byte{}, cfg.TargetLabel, val, indexes))
+		if !target.IsValid() {
+			break
+		}
+		res := cfg.Regex.ExpandString([]byte{}, cfg.Replacement, val, indexes)
+		if len(res) == 0 {
+			delete(lset, target)
+			break
+		}
+		lset[target] = model.LabelValue(res)
+	case HashMod:
+		hash := md5.Sum([]byte(val))
+		// Use only the last 8 bytes of the hash to give the same result as earlier versions of Prometheus.
+		mod := binary.BigEndian.Uint64(hash[8:]) % cfg.Modulus
+		lset[model.LabelName(cfg.TargetLabel)] = model.LabelValue(fmt.Sprintf("%d", mod))
+	case LabelDrop:
+		for ln := range lset {
+			if cfg.Regex.MatchString(string(ln)) {
+				delete(lset, ln)
+			}
+		}
+	case LabelKeep:
+		for ln := range lset {
+			if !cfg.Regex.MatchString(string(ln)) {
+				delete(lset, ln)
+			}
+		}
+	default:
+		panic(fmt.Errorf("relabel: unknown relabel action type %q", cfg.Action))
+	}
+	return true
+}
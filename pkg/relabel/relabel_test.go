@@ -0,0 +1,170 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relabel
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+)
+
+func TestProcess(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		input  model.LabelSet
+		config string
+		output model.LabelSet
+	}{
+		{
+			name:  "replace",
+			input: model.LabelSet{"environment": "prod", "alertname": "A"},
+			config: `
+- source_labels: [environment]
+  target_label: env
+- action: labeldrop
+  regex: environment
+`,
+			output: model.LabelSet{"env": "prod", "alertname": "A"},
+		},
+		{
+			name:  "replace with no match",
+			input: model.LabelSet{"alertname": "A"},
+			config: `
+- source_labels: [environment]
+  regex: (.+)
+  target_label: env
+`,
+			output: model.LabelSet{"alertname": "A"},
+		},
+		{
+			name:  "replace with interpolated target",
+			input: model.LabelSet{"kind": "team", "value": "db"},
+			config: `
+- source_labels: [kind, value]
+  regex: (\w+);(\w+)
+  target_label: ${1}_name
+  replacement: $2
+`,
+			output: model.LabelSet{"kind": "team", "value": "db", "team_name": "db"},
+		},
+		{
+			name:  "drop",
+			input: model.LabelSet{"alertname": "Watchdog"},
+			config: `
+- source_labels: [alertname]
+  regex: Watchdog
+  action: drop
+`,
+			output: nil,
+		},
+		{
+			name:  "keep",
+			input: model.LabelSet{"alertname": "A", "severity": "info"},
+			config: `
+- source_labels: [severity]
+  regex: critical|warning
+  action: keep
+`,
+			output: nil,
+		},
+		{
+			name:  "labelkeep",
+			input: model.LabelSet{"alertname": "A", "replica": "1", "prometheus": "p1"},
+			config: `
+- action: labelkeep
+  regex: alertname|prometheus
+`,
+			output: model.LabelSet{"alertname": "A", "prometheus": "p1"},
+		},
+		{
+			name:  "hashmod",
+			input: model.LabelSet{"alertname": "A"},
+			config: `
+- source_labels: [alertname]
+  target_label: shard
+  modulus: 1
+  action: hashmod
+`,
+			output: model.LabelSet{"alertname": "A", "shard": "0"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfgs []*Config
+			if err := yaml.Unmarshal([]byte(tc.config), &cfgs); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			res := Process(tc.input, cfgs...)
+			if !reflect.DeepEqual(res, tc.output) {
+				t.Fatalf("expected %v, got %v", tc.output, res)
+			}
+		})
+	}
+}
+
+func TestProcessDoesNotModifyInput(t *testing.T) {
+	input := model.LabelSet{"alertname": "A", "replica": "1"}
+	cfg := DefaultRelabelConfig
+	cfg.Action = LabelDrop
+	cfg.Regex = MustNewRegexp("replica")
+
+	Process(input, &cfg)
+	if _, ok := input["replica"]; !ok {
+		t.Fatal("input label set was modified")
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		config string
+		err    string
+	}{
+		{
+			config: `action: unknown`,
+			err:    `unknown relabel action "unknown"`,
+		},
+		{
+			config: `action: replace`,
+			err:    "relabel configuration for replace action requires 'target_label' value",
+		},
+		{
+			config: `
+action: hashmod
+target_label: shard
+`,
+			err: "relabel configuration for hashmod requires non-zero modulus",
+		},
+		{
+			config: `
+action: labeldrop
+source_labels: [foo]
+`,
+			err: "labeldrop action requires only 'regex', and no other fields",
+		},
+		{
+			config: `regex: "("`,
+			err:    "error parsing regexp: missing closing ): `^(?:()$`",
+		},
+	} {
+		var cfg Config
+		err := yaml.Unmarshal([]byte(tc.config), &cfg)
+		if err == nil {
+			t.Fatalf("expected error %q, got none", tc.err)
+		}
+		if err.Error() != tc.err {
+			t.Errorf("expected error %q, got %q", tc.err, err.Error())
+		}
+	}
+}
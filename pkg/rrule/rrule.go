@@ -0,0 +1,470 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rrule evaluates recurrence rules as defined by RFC 5545.
+//
+// The supported subset covers the DAILY, WEEKLY, MONTHLY and YEARLY
+// frequencies with the INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY, BYDAY,
+// BYHOUR, BYMINUTE and WKST parts. In YEARLY rules, BYDAY requires BYMONTH
+// and its ordinals refer to the weeks of the month.
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the frequency at which a rule recurs.
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencies = map[string]Frequency{
+	"DAILY":   Daily,
+	"WEEKLY":  Weekly,
+	"MONTHLY": Monthly,
+	"YEARLY":  Yearly,
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// maxCountDays limits the number of days searched to resolve the COUNT of a
+// rule.
+const maxCountDays = 100 * 366
+
+// Weekday is a BYDAY entry. If N is not 0, it selects the N-th occurrence of
+// the weekday within the month, counting from the end if N is negative.
+type Weekday struct {
+	N   int
+	Day time.Weekday
+}
+
+// Rule is a parsed recurrence rule anchored at a start time.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []Weekday
+	ByHour     []int
+	ByMinute   []int
+	WeekStart  time.Weekday
+
+	dtstart time.Time
+}
+
+// Parse parses the recurrence rule, optionally prefixed with "RRULE:". The
+// first occurrence is at dtstart, whose location and time of day are used
+// for the occurrences unless overridden by the rule.
+func Parse(s string, dtstart time.Time) (*Rule, error) {
+	r := &Rule{
+		Interval:  1,
+		WeekStart: time.Monday,
+		dtstart:   dtstart,
+	}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("empty rule")
+	}
+
+	var hasFreq bool
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq, hasFreq = frequencies[strings.ToUpper(value)]
+			if !hasFreq {
+				return nil, fmt.Errorf("unsupported frequency %q", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "UNTIL":
+			r.Until, err = parseUntil(value, dtstart.Location())
+		case "BYMONTH":
+			err = parseList(value, func(n int) error {
+				if n < 1 || n > 12 {
+					return fmt.Errorf("month %d out of range", n)
+				}
+				r.ByMonth = append(r.ByMonth, time.Month(n))
+				return nil
+			})
+		case "BYMONTHDAY":
+			err = parseList(value, func(n int) error {
+				if n == 0 || n < -31 || n > 31 {
+					return fmt.Errorf("day of month %d out of range", n)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+				return nil
+			})
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYHOUR":
+			err = parseList(value, func(n int) error {
+				if n < 0 || n > 23 {
+					return fmt.Errorf("hour %d out of range", n)
+				}
+				r.ByHour = append(r.ByHour, n)
+				return nil
+			})
+		case "BYMINUTE":
+			err = parseList(value, func(n int) error {
+				if n < 0 || n > 59 {
+					return fmt.Errorf("minute %d out of range", n)
+				}
+				r.ByMinute = append(r.ByMinute, n)
+				return nil
+			})
+		case "WKST":
+			var ok bool
+			if r.WeekStart, ok = weekdays[strings.ToUpper(value)]; !ok {
+				err = fmt.Errorf("unknown weekday")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", strings.ToUpper(name), value, err)
+		}
+	}
+
+	if !hasFreq {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("COUNT and UNTIL must not both be set")
+	}
+	if err := r.validateByDay(); err != nil {
+		return nil, err
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return nil, fmt.Errorf("BYMONTHDAY is not allowed in WEEKLY rules")
+	}
+
+	sort.Ints(r.ByHour)
+	sort.Ints(r.ByMinute)
+
+	if r.Count > 0 {
+		r.resolveCount()
+	}
+	return r, nil
+}
+
+func (r *Rule) validateByDay() error {
+	for _, wd := range r.ByDay {
+		if wd.N == 0 {
+			continue
+		}
+		if r.Freq != Monthly && r.Freq != Yearly {
+			return fmt.Errorf("BYDAY ordinals are only allowed in MONTHLY and YEARLY rules")
+		}
+	}
+	if r.Freq == Yearly && len(r.ByDay) > 0 && len(r.ByMonth) == 0 {
+		return fmt.Errorf("BYDAY in YEARLY rules requires BYMONTH")
+	}
+	return nil
+}
+
+// resolveCount replaces the COUNT of the rule with the time of its last
+// occurrence.
+func (r *Rule) resolveCount() {
+	n := 0
+	day := date(r.dtstart)
+	for i := 0; i < maxCountDays && n < r.Count; i++ {
+		if r.matchesDay(day) {
+			for _, t := range r.times(day) {
+				if t.Before(r.dtstart) {
+					continue
+				}
+				r.Until = t
+				if n++; n == r.Count {
+					break
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+}
+
+// Between returns the occurrences of the rule between from and to,
+// both inclusive.
+func (r *Rule) Between(from, to time.Time) []time.Time {
+	var res []time.Time
+	r.each(from, to, func(t time.Time) bool {
+		res = append(res, t)
+		return true
+	})
+	return res
+}
+
+// Contains returns true if t is within an occurrence of the rule lasting d.
+func (r *Rule) Contains(t time.Time, d time.Duration) bool {
+	found := false
+	r.each(t.Add(-d), t, func(o time.Time) bool {
+		// Occurrences are half-open intervals [o, o+d).
+		if o.Add(d).After(t) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// each calls fn for the occurrences between from and to in ascending order
+// until fn returns false.
+func (r *Rule) each(from, to time.Time, fn func(time.Time) bool) {
+	if from.Before(r.dtstart) {
+		from = r.dtstart
+	}
+	if !r.Until.IsZero() && to.After(r.Until) {
+		to = r.Until
+	}
+	if to.Before(from) {
+		return
+	}
+	loc := r.dtstart.Location()
+	for day, last := date(from.In(loc)), date(to.In(loc)); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !r.matchesDay(day) {
+			continue
+		}
+		for _, t := range r.times(day) {
+			if t.Before(from) || t.After(to) {
+				continue
+			}
+			if !fn(t) {
+				return
+			}
+		}
+	}
+}
+
+// matchesDay returns true if the rule has occurrences on the day.
+func (r *Rule) matchesDay(day time.Time) bool {
+	start := date(r.dtstart)
+	if day.Before(start) {
+		return false
+	}
+	if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, day.Month()) {
+		return false
+	}
+
+	switch r.Freq {
+	case Daily:
+		if daysBetween(start, day)%r.Interval != 0 {
+			return false
+		}
+		return r.matchesMonthDay(day, true) && r.matchesWeekday(day, true)
+	case Weekly:
+		if daysBetween(r.weekStart(start), r.weekStart(day))/7%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == r.dtstart.Weekday()
+		}
+		return r.matchesWeekday(day, false)
+	case Monthly:
+		months := (day.Year()-start.Year())*12 + int(day.Month()-start.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		return r.matchesDayInMonth(day)
+	case Yearly:
+		if (day.Year()-start.Year())%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByMonth) == 0 && day.Month() != start.Month() {
+			return false
+		}
+		return r.matchesDayInMonth(day)
+	}
+	return false
+}
+
+// matchesDayInMonth applies BYMONTHDAY and BYDAY, or the day of the month of
+// the start if neither is set.
+func (r *Rule) matchesDayInMonth(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		return day.Day() == r.dtstart.Day()
+	}
+	return r.matchesMonthDay(day, true) && r.matchesWeekday(day, true)
+}
+
+func (r *Rule) matchesMonthDay(day time.Time, emptyMatches bool) bool {
+	if len(r.ByMonthDay) == 0 {
+		return emptyMatches
+	}
+	days := daysInMonth(day)
+	for _, md := range r.ByMonthDay {
+		if md < 0 {
+			md = days + md + 1
+		}
+		if md == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesWeekday(day time.Time, emptyMatches bool) bool {
+	if len(r.ByDay) == 0 {
+		return emptyMatches
+	}
+	for _, wd := range r.ByDay {
+		if wd.Day != day.Weekday() {
+			continue
+		}
+		switch {
+		case wd.N == 0:
+			return true
+		case wd.N > 0 && (day.Day()-1)/7+1 == wd.N:
+			return true
+		case wd.N < 0 && (daysInMonth(day)-day.Day())/7+1 == -wd.N:
+			return true
+		}
+	}
+	return false
+}
+
+// times returns the occurrences on a matching day.
+func (r *Rule) times(day time.Time) []time.Time {
+	hours, minutes := r.ByHour, r.ByMinute
+	if len(hours) == 0 {
+		hours = []int{r.dtstart.Hour()}
+	}
+	if len(minutes) == 0 {
+		minutes = []int{r.dtstart.Minute()}
+	}
+	res := make([]time.Time, 0, len(hours)*len(minutes))
+	for _, h := range hours {
+		for _, m := range minutes {
+			t := time.Date(day.Year(), day.Month(), day.Day(), h, m, r.dtstart.Second(), 0, r.dtstart.Location())
+			if t.Before(r.dtstart) {
+				continue
+			}
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func (r *Rule) weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) - int(r.WeekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func parseUntil(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", s, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("20060102", s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a date or date-time")
+	}
+	// A date includes the whole day.
+	return t.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+func parseList(s string, fn func(int) error) error {
+	for _, v := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		if err := fn(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseByDay(s string) ([]Weekday, error) {
+	var res []Weekday
+	for _, v := range strings.Split(s, ",") {
+		if len(v) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", v)
+		}
+		day, ok := weekdays[strings.ToUpper(v[len(v)-2:])]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", v)
+		}
+		wd := Weekday{Day: day}
+		if n := v[:len(v)-2]; n != "" {
+			var err error
+			if wd.N, err = strconv.Atoi(n); err != nil {
+				return nil, fmt.Errorf("invalid weekday ordinal %q", v)
+			}
+			if wd.N == 0 || wd.N < -5 || wd.N > 5 {
+				return nil, fmt.Errorf("weekday ordinal %q out of range", v)
+			}
+		}
+		res = append(res, wd)
+	}
+	return res, nil
+}
+
+func containsMonth(months []time.Month, m time.Month) bool {
+	for _, month := range months {
+		if month == m {
+			return true
+		}
+	}
+	return false
+}
+
+// date returns the start of the day of t in the location of t.
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween returns the number of calendar days from a to b.
+func daysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
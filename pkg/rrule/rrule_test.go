@@ -0,0 +1,179 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, s)
+	require.NoError(t, err)
+	return ts
+}
+
+func TestParseErrors(t *testing.T) {
+	dtstart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		rule string
+		err  string
+	}{
+		{rule: "", err: "empty rule"},
+		{rule: "INTERVAL=2", err: "FREQ is required"},
+		{rule: "FREQ=SECONDLY", err: "unsupported frequency"},
+		{rule: "FREQ=DAILY;BYSETPOS=1", err: "unsupported rule part"},
+		{rule: "FREQ=DAILY;INTERVAL=0", err: "invalid INTERVAL"},
+		{rule: "FREQ=DAILY;COUNT=2;UNTIL=20240301", err: "COUNT and UNTIL"},
+		{rule: "FREQ=DAILY;BYHOUR=24", err: "hour 24 out of range"},
+		{rule: "FREQ=WEEKLY;BYDAY=1MO", err: "ordinals are only allowed"},
+		{rule: "FREQ=WEEKLY;BYMONTHDAY=1", err: "BYMONTHDAY is not allowed"},
+		{rule: "FREQ=YEARLY;BYDAY=MO", err: "requires BYMONTH"},
+		{rule: "FREQ=MONTHLY;BYDAY=6MO", err: "out of range"},
+	} {
+		t.Run(tc.rule, func(t *testing.T) {
+			_, err := Parse(tc.rule, dtstart)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rule     string
+		dtstart  string
+		from, to string
+		exp      []string
+	}{
+		{
+			name:    "daily with interval",
+			rule:    "FREQ=DAILY;INTERVAL=2",
+			dtstart: "2024-01-01T10:00:00Z",
+			from:    "2023-12-01T00:00:00Z",
+			to:      "2024-01-06T00:00:00Z",
+			exp:     []string{"2024-01-01T10:00:00Z", "2024-01-03T10:00:00Z", "2024-01-05T10:00:00Z"},
+		},
+		{
+			name:    "weekly on weekends at two times",
+			rule:    "RRULE:FREQ=WEEKLY;BYDAY=SA,SU;BYHOUR=2,14;BYMINUTE=30",
+			dtstart: "2024-01-01T00:00:00Z",
+			from:    "2024-01-06T00:00:00Z",
+			to:      "2024-01-07T23:59:59Z",
+			exp: []string{
+				"2024-01-06T02:30:00Z", "2024-01-06T14:30:00Z",
+				"2024-01-07T02:30:00Z", "2024-01-07T14:30:00Z",
+			},
+		},
+		{
+			name:    "biweekly defaults to the start weekday",
+			rule:    "FREQ=WEEKLY;INTERVAL=2",
+			dtstart: "2024-01-03T08:00:00Z",
+			from:    "2024-01-01T00:00:00Z",
+			to:      "2024-02-01T00:00:00Z",
+			exp:     []string{"2024-01-03T08:00:00Z", "2024-01-17T08:00:00Z", "2024-01-31T08:00:00Z"},
+		},
+		{
+			name:    "last friday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: "2024-01-01T18:00:00Z",
+			from:    "2024-01-01T00:00:00Z",
+			to:      "2024-03-31T00:00:00Z",
+			exp:     []string{"2024-01-26T18:00:00Z", "2024-02-23T18:00:00Z", "2024-03-29T18:00:00Z"},
+		},
+		{
+			name:    "last day of the month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: "2024-01-01T00:00:00Z",
+			from:    "2024-01-01T00:00:00Z",
+			to:      "2024-03-31T00:00:00Z",
+			exp:     []string{"2024-01-31T00:00:00Z", "2024-02-29T00:00:00Z", "2024-03-31T00:00:00Z"},
+		},
+		{
+			name:    "yearly on the fourth thursday of november",
+			rule:    "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			dtstart: "2023-01-01T00:00:00Z",
+			from:    "2023-01-01T00:00:00Z",
+			to:      "2025-01-01T00:00:00Z",
+			exp:     []string{"2023-11-23T00:00:00Z", "2024-11-28T00:00:00Z"},
+		},
+		{
+			name:    "count",
+			rule:    "FREQ=DAILY;COUNT=2",
+			dtstart: "2024-01-01T10:00:00Z",
+			from:    "2024-01-01T00:00:00Z",
+			to:      "2024-01-10T00:00:00Z",
+			exp:     []string{"2024-01-01T10:00:00Z", "2024-01-02T10:00:00Z"},
+		},
+		{
+			name:    "until date is inclusive",
+			rule:    "FREQ=DAILY;UNTIL=20240102",
+			dtstart: "2024-01-01T10:00:00Z",
+			from:    "2024-01-01T00:00:00Z",
+			to:      "2024-01-10T00:00:00Z",
+			exp:     []string{"2024-01-01T10:00:00Z", "2024-01-02T10:00:00Z"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Parse(tc.rule, mustTime(t, tc.dtstart))
+			require.NoError(t, err)
+
+			var exp []time.Time
+			for _, s := range tc.exp {
+				exp = append(exp, mustTime(t, s))
+			}
+			require.Equal(t, exp, r.Between(mustTime(t, tc.from), mustTime(t, tc.to)))
+		})
+	}
+}
+
+func TestBetweenLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// The occurrences keep their time of day across daylight saving time.
+	r, err := Parse("FREQ=DAILY", time.Date(2024, 3, 30, 2, 30, 0, 0, loc))
+	require.NoError(t, err)
+	res := r.Between(time.Date(2024, 3, 31, 12, 0, 0, 0, loc), time.Date(2024, 4, 1, 12, 0, 0, 0, loc))
+	require.Equal(t, []time.Time{time.Date(2024, 4, 1, 2, 30, 0, 0, loc)}, res)
+}
+
+func TestContains(t *testing.T) {
+	// Every Sunday from 02:00 to 04:00.
+	r, err := Parse("FREQ=WEEKLY;BYDAY=SU;BYHOUR=2;BYMINUTE=0", mustTime(t, "2024-01-01T00:00:00Z"))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		t   string
+		exp bool
+	}{
+		{t: "2024-01-07T01:59:59Z", exp: false},
+		{t: "2024-01-07T02:00:00Z", exp: true},
+		{t: "2024-01-07T03:59:59Z", exp: true},
+		{t: "2024-01-07T04:00:00Z", exp: false},
+		{t: "2024-01-08T03:00:00Z", exp: false},
+		{t: "2024-01-14T03:00:00Z", exp: true},
+	} {
+		require.Equal(t, tc.exp, r.Contains(mustTime(t, tc.t), 2*time.Hour), tc.t)
+	}
+
+	// Occurrences may last across midnight.
+	r, err = Parse("FREQ=WEEKLY;BYDAY=SA;BYHOUR=22;BYMINUTE=0", mustTime(t, "2024-01-01T00:00:00Z"))
+	require.NoError(t, err)
+	require.True(t, r.Contains(mustTime(t, "2024-01-07T01:00:00Z"), 4*time.Hour))
+	require.False(t, r.Contains(mustTime(t, "2024-01-07T02:00:00Z"), 4*time.Hour))
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mem
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// flapDetector tracks the transitions of alerts between firing and resolved.
+// An alert is flapping if it transitioned at least threshold times within
+// the window.
+type flapDetector struct {
+	mtx       sync.Mutex
+	window    time.Duration
+	threshold int
+	states    map[model.Fingerprint]*flapState
+}
+
+type flapState struct {
+	resolved     bool
+	transitions  []time.Time
+	lastFlapping time.Time
+}
+
+func newFlapDetector() *flapDetector {
+	return &flapDetector{
+		states: map[model.Fingerprint]*flapState{},
+	}
+}
+
+func (d *flapDetector) configure(window time.Duration, threshold int) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.window = window
+	d.threshold = threshold
+}
+
+func (d *flapDetector) enabled() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.threshold > 0
+}
+
+// observe records the current resolved state of an alert and returns
+// whether the alert is flapping.
+func (d *flapDetector) observe(fp model.Fingerprint, resolved bool, now time.Time) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	s, ok := d.states[fp]
+	if !ok {
+		d.states[fp] = &flapState{resolved: resolved}
+		return false
+	}
+	if s.resolved != resolved {
+		s.transitions = append(s.transitions, now)
+		s.resolved = resolved
+	}
+	return s.evaluate(now, d.window, d.threshold)
+}
+
+// status returns whether the alert is flapping and whether it has been
+// flapping within the last window.
+func (d *flapDetector) status(fp model.Fingerprint, now time.Time) (flapping, recently bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	s, ok := d.states[fp]
+	if !ok {
+		return false, false
+	}
+	flapping = s.evaluate(now, d.window, d.threshold)
+	recently = !s.lastFlapping.IsZero() && now.Sub(s.lastFlapping) < d.window
+	return flapping, recently
+}
+
+// gc removes the state of alerts which had no transitions and were not
+// flapping within the last window.
+func (d *flapDetector) gc(now time.Time) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	for fp, s := range d.states {
+		s.evaluate(now, d.window, d.threshold)
+		if len(s.transitions) == 0 && now.Sub(s.lastFlapping) >= d.window {
+			delete(d.states, fp)
+		}
+	}
+}
+
+// evaluate drops transitions which are older than the window and returns
+// whether the alert is flapping.
+func (s *flapState) evaluate(now time.Time, window time.Duration, threshold int) bool {
+	i := 0
+	for i < len(s.transitions) && now.Sub(s.transitions[i]) >= window {
+		i++
+	}
+	s.transitions = s.transitions[i:]
+
+	if threshold <= 0 || len(s.transitions) < threshold {
+		return false
+	}
+	s.lastFlapping = now
+	return true
+}
@@ -43,6 +43,8 @@ type Alerts struct {
 
 	callback AlertStoreCallback
 
+	flaps *flapDetector
+
 	logger log.Logger
 }
 
@@ -82,6 +84,7 @@ func (a *Alerts) registerMetrics(r prometheus.Registerer) {
 	r.MustRegister(newMemAlertByStatus(types.AlertStateActive))
 	r.MustRegister(newMemAlertByStatus(types.AlertStateSuppressed))
 	r.MustRegister(newMemAlertByStatus(types.AlertStateUnprocessed))
+	r.MustRegister(newMemAlertByStatus(types.AlertStateFlapping))
 }
 
 // NewAlerts returns a new alert provider.
@@ -99,6 +102,7 @@ func NewAlerts(ctx context.Context, m types.Marker, intervalGC time.Duration, al
 		next:      0,
 		logger:    log.With(l, "component", "provider"),
 		callback:  alertCallback,
+		flaps:     newFlapDetector(),
 	}
 	a.alerts.SetGCCallback(func(alerts []*types.Alert) {
 		for _, alert := range alerts {
@@ -108,6 +112,7 @@ func NewAlerts(ctx context.Context, m types.Marker, intervalGC time.Duration, al
 			m.Delete(alert.Fingerprint())
 			a.callback.PostDelete(alert)
 		}
+		a.flaps.gc(time.Now())
 
 		a.mtx.Lock()
 		for i, l := range a.listeners {
@@ -138,6 +143,25 @@ func (a *Alerts) Close() {
 	}
 }
 
+// SetFlapDetection configures the detection of flapping alerts. An alert is
+// flapping if it transitioned between firing and resolved at least threshold
+// times within window. A threshold of 0 disables the detection.
+func (a *Alerts) SetFlapDetection(window time.Duration, threshold int) {
+	a.flaps.configure(window, threshold)
+}
+
+// Flapping returns whether the alert with the given fingerprint is flapping
+// and whether it has been flapping within the last flap detection window.
+// The flapping state of the alert is updated in the marker.
+func (a *Alerts) Flapping(fp model.Fingerprint) (flapping, recently bool) {
+	if !a.flaps.enabled() {
+		return false, false
+	}
+	flapping, recently = a.flaps.status(fp, time.Now())
+	a.marker.SetFlapping(fp, flapping)
+	return flapping, recently
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -227,6 +251,10 @@ func (a *Alerts) Put(alerts ...*types.Alert) error {
 
 		a.callback.PostStore(alert, existing)
 
+		if a.flaps.enabled() {
+			a.marker.SetFlapping(fp, a.flaps.observe(fp, alert.Resolved(), time.Now()))
+		}
+
 		a.mtx.Lock()
 		for _, l := range a.listeners {
 			select {
@@ -504,3 +504,76 @@ func (l *limitCountCallback) PostStore(_ *types.Alert, existing bool) {
 func (l *limitCountCallback) PostDelete(_ *types.Alert) {
 	l.alerts.Dec()
 }
+
+func TestAlertsFlapping(t *testing.T) {
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := NewAlerts(context.Background(), marker, 30*time.Minute, nil, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	alerts.SetFlapDetection(time.Hour, 3)
+
+	now := time.Now()
+	newAlert := func(i int, resolved bool) *types.Alert {
+		a := &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "Flapping"},
+				StartsAt: now.Add(-time.Hour),
+				EndsAt:   now.Add(time.Hour),
+			},
+			UpdatedAt: now.Add(time.Duration(i) * time.Second),
+		}
+		if resolved {
+			a.EndsAt = now.Add(-time.Minute)
+		}
+		return a
+	}
+
+	fp := newAlert(0, false).Fingerprint()
+	for i, resolved := range []bool{false, true, false} {
+		require.NoError(t, alerts.Put(newAlert(i, resolved)))
+		require.False(t, marker.Flapping(fp))
+	}
+
+	// The third transition marks the alert as flapping.
+	require.NoError(t, alerts.Put(newAlert(3, true)))
+	require.True(t, marker.Flapping(fp))
+	require.Equal(t, types.AlertStateFlapping, marker.Status(fp).State)
+	require.Equal(t, 1, marker.Count(types.AlertStateFlapping))
+
+	flapping, recently := alerts.Flapping(fp)
+	require.True(t, flapping)
+	require.True(t, recently)
+
+	// Silences take precedence over flapping.
+	marker.SetActiveOrSilenced(fp, 1, []string{"1"}, nil)
+	require.Equal(t, types.AlertStateSuppressed, marker.Status(fp).State)
+	marker.SetActiveOrSilenced(fp, 1, nil, nil)
+	require.Equal(t, types.AlertStateFlapping, marker.Status(fp).State)
+}
+
+func TestFlapDetector(t *testing.T) {
+	d := newFlapDetector()
+	d.configure(time.Hour, 2)
+
+	var (
+		fp  = model.Fingerprint(1)
+		now = time.Now()
+	)
+	require.False(t, d.observe(fp, false, now))
+	require.False(t, d.observe(fp, true, now.Add(time.Minute)))
+	require.True(t, d.observe(fp, false, now.Add(2*time.Minute)))
+	// Observing the same state is no transition.
+	require.True(t, d.observe(fp, false, now.Add(3*time.Minute)))
+
+	// Once the first transition left the window, the alert has stabilised
+	// but is still reported as recently flapping.
+	flapping, recently := d.status(fp, now.Add(61*time.Minute))
+	require.False(t, flapping)
+	require.True(t, recently)
+
+	flapping, recently = d.status(fp, now.Add(3*time.Hour))
+	require.False(t, flapping)
+	require.False(t, recently)
+
+	d.gc(now.Add(3 * time.Hour))
+	require.Empty(t, d.states)
+}
@@ -0,0 +1,231 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// QCreatedBy filters queried silences by their author.
+func QCreatedBy(author string) QueryParam {
+	return func(q *query) error {
+		f := func(sil *pb.Silence, _ *Silences, _ time.Time) (bool, error) {
+			return sil.CreatedBy == author, nil
+		}
+		q.filters = append(q.filters, f)
+		return nil
+	}
+}
+
+// QComment filters queried silences by a regular expression matching a part
+// of their comment.
+func QComment(pattern string) QueryParam {
+	return func(q *query) error {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return errors.Wrap(err, "invalid comment pattern")
+		}
+		f := func(sil *pb.Silence, _ *Silences, _ time.Time) (bool, error) {
+			return re.MatchString(sil.Comment), nil
+		}
+		q.filters = append(q.filters, f)
+		return nil
+	}
+}
+
+// QMatchers filters queried silences by their matchers. A silence matches if
+// it has an equal matcher for each of the given ones.
+func QMatchers(ms ...*labels.Matcher) QueryParam {
+	return func(q *query) error {
+		f := func(sil *pb.Silence, _ *Silences, _ time.Time) (bool, error) {
+			for _, m := range ms {
+				if !hasMatcher(sil, m) {
+					return false, nil
+				}
+			}
+			return true, nil
+		}
+		q.filters = append(q.filters, f)
+		return nil
+	}
+}
+
+func hasMatcher(sil *pb.Silence, m *labels.Matcher) bool {
+	var mt pb.Matcher_Type
+	switch m.Type {
+	case labels.MatchEqual:
+		mt = pb.Matcher_EQUAL
+	case labels.MatchNotEqual:
+		mt = pb.Matcher_NOT_EQUAL
+	case labels.MatchRegexp:
+		mt = pb.Matcher_REGEXP
+	case labels.MatchNotRegexp:
+		mt = pb.Matcher_NOT_REGEXP
+	}
+	for _, sm := range sil.Matchers {
+		if sm.Name == m.Name && sm.Pattern == m.Value && sm.Type == mt {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpireAll expires all silences matching the query parameters which have
+// not expired yet. The silences are expired in a single transaction. It
+// returns the IDs of the expired silences.
+func (s *Silences) ExpireAll(ctx context.Context, params ...QueryParam) ([]string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	return s.updateAll(ctx, now, params, func(prev *pb.Silence) (*pb.Silence, bool, error) {
+		sil, ok := expiredSilence(prev, now)
+		return sil, ok, nil
+	})
+}
+
+// ExtendAll extends the end of all silences matching the query parameters
+// which have not expired yet by the given duration. The silences are updated
+// in a single transaction. Like any other change, extending a silence which
+// violates the policy requires an approval. It returns the IDs of the extended silences.
+func (s *Silences) ExtendAll(ctx context.Context, d time.Duration, params ...QueryParam) ([]string, error) {
+	if d <= 0 {
+		return nil, errors.New("extension must be positive")
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	return s.updateAll(ctx, now, params, func(prev *pb.Silence) (*pb.Silence, bool, error) {
+		if getState(prev, now) == types.SilenceStateExpired {
+			return nil, false, nil
+		}
+		sil := cloneSilence(prev)
+		sil.EndsAt = sil.EndsAt.Add(d)
+
+		reasons, err := s.violations(ctx, sil, now)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "check silence policy")
+		}
+		sil.Approval = nil
+		if len(reasons) > 0 {
+			sil.Approval = &pb.Approval{Reasons: reasons}
+		}
+		return sil, true, nil
+	})
+}
+
+// updateAll replaces the stored silences matching the query parameters by
+// the result of update, skipping those for which it returns false. The
+// silences are read and written while watching the silence index, so that a
+// concurrent change of another instance restarts the update instead of
+// being overwritten. It returns the IDs of the updated silences.
+// The caller must hold the lock.
+func (s *Silences) updateAll(ctx context.Context, now time.Time, params []QueryParam, update func(*pb.Silence) (*pb.Silence, bool, error)) ([]string, error) {
+	var (
+		ws       []silenceWrite
+		versions []int64
+	)
+	err := s.watch(ctx, func(tx *redis.Tx) error {
+		sils, err := s.queryStored(ctx, tx, now, params...)
+		if err != nil {
+			return err
+		}
+		ws = make([]silenceWrite, 0, len(sils))
+		for _, prev := range sils {
+			sil, ok, err := update(prev)
+			if err != nil {
+				return err
+			}
+			if ok {
+				ws = append(ws, silenceWrite{prev: prev, sil: sil})
+			}
+		}
+		if err := prepareWrites(ws, now); err != nil {
+			return err
+		}
+		versions, err = s.writeSilences(ctx, tx, ws)
+		return err
+	}, s.orgSilenceIdx())
+	if err != nil {
+		return nil, err
+	}
+	s.applyWrites(ctx, ws, versions)
+	return writtenIDs(ws), nil
+}
+
+// queryStored returns the silences stored in Redis which match the query
+// parameters. It bypasses the cache so that changes apply to the latest
+// version of the silences.
+// The caller must hold the lock.
+func (s *Silences) queryStored(ctx context.Context, rdb redis.Cmdable, now time.Time, params ...QueryParam) ([]*pb.Silence, error) {
+	q := &query{}
+	for _, p := range params {
+		if err := p(q); err != nil {
+			return nil, err
+		}
+	}
+	all, err := s.loadAll(ctx, rdb)
+	if err != nil {
+		return nil, err
+	}
+	var ids map[string]struct{}
+	if q.ids != nil {
+		ids = make(map[string]struct{}, len(q.ids))
+		for _, id := range q.ids {
+			ids[id] = struct{}{}
+		}
+	}
+
+	var res []*pb.Silence
+	for _, sil := range all {
+		if _, ok := ids[sil.Id]; ids != nil && !ok {
+			continue
+		}
+		keep := true
+		for _, f := range q.filters {
+			ok, err := f(sil, s, now)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			res = append(res, sil)
+		}
+	}
+	return res, nil
+}
+
+func writtenIDs(ws []silenceWrite) []string {
+	ids := make([]string, 0, len(ws))
+	for _, w := range ws {
+		ids = append(ids, w.sil.Id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/test/redistest"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestBulkQueryParams(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s, err := New(Options{})
+	require.NoError(t, err)
+	s.loaded = true
+	s.st = state{
+		"1": {
+			Id:        "1",
+			CreatedBy: "alice",
+			Comment:   "Maintenance for INC123",
+			Matchers:  []*pb.Matcher{{Name: "incident", Pattern: "INC123", Type: pb.Matcher_EQUAL}},
+			StartsAt:  now.Add(-time.Hour),
+			EndsAt:    now.Add(time.Hour),
+		},
+		"2": {
+			Id:        "2",
+			CreatedBy: "bob",
+			Comment:   "INC123 follow-up",
+			Matchers: []*pb.Matcher{
+				{Name: "incident", Pattern: "INC123", Type: pb.Matcher_EQUAL},
+				{Name: "job", Pattern: "db.*", Type: pb.Matcher_REGEXP},
+			},
+			StartsAt: now.Add(time.Hour),
+			EndsAt:   now.Add(2 * time.Hour),
+		},
+		"3": {
+			Id:        "3",
+			CreatedBy: "alice",
+			Comment:   "Deploy",
+			Matchers:  []*pb.Matcher{{Name: "incident", Pattern: "INC123", Type: pb.Matcher_NOT_EQUAL}},
+			StartsAt:  now.Add(-time.Hour),
+			EndsAt:    now.Add(time.Hour),
+			Approval:  &pb.Approval{Reasons: []string{"too broad"}},
+		},
+	}
+
+	incident, err := labels.NewMatcher(labels.MatchEqual, "incident", "INC123")
+	require.NoError(t, err)
+	job, err := labels.NewMatcher(labels.MatchRegexp, "job", "db.*")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		params []QueryParam
+		exp    []string
+	}{
+		{name: "matcher", params: []QueryParam{QMatchers(incident)}, exp: []string{"1", "2"}},
+		{name: "all matchers", params: []QueryParam{QMatchers(incident, job)}, exp: []string{"2"}},
+		{name: "author", params: []QueryParam{QCreatedBy("alice")}, exp: []string{"1", "3"}},
+		{name: "comment", params: []QueryParam{QComment("INC1\\d+")}, exp: []string{"1", "2"}},
+		{
+			name:   "author and state",
+			params: []QueryParam{QCreatedBy("alice"), QState(types.SilenceStateActive)},
+			exp:    []string{"1"},
+		},
+		{
+			name:   "pending approval",
+			params: []QueryParam{QState(types.SilenceStatePendingApproval)},
+			exp:    []string{"3"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &query{}
+			for _, p := range tc.params {
+				require.NoError(t, p(q))
+			}
+			sils, _, err := s.query(q, now)
+			require.NoError(t, err)
+			var ids []string
+			for _, sil := range sils {
+				ids = append(ids, sil.Id)
+			}
+			sort.Strings(ids)
+			require.Equal(t, tc.exp, ids)
+		})
+	}
+
+	require.Error(t, QComment("(")(&query{}))
+}
+
+func TestExpiredSilence(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	active := &pb.Silence{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+	sil, ok := expiredSilence(active, now)
+	require.True(t, ok)
+	require.Equal(t, active.StartsAt, sil.StartsAt)
+	require.Equal(t, now, sil.EndsAt)
+	require.Equal(t, now.Add(time.Hour), active.EndsAt, "the previous version must not change")
+
+	pending := &pb.Silence{StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)}
+	sil, ok = expiredSilence(pending, now)
+	require.True(t, ok)
+	require.Equal(t, now, sil.StartsAt)
+	require.Equal(t, now, sil.EndsAt)
+
+	_, ok = expiredSilence(&pb.Silence{StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)}, now)
+	require.False(t, ok)
+}
+
+// onceAfterHGetAll runs fn once after the first HGETALL of the client.
+type onceAfterHGetAll struct {
+	fn   func()
+	done bool
+}
+
+func (h *onceAfterHGetAll) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *onceAfterHGetAll) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if !h.done && strings.EqualFold(cmd.Name(), "hgetall") {
+			h.done = true
+			h.fn()
+		}
+		return err
+	}
+}
+
+func (h *onceAfterHGetAll) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestBulkUpdatesWatchConcurrentChanges(t *testing.T) {
+	srv := redistest.Run(t)
+	ctx := context.Background()
+
+	rdb := srv.Client(t)
+	hook := &onceAfterHGetAll{}
+	rdb.AddHook(hook)
+	s, mc := newTestSilences(t, srv, Options{Rdb: rdb})
+	other, _ := newTestSilences(t, srv, Options{})
+	other.clock = mc
+
+	first, err := s.Set(ctx, newTestSilence(mc.Now(), time.Hour))
+	require.NoError(t, err)
+
+	// A silence created by another instance while the silences are read is
+	// extended as well, as the transaction is retried.
+	var second string
+	hook.done = false
+	hook.fn = func() {
+		second, err = other.Set(ctx, newTestSilence(mc.Now(), time.Hour))
+		require.NoError(t, err)
+	}
+	ids, err := s.ExtendAll(ctx, time.Hour, QCreatedBy("alice"))
+	require.NoError(t, err)
+	sort.Strings(ids)
+	exp := []string{first, second}
+	sort.Strings(exp)
+	require.Equal(t, exp, ids)
+
+	for _, id := range exp {
+		sil, err := s.QueryOne(QIDs(id))
+		require.NoError(t, err)
+		require.Equal(t, mc.Now().Add(2*time.Hour), sil.EndsAt)
+		history, err := s.History(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+	}
+
+	// A change of another instance in between is not overwritten by the
+	// previous version.
+	hook.done = false
+	hook.fn = func() {
+		sil, err := other.QueryOne(QIDs(first))
+		require.NoError(t, err)
+		sil.Comment = "changed"
+		_, err = other.Set(ctx, sil)
+		require.NoError(t, err)
+	}
+	mc.Add(time.Minute)
+	ids, err = s.ExpireAll(ctx, QCreatedBy("alice"))
+	require.NoError(t, err)
+	sort.Strings(ids)
+	require.Equal(t, exp, ids)
+
+	sil, err := s.QueryOne(QIDs(first))
+	require.NoError(t, err)
+	require.Equal(t, "changed", sil.Comment)
+	require.Equal(t, mc.Now(), sil.EndsAt)
+	history, err := s.History(ctx, first)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.Equal(t, "changed", history[2].Comment)
+}
@@ -0,0 +1,242 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+const orgSilenceUpdates = "%d_silence_updates"
+
+// resyncInterval is the interval at which the silence cache is checked
+// against the version in Redis, to recover from missed updates.
+const resyncInterval = 30 * time.Second
+
+// silenceUpdate is published whenever a silence is modified or removed.
+type silenceUpdate struct {
+	// Origin identifies the Silences instance which made the change.
+	Origin string `json:"origin"`
+	// Version is the silence version in Redis after the change.
+	Version int64  `json:"version"`
+	ID      string `json:"id"`
+}
+
+// subscriber is implemented by Redis clients supporting pub/sub.
+type subscriber interface {
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+}
+
+// Run keeps the silence cache coherent with the silences modified by other
+// Alertmanager replicas until stopc is closed. Updates are received through
+// Redis pub/sub; missed updates are detected by comparing the silence version
+// in Redis with the version of the cache.
+func (s *Silences) Run(stopc <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Subscribe before loading the cache so that no update is missed in
+	// between.
+	var msgs <-chan *redis.Message
+	if sub, ok := s.rdb.(subscriber); ok {
+		ps := sub.Subscribe(ctx, s.updatesChannel())
+		defer ps.Close()
+		if _, err := ps.Receive(ctx); err != nil {
+			level.Error(s.logger).Log("msg", "Subscribing to silence updates failed", "err", err)
+		}
+		msgs = ps.Channel()
+	} else {
+		level.Warn(s.logger).Log("msg", "Redis client doesn't support pub/sub, silence updates of other replicas are applied with a delay")
+	}
+
+	s.mtx.Lock()
+	if err := s.load(ctx); err != nil {
+		level.Error(s.logger).Log("msg", "Loading silences failed", "err", err)
+	}
+	s.mtx.Unlock()
+
+	t := s.clock.Ticker(resyncInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case m, ok := <-msgs:
+			if !ok {
+				msgs = nil
+				continue
+			}
+			s.applyUpdate(ctx, m.Payload)
+		case <-t.C:
+			if err := s.resync(ctx); err != nil {
+				level.Error(s.logger).Log("msg", "Resyncing silences failed", "err", err)
+			}
+		}
+	}
+}
+
+// ensureLoaded loads the cache if it wasn't loaded yet.
+// The caller must hold the lock.
+func (s *Silences) ensureLoaded(ctx context.Context) error {
+	if s.loaded {
+		return nil
+	}
+	return s.load(ctx)
+}
+
+// load replaces the cache with the silences stored in Redis.
+// The caller must hold the lock.
+func (s *Silences) load(ctx context.Context) error {
+	version, err := s.redisVersion(ctx)
+	if err != nil {
+		return err
+	}
+	allSilJson, err := s.rdb.HGetAll(ctx, s.orgSilenceIdx()).Result()
+	if err != nil {
+		return errors.Wrap(err, "get all silence for redis")
+	}
+
+	st := make(state, len(allSilJson))
+	idx := newSilenceIndex()
+	for uid, silJson := range allSilJson {
+		sil, err := unmarshalSilence(silJson)
+		if err != nil {
+			level.Error(s.logger).Log("msg", "unmarshal silence failed", "uid", uid, "err", err)
+			continue
+		}
+		st[uid] = sil
+		idx.add(sil)
+	}
+
+	s.st = st
+	s.idx = idx
+	s.mc = matcherCache{}
+	s.rc = recurrenceCache{}
+	s.seen = version
+	s.loaded = true
+	s.version++
+	s.metrics.cacheLoadsTotal.Inc()
+	return nil
+}
+
+// resync reloads the cache if Redis holds changes which were not received
+// as updates.
+func (s *Silences) resync(ctx context.Context) error {
+	version, err := s.redisVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.loaded && version <= s.seen {
+		return nil
+	}
+	level.Debug(s.logger).Log("msg", "Silence cache is out of date, reloading", "version", version, "seen", s.seen)
+	return s.load(ctx)
+}
+
+// applyUpdate applies an update published by another instance to the cache.
+func (s *Silences) applyUpdate(ctx context.Context, payload string) {
+	var u silenceUpdate
+	if err := json.Unmarshal([]byte(payload), &u); err != nil {
+		level.Error(s.logger).Log("msg", "unmarshal silence update failed", "err", err)
+		return
+	}
+	if u.Origin == s.origin {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.loaded {
+		// The update is part of the cache once it is loaded.
+		return
+	}
+	if sil, ok := s.getSilence(ctx, u.ID); ok {
+		s.cache(sil)
+	} else {
+		s.uncache(u.ID)
+	}
+	s.observeVersion(u.Version)
+}
+
+// publish notifies the other instances about the modification of a silence.
+func (s *Silences) publish(ctx context.Context, id string, version int64) {
+	b, err := json.Marshal(silenceUpdate{Origin: s.origin, Version: version, ID: id})
+	if err != nil {
+		level.Error(s.logger).Log("msg", "marshal silence update failed", "err", err)
+		return
+	}
+	if err := s.rdb.Publish(ctx, s.updatesChannel(), b).Err(); err != nil {
+		// Other instances pick up the change when they resync.
+		level.Error(s.logger).Log("msg", "publish silence update failed", "uid", id, "err", err)
+	}
+}
+
+// cache adds or replaces the silence in the cache.
+// The caller must hold the lock.
+func (s *Silences) cache(sil *pb.Silence) {
+	delete(s.mc, sil.Id)
+	delete(s.rc, sil.Id)
+	s.st[sil.Id] = sil
+	s.idx.add(sil)
+	s.version++
+}
+
+// uncache removes the silence from the cache.
+// The caller must hold the lock.
+func (s *Silences) uncache(id string) {
+	delete(s.mc, id)
+	delete(s.rc, id)
+	delete(s.st, id)
+	s.idx.remove(id)
+	s.version++
+}
+
+// observeVersion records that the cache contains the change which resulted
+// in the given Redis version. Only consecutive versions are recorded, a gap
+// means that a change was missed and is picked up by the next resync.
+// The caller must hold the lock.
+func (s *Silences) observeVersion(version int64) {
+	if version == s.seen+1 {
+		s.seen = version
+	}
+}
+
+func (s *Silences) redisVersion(ctx context.Context) (int64, error) {
+	version, err := s.rdb.Get(ctx, s.versionIdx()).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "get silence version for redis")
+	}
+	return version, nil
+}
+
+func (s *Silences) updatesChannel() string {
+	return fmt.Sprintf(orgSilenceUpdates, s.orgId)
+}
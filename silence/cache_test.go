@@ -0,0 +1,180 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/test/redistest"
+)
+
+func TestObserveVersion(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		version, seen int64
+	}{
+		{version: 1, seen: 1},
+		// A gap is not recorded until it is filled.
+		{version: 3, seen: 1},
+		{version: 2, seen: 2},
+		{version: 2, seen: 2},
+		{version: 3, seen: 3},
+	} {
+		s.observeVersion(tc.version)
+		require.Equal(t, tc.seen, s.seen, "version %d", tc.version)
+	}
+}
+
+func updatePayload(t *testing.T, origin string, version int64, id string) string {
+	b, err := json.Marshal(silenceUpdate{Origin: origin, Version: version, ID: id})
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestApplyUpdate(t *testing.T) {
+	srv := redistest.Run(t)
+	a, mc := newTestSilences(t, srv, Options{})
+	b, _ := newTestSilences(t, srv, Options{})
+	ctx := context.Background()
+
+	// Load the cache of b.
+	_, _, err := b.Query()
+	require.NoError(t, err)
+
+	id, err := a.Set(ctx, newTestSilence(mc.Now(), time.Hour))
+	require.NoError(t, err)
+	_, err = b.QueryOne(QIDs(id))
+	require.Equal(t, ErrNotFound, err)
+
+	// Updates of the instance itself are ignored.
+	b.applyUpdate(ctx, updatePayload(t, b.origin, 1, id))
+	_, err = b.QueryOne(QIDs(id))
+	require.Equal(t, ErrNotFound, err)
+
+	b.applyUpdate(ctx, updatePayload(t, a.origin, 1, id))
+	_, err = b.QueryOne(QIDs(id))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), b.seen)
+
+	// Removed silences are dropped from the cache.
+	require.NoError(t, srv.Client(t).HDel(ctx, a.orgSilenceIdx(), id).Err())
+	b.applyUpdate(ctx, updatePayload(t, a.origin, 2, id))
+	_, err = b.QueryOne(QIDs(id))
+	require.Equal(t, ErrNotFound, err)
+	require.Equal(t, int64(2), b.seen)
+
+	// Invalid updates are ignored.
+	version := b.Version()
+	b.applyUpdate(ctx, "{")
+	require.Equal(t, version, b.Version())
+}
+
+func TestResync(t *testing.T) {
+	srv := redistest.Run(t)
+	a, mc := newTestSilences(t, srv, Options{})
+	b, _ := newTestSilences(t, srv, Options{})
+	ctx := context.Background()
+
+	require.NoError(t, b.resync(ctx))
+	require.Equal(t, 1.0, testutil.ToFloat64(b.metrics.cacheLoadsTotal))
+
+	// The cache is not reloaded while it is up to date.
+	require.NoError(t, b.resync(ctx))
+	require.Equal(t, 1.0, testutil.ToFloat64(b.metrics.cacheLoadsTotal))
+
+	// The update of a is missed by b and picked up by the resync.
+	id, err := a.Set(ctx, newTestSilence(mc.Now(), time.Hour))
+	require.NoError(t, err)
+	_, err = b.QueryOne(QIDs(id))
+	require.Equal(t, ErrNotFound, err)
+
+	require.NoError(t, b.resync(ctx))
+	require.Equal(t, 2.0, testutil.ToFloat64(b.metrics.cacheLoadsTotal))
+	_, err = b.QueryOne(QIDs(id))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), b.seen)
+
+	// After a gap in the received updates, the next resync reloads the
+	// cache even though the latest update was applied.
+	require.NoError(t, a.Expire(ctx, id))
+	id2, err := a.Set(ctx, newTestSilence(mc.Now(), time.Hour))
+	require.NoError(t, err)
+	b.applyUpdate(ctx, updatePayload(t, a.origin, 3, id2))
+	require.Equal(t, int64(1), b.seen)
+
+	require.NoError(t, b.resync(ctx))
+	require.Equal(t, 3.0, testutil.ToFloat64(b.metrics.cacheLoadsTotal))
+	require.Equal(t, int64(3), b.seen)
+	sil, err := b.QueryOne(QIDs(id))
+	require.NoError(t, err)
+	require.Equal(t, mc.Now(), sil.EndsAt)
+}
+
+func TestRunKeepsCacheCoherent(t *testing.T) {
+	srv := redistest.Run(t)
+	a, mc := newTestSilences(t, srv, Options{})
+	b, bc := newTestSilences(t, srv, Options{})
+	ctx := context.Background()
+
+	stopc := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		b.Run(stopc)
+		close(done)
+	}()
+	defer func() {
+		close(stopc)
+		<-done
+	}()
+	require.Eventually(t, func() bool {
+		b.mtx.RLock()
+		defer b.mtx.RUnlock()
+		return b.loaded
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Updates are received through pub/sub.
+	id, err := a.Set(ctx, newTestSilence(mc.Now(), time.Hour))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := b.QueryOne(QIDs(id))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Changes without update are picked up by the periodic resync.
+	id2 := "missed"
+	sil := newTestSilence(mc.Now(), time.Hour)
+	sil.Id = id2
+	sil.UpdatedAt = mc.Now()
+	b2, err := marshalSilence(sil)
+	require.NoError(t, err)
+	rdb := srv.Client(t)
+	require.NoError(t, rdb.HSet(ctx, a.orgSilenceIdx(), id2, b2).Err())
+	require.NoError(t, rdb.Incr(ctx, a.versionIdx()).Err())
+
+	_, err = b.QueryOne(QIDs(id2))
+	require.Equal(t, ErrNotFound, err)
+	require.Eventually(t, func() bool {
+		bc.Add(resyncInterval)
+		_, err := b.QueryOne(QIDs(id2))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
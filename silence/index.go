@@ -0,0 +1,93 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"github.com/prometheus/common/model"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+// indexKey is the label name and value under which a silence is indexed.
+type indexKey struct {
+	name, value string
+}
+
+// silenceIndex maps label names and values to the silences which can only
+// match label sets containing them. A silence is indexed by one of its
+// equality matchers with a non-empty value. Silences without such a matcher
+// are candidates for every label set.
+type silenceIndex struct {
+	byLabel   map[indexKey]map[string]struct{}
+	unindexed map[string]struct{}
+	keys      map[string]indexKey
+}
+
+func newSilenceIndex() *silenceIndex {
+	return &silenceIndex{
+		byLabel:   map[indexKey]map[string]struct{}{},
+		unindexed: map[string]struct{}{},
+		keys:      map[string]indexKey{},
+	}
+}
+
+// add indexes the silence, replacing a previous version of it.
+func (i *silenceIndex) add(sil *pb.Silence) {
+	i.remove(sil.Id)
+
+	for _, m := range sil.Matchers {
+		if m.Type != pb.Matcher_EQUAL || m.Pattern == "" {
+			continue
+		}
+		k := indexKey{name: m.Name, value: m.Pattern}
+		ids, ok := i.byLabel[k]
+		if !ok {
+			ids = map[string]struct{}{}
+			i.byLabel[k] = ids
+		}
+		ids[sil.Id] = struct{}{}
+		i.keys[sil.Id] = k
+		return
+	}
+	i.unindexed[sil.Id] = struct{}{}
+}
+
+// remove removes the silence with the given ID from the index.
+func (i *silenceIndex) remove(id string) {
+	k, ok := i.keys[id]
+	if !ok {
+		delete(i.unindexed, id)
+		return
+	}
+	delete(i.keys, id)
+	ids := i.byLabel[k]
+	delete(ids, id)
+	if len(ids) == 0 {
+		delete(i.byLabel, k)
+	}
+}
+
+// candidates returns the IDs of the silences which may match the label set.
+func (i *silenceIndex) candidates(lset model.LabelSet) []string {
+	res := make([]string, 0, len(i.unindexed))
+	for id := range i.unindexed {
+		res = append(res, id)
+	}
+	for name, value := range lset {
+		for id := range i.byLabel[indexKey{name: string(name), value: string(value)}] {
+			res = append(res, id)
+		}
+	}
+	return res
+}
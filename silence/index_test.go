@@ -0,0 +1,57 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+func TestSilenceIndex(t *testing.T) {
+	idx := newSilenceIndex()
+	idx.add(&pb.Silence{Id: "eq", Matchers: []*pb.Matcher{
+		{Type: pb.Matcher_REGEXP, Name: "instance", Pattern: ".+"},
+		{Type: pb.Matcher_EQUAL, Name: "job", Pattern: "node"},
+	}})
+	idx.add(&pb.Silence{Id: "re", Matchers: []*pb.Matcher{
+		{Type: pb.Matcher_REGEXP, Name: "job", Pattern: "node|db"},
+	}})
+	idx.add(&pb.Silence{Id: "other", Matchers: []*pb.Matcher{
+		{Type: pb.Matcher_EQUAL, Name: "job", Pattern: "db"},
+	}})
+
+	require.ElementsMatch(t, []string{"eq", "re"}, idx.candidates(model.LabelSet{"job": "node", "instance": "1"}))
+	require.ElementsMatch(t, []string{"re", "other"}, idx.candidates(model.LabelSet{"job": "db"}))
+	require.ElementsMatch(t, []string{"re"}, idx.candidates(model.LabelSet{"alertname": "Down"}))
+
+	// Updating a silence replaces its index entry.
+	idx.add(&pb.Silence{Id: "eq", Matchers: []*pb.Matcher{
+		{Type: pb.Matcher_EQUAL, Name: "job", Pattern: "db"},
+	}})
+	require.ElementsMatch(t, []string{"re"}, idx.candidates(model.LabelSet{"job": "node"}))
+	require.ElementsMatch(t, []string{"eq", "re", "other"}, idx.candidates(model.LabelSet{"job": "db"}))
+
+	idx.remove("eq")
+	idx.remove("re")
+	require.ElementsMatch(t, []string{"other"}, idx.candidates(model.LabelSet{"job": "db"}))
+	require.Empty(t, idx.unindexed)
+
+	idx.remove("other")
+	require.Empty(t, idx.byLabel)
+	require.Empty(t, idx.keys)
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+var (
+	// ErrNotPendingApproval is returned when approving a silence which does
+	// not require an approval or has already been approved.
+	ErrNotPendingApproval = errors.New("silence is not pending approval")
+	// ErrSelfApproval is returned when the author of a silence approves it.
+	ErrSelfApproval = errors.New("silence must be approved by another user than its author")
+	// ErrApproverMissing is returned when approving a silence without an
+	// approver.
+	ErrApproverMissing = errors.New("approver missing")
+)
+
+// Policy restricts the silences which take effect without an approval.
+// Zero values disable the respective restriction.
+type Policy struct {
+	// MaxDuration is the maximum time between the start and end of a
+	// silence.
+	MaxDuration time.Duration
+	// RequiredLabels are labels of which a silence must match at least one
+	// by a matcher that does not match the empty string, e.g. team or
+	// service.
+	RequiredLabels []string
+	// MaxMatchedAlerts is the maximum number of firing alerts a silence may
+	// match when it is set.
+	MaxMatchedAlerts int
+	// MaxPerAuthor is the maximum number of active and pending silences
+	// created by the same author.
+	MaxPerAuthor int
+}
+
+// violations returns the reasons for which the silence violates the policy.
+// The caller must hold the lock.
+func (s *Silences) violations(ctx context.Context, sil *pb.Silence, now time.Time) ([]string, error) {
+	var (
+		p       = s.policy
+		reasons []string
+	)
+	if p.MaxDuration > 0 {
+		if d := sil.EndsAt.Sub(sil.StartsAt); d > p.MaxDuration {
+			reasons = append(reasons, fmt.Sprintf("duration %s exceeds the maximum of %s", d, p.MaxDuration))
+		}
+	}
+	if len(p.RequiredLabels) > 0 && !scopedBy(sil, p.RequiredLabels) {
+		reasons = append(reasons, fmt.Sprintf("no matcher on one of the labels %s", strings.Join(p.RequiredLabels, ", ")))
+	}
+	if p.MaxMatchedAlerts > 0 && s.alerts != nil {
+		// Invalid matchers are rejected by the validation of the silence.
+		ms, _ := CompileMatchers(sil)
+		matched := 0
+		for _, a := range s.alerts() {
+			if !a.ResolvedAt(now) && ms.Matches(a.Labels) {
+				matched++
+			}
+		}
+		if matched > p.MaxMatchedAlerts {
+			reasons = append(reasons, fmt.Sprintf("matches %d firing alerts, more than the maximum of %d", matched, p.MaxMatchedAlerts))
+		}
+	}
+	if p.MaxPerAuthor > 0 {
+		if err := s.ensureLoaded(ctx); err != nil {
+			return nil, err
+		}
+		n := 0
+		for _, other := range s.st {
+			if other.Id != sil.Id && other.CreatedBy == sil.CreatedBy && getState(other, now) != types.SilenceStateExpired {
+				n++
+			}
+		}
+		if n >= p.MaxPerAuthor {
+			reasons = append(reasons, fmt.Sprintf("author %q already has %d silences, the maximum is %d", sil.CreatedBy, n, p.MaxPerAuthor))
+		}
+	}
+	return reasons, nil
+}
+
+// scopedBy returns true if the silence has a matcher on one of the labels
+// which does not match the empty string.
+func scopedBy(sil *pb.Silence, labels []string) bool {
+	for _, m := range sil.Matchers {
+		if matchesEmpty(m) {
+			continue
+		}
+		for _, ln := range labels {
+			if m.Name == ln {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Approve approves the silence with the given ID, which is pending approval
+// because it violated the silence policy. The approver must not be the
+// author of the silence.
+func (s *Silences) Approve(ctx context.Context, id, approvedBy string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if approvedBy == "" {
+		return ErrApproverMissing
+	}
+	prev, ok := s.getSilence(ctx, id)
+	if !ok {
+		return ErrNotFound
+	}
+	now := s.now()
+	if !prev.Approval.Pending() || getState(prev, now) == types.SilenceStateExpired {
+		return ErrNotPendingApproval
+	}
+	if approvedBy == prev.CreatedBy {
+		return ErrSelfApproval
+	}
+
+	sil := cloneSilence(prev)
+	sil.Approval = &pb.Approval{
+		Reasons:    prev.Approval.Reasons,
+		ApprovedBy: approvedBy,
+		ApprovedAt: now,
+	}
+	return s.setSilence(ctx, prev, sil, now)
+}
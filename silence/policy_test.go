@@ -0,0 +1,138 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestViolations(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	alerts := []*types.Alert{
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "DiskFull", "team": "db"}}},
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "DiskFull", "team": "web"}}},
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "DiskFull", "team": "web"}, EndsAt: now.Add(-time.Minute)}},
+	}
+	s, err := New(Options{
+		Policy: Policy{
+			MaxDuration:      24 * time.Hour,
+			RequiredLabels:   []string{"team", "service"},
+			MaxMatchedAlerts: 1,
+			MaxPerAuthor:     2,
+		},
+		Alerts: func() []*types.Alert { return alerts },
+	})
+	require.NoError(t, err)
+	s.loaded = true
+	s.st = state{
+		"1": {Id: "1", CreatedBy: "alice", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		"2": {Id: "2", CreatedBy: "alice", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+		"3": {Id: "3", CreatedBy: "bob", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		"4": {Id: "4", CreatedBy: "bob", StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		sil     *pb.Silence
+		reasons []string
+	}{
+		{
+			name: "within policy",
+			sil: &pb.Silence{
+				CreatedBy: "bob",
+				Matchers:  []*pb.Matcher{{Name: "team", Pattern: "db", Type: pb.Matcher_EQUAL}},
+				StartsAt:  now,
+				EndsAt:    now.Add(time.Hour),
+			},
+		},
+		{
+			name: "too long",
+			sil: &pb.Silence{
+				CreatedBy: "bob",
+				Matchers:  []*pb.Matcher{{Name: "team", Pattern: "db", Type: pb.Matcher_EQUAL}},
+				StartsAt:  now,
+				EndsAt:    now.Add(48 * time.Hour),
+			},
+			reasons: []string{"duration 48h0m0s exceeds the maximum of 24h0m0s"},
+		},
+		{
+			name: "unscoped and matching too many alerts",
+			sil: &pb.Silence{
+				CreatedBy: "bob",
+				Matchers: []*pb.Matcher{
+					{Name: "alertname", Pattern: "DiskFull", Type: pb.Matcher_EQUAL},
+					{Name: "team", Pattern: ".*", Type: pb.Matcher_REGEXP},
+				},
+				StartsAt: now,
+				EndsAt:   now.Add(time.Hour),
+			},
+			reasons: []string{
+				"no matcher on one of the labels team, service",
+				"matches 2 firing alerts, more than the maximum of 1",
+			},
+		},
+		{
+			name: "author quota",
+			sil: &pb.Silence{
+				CreatedBy: "alice",
+				Matchers:  []*pb.Matcher{{Name: "service", Pattern: "api", Type: pb.Matcher_EQUAL}},
+				StartsAt:  now,
+				EndsAt:    now.Add(time.Hour),
+			},
+			reasons: []string{`author "alice" already has 2 silences, the maximum is 2`},
+		},
+		{
+			name: "updates do not count against the quota",
+			sil: &pb.Silence{
+				Id:        "1",
+				CreatedBy: "alice",
+				Matchers:  []*pb.Matcher{{Name: "service", Pattern: "api", Type: pb.Matcher_EQUAL}},
+				StartsAt:  now,
+				EndsAt:    now.Add(time.Hour),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reasons, err := s.violations(context.Background(), tc.sil, now)
+			require.NoError(t, err)
+			require.Equal(t, tc.reasons, reasons)
+		})
+	}
+}
+
+func TestMutingStatePendingApproval(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sil := &pb.Silence{
+		Id:       "broad",
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+		Approval: &pb.Approval{Reasons: []string{"too broad"}},
+	}
+	require.Equal(t, types.SilenceStatePendingApproval, s.mutingState(sil, now))
+	require.Equal(t, types.SilenceStateExpired, s.mutingState(sil, now.Add(2*time.Hour)))
+
+	sil.Approval.ApprovedBy = "bob"
+	require.Equal(t, types.SilenceStateActive, s.mutingState(sil, now))
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/alertmanager/pkg/rrule"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// recurrence returns true if the given time is within a recurring window of
+// a silence.
+type recurrence func(time.Time) bool
+
+type recurrenceCache map[string]recurrence
+
+// Get returns the compiled recurrence of the silence. If it is a missed cache
+// access, it compiles and adds the recurrence of the silence to the cache.
+func (c recurrenceCache) Get(s *pb.Silence) (recurrence, error) {
+	if r, ok := c[s.Id]; ok {
+		return r, nil
+	}
+	r, err := compileRecurrence(s)
+	if err != nil {
+		return nil, err
+	}
+	c[s.Id] = r
+	return r, nil
+}
+
+// compileRecurrence compiles the recurrence of a silence. Silences without
+// a recurrence are always within it.
+func compileRecurrence(s *pb.Silence) (recurrence, error) {
+	rec := s.Recurrence
+	if rec == nil {
+		return func(time.Time) bool { return true }, nil
+	}
+	if rec.RRule == "" {
+		return func(t time.Time) bool {
+			for _, ti := range rec.TimeIntervals {
+				if ti.ContainsTime(t.UTC()) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+	loc, err := time.LoadLocation(rec.Location)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid location")
+	}
+	r, err := rrule.Parse(rec.RRule, s.StartsAt.In(loc))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid recurrence rule")
+	}
+	return func(t time.Time) bool {
+		return r.Contains(t, rec.Duration)
+	}, nil
+}
+
+func validateRecurrence(s *pb.Silence) error {
+	rec := s.Recurrence
+	if rec == nil {
+		return nil
+	}
+	if (len(rec.TimeIntervals) == 0) == (rec.RRule == "") {
+		return errors.New("recurrence requires either time intervals or a recurrence rule")
+	}
+	if rec.RRule == "" {
+		if rec.Duration != 0 || rec.Location != "" {
+			return errors.New("recurrence duration and location require a recurrence rule")
+		}
+		return nil
+	}
+	if rec.Duration <= 0 {
+		return errors.New("recurrence rule requires a positive duration")
+	}
+	_, err := compileRecurrence(s)
+	return err
+}
+
+// mutingState returns the state of the silence at the given time with
+// regard to muting alerts. Active silences with a recurrence are pending
+// outside of their recurring windows, silences which are not approved yet
+// never mute alerts.
+func (s *Silences) mutingState(sil *pb.Silence, now time.Time) types.SilenceState {
+	st := silenceState(sil, now)
+	if st != types.SilenceStateActive || sil.Recurrence == nil {
+		return st
+	}
+
+	s.mtx.Lock()
+	r, err := s.rc.Get(sil)
+	s.mtx.Unlock()
+	if err != nil {
+		level.Error(s.logger).Log("msg", "Compiling silence recurrence failed", "uid", sil.Id, "err", err)
+		return types.SilenceStatePending
+	}
+	if !r(now) {
+		return types.SilenceStatePending
+	}
+	return st
+}
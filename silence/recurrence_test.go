@@ -0,0 +1,116 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestValidateRecurrence(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name string
+		rec  *pb.Recurrence
+		err  string
+	}{
+		{name: "none"},
+		{
+			name: "time intervals",
+			rec:  &pb.Recurrence{TimeIntervals: []timeinterval.TimeInterval{{}}},
+		},
+		{
+			name: "rule",
+			rec:  &pb.Recurrence{RRule: "FREQ=DAILY", Duration: time.Hour, Location: "Europe/Berlin"},
+		},
+		{
+			name: "empty",
+			rec:  &pb.Recurrence{},
+			err:  "either time intervals or a recurrence rule",
+		},
+		{
+			name: "both",
+			rec:  &pb.Recurrence{TimeIntervals: []timeinterval.TimeInterval{{}}, RRule: "FREQ=DAILY"},
+			err:  "either time intervals or a recurrence rule",
+		},
+		{
+			name: "rule without duration",
+			rec:  &pb.Recurrence{RRule: "FREQ=DAILY"},
+			err:  "positive duration",
+		},
+		{
+			name: "invalid rule",
+			rec:  &pb.Recurrence{RRule: "FREQ=HOURLY", Duration: time.Hour},
+			err:  "invalid recurrence rule",
+		},
+		{
+			name: "invalid location",
+			rec:  &pb.Recurrence{RRule: "FREQ=DAILY", Duration: time.Hour, Location: "Mars/Olympus"},
+			err:  "invalid location",
+		},
+		{
+			name: "duration without rule",
+			rec:  &pb.Recurrence{TimeIntervals: []timeinterval.TimeInterval{{}}, Duration: time.Hour},
+			err:  "require a recurrence rule",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRecurrence(&pb.Silence{StartsAt: start, Recurrence: tc.rec})
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestMutingState(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	// Saturdays from 22:00 for four hours.
+	sil := &pb.Silence{
+		Id:       "weekly",
+		StartsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Recurrence: &pb.Recurrence{
+			RRule:    "FREQ=WEEKLY;BYDAY=SA;BYHOUR=22;BYMINUTE=0",
+			Duration: 4 * time.Hour,
+		},
+	}
+	require.Equal(t, types.SilenceStatePending, s.mutingState(sil, time.Date(2024, 1, 6, 21, 0, 0, 0, time.UTC)))
+	require.Equal(t, types.SilenceStateActive, s.mutingState(sil, time.Date(2024, 1, 7, 1, 0, 0, 0, time.UTC)))
+	require.Equal(t, types.SilenceStatePending, s.mutingState(sil, time.Date(2024, 1, 7, 2, 0, 0, 0, time.UTC)))
+	// The recurrence ends with the silence.
+	require.Equal(t, types.SilenceStateExpired, s.mutingState(sil, time.Date(2024, 2, 3, 23, 0, 0, 0, time.UTC)))
+
+	var rec pb.Recurrence
+	require.NoError(t, json.Unmarshal([]byte(`{"time_intervals": [{"weekdays": ["saturday", "sunday"]}]}`), &rec))
+	sil = &pb.Silence{
+		Id:         "weekend",
+		StartsAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Recurrence: &rec,
+	}
+	require.Equal(t, types.SilenceStatePending, s.mutingState(sil, time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)))
+	require.Equal(t, types.SilenceStateActive, s.mutingState(sil, time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)))
+}
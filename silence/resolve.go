@@ -0,0 +1,257 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// RunExpireResolved expires the silences whose matched alerts resolved at
+// the given interval until stopc is closed. The alerts function returns the
+// alerts currently known.
+func (s *Silences) RunExpireResolved(interval time.Duration, stopc <-chan struct{}, alerts func() []*types.Alert) {
+	t := s.clock.Ticker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			n, err := s.ExpireResolved(context.Background(), alerts())
+			if err != nil {
+				level.Error(s.logger).Log("msg", "Expiring resolved silences failed", "err", err)
+				continue
+			}
+			if n > 0 {
+				level.Debug(s.logger).Log("msg", "Expired resolved silences", "expired", n)
+			}
+		}
+	}
+}
+
+// orgSilenceMatched is the hash of the alerts matched by a silence expiring
+// on resolve, keyed by alert fingerprint.
+const orgSilenceMatched = "%d_silence_matched_%s"
+
+// matchedAlert is an alert matched by a silence expiring on resolve, as last
+// observed by any instance.
+type matchedAlert struct {
+	// MatchedAt is the time the alert was first observed firing.
+	MatchedAt time.Time `json:"matchedAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// resolvedAt returns whether the alert is resolved at the given time. Alerts
+// which aren't observed anymore resolve once their last known end passed.
+func (m matchedAlert) resolvedAt(ts time.Time) bool {
+	return !m.EndsAt.IsZero() && !m.EndsAt.After(ts)
+}
+
+// ExpireResolved records the alerts matched by the active silences which
+// expire on resolve, and expires the silences all of whose matched alerts
+// are resolved for longer than the grace period. The matched alerts are
+// shared by all instances in Redis, an alert counts as matched once it was
+// observed firing by any of them. It returns the number of expired silences.
+func (s *Silences) ExpireResolved(ctx context.Context, alerts []*types.Alert) (int, error) {
+	now := s.now()
+
+	type candidate struct {
+		sil      *pb.Silence
+		matchers labels.Matchers
+	}
+	var candidates []candidate
+
+	s.mtx.Lock()
+	if err := s.ensureLoaded(ctx); err != nil {
+		s.mtx.Unlock()
+		return 0, err
+	}
+	for _, sil := range s.st {
+		if !sil.ExpireOnResolve || getState(sil, now) != types.SilenceStateActive {
+			continue
+		}
+		ms, err := s.mc.Get(sil)
+		if err != nil {
+			level.Error(s.logger).Log("msg", "Compiling silence matchers failed", "uid", sil.Id, "err", err)
+			continue
+		}
+		candidates = append(candidates, candidate{sil: cloneSilence(sil), matchers: ms})
+	}
+	s.mtx.Unlock()
+
+	var n int
+	for _, c := range candidates {
+		var matching []*types.Alert
+		for _, a := range alerts {
+			if c.matchers.Matches(a.Labels) {
+				matching = append(matching, a)
+			}
+		}
+		matched, err := s.trackMatched(ctx, c.sil.Id, matching, now)
+		if err != nil {
+			return n, err
+		}
+
+		var prev pb.ResolveState
+		if c.sil.ResolveState != nil {
+			prev = *c.sil.ResolveState
+		}
+		state := resolveState(matched, now)
+		if !state.ResolvedAt.IsZero() && !now.Before(state.ResolvedAt.Add(s.resolveGracePeriod)) {
+			expired, err := s.expireResolved(ctx, c.sil)
+			if err != nil {
+				return n, err
+			}
+			if expired {
+				n++
+			}
+			continue
+		}
+		if state.MatchedAt.Equal(prev.MatchedAt) && state.ResolvedAt.Equal(prev.ResolvedAt) {
+			continue
+		}
+		if err := s.setResolveState(ctx, c.sil, state); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// trackMatched merges the given alerts matched by the silence into the
+// matched alerts stored in Redis and returns the result. Alerts are only
+// added while firing, the most recently updated version of an alert wins.
+func (s *Silences) trackMatched(ctx context.Context, id string, alerts []*types.Alert, now time.Time) (map[string]matchedAlert, error) {
+	key := s.matchedIdx(id)
+	var matched map[string]matchedAlert
+
+	err := s.watch(ctx, func(tx *redis.Tx) error {
+		cur, err := tx.HGetAll(ctx, key).Result()
+		if err != nil {
+			return errors.Wrap(err, "get matched alerts for redis")
+		}
+		matched = make(map[string]matchedAlert, len(cur))
+		for fp, v := range cur {
+			var m matchedAlert
+			if err := json.Unmarshal([]byte(v), &m); err != nil {
+				level.Error(s.logger).Log("msg", "unmarshal matched alert failed", "uid", id, "fingerprint", fp, "err", err)
+				continue
+			}
+			matched[fp] = m
+		}
+
+		var changed []interface{}
+		for _, a := range alerts {
+			fp := a.Fingerprint().String()
+			m, ok := matched[fp]
+			switch {
+			case !ok && a.ResolvedAt(now):
+				continue
+			case !ok:
+				m.MatchedAt = now
+			case !a.UpdatedAt.After(m.UpdatedAt):
+				continue
+			}
+			m.EndsAt = a.EndsAt
+			m.UpdatedAt = a.UpdatedAt
+			b, err := json.Marshal(m)
+			if err != nil {
+				return errors.Wrap(err, "marshal matched alert")
+			}
+			matched[fp] = m
+			changed = append(changed, fp, b)
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key, changed...)
+			return nil
+		})
+		return err
+	}, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "track matched alerts")
+	}
+	return matched, nil
+}
+
+// resolveState returns the resolve state of a silence with the given matched
+// alerts.
+func resolveState(matched map[string]matchedAlert, now time.Time) pb.ResolveState {
+	var (
+		state  pb.ResolveState
+		firing bool
+	)
+	for _, m := range matched {
+		if state.MatchedAt.IsZero() || m.MatchedAt.Before(state.MatchedAt) {
+			state.MatchedAt = m.MatchedAt
+		}
+		if !m.resolvedAt(now) {
+			firing = true
+		} else if m.EndsAt.After(state.ResolvedAt) {
+			state.ResolvedAt = m.EndsAt
+		}
+	}
+	if firing {
+		state.ResolvedAt = time.Time{}
+	}
+	return state
+}
+
+func (s *Silences) matchedIdx(id string) string {
+	return fmt.Sprintf(orgSilenceMatched, s.orgId, id)
+}
+
+// expireResolved expires the silence unless it was modified in the meantime.
+func (s *Silences) expireResolved(ctx context.Context, sil *pb.Silence) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if cur, ok := s.st[sil.Id]; !ok || !cur.UpdatedAt.Equal(sil.UpdatedAt) {
+		return false, nil
+	}
+	if err := s.expire(ctx, []string{sil.Id}); err != nil {
+		return false, errors.Wrap(err, "expire resolved silence")
+	}
+	level.Info(s.logger).Log("msg", "Expired silence as its alerts resolved", "uid", sil.Id)
+	return true, nil
+}
+
+// setResolveState stores the resolve state of the silence unless it was
+// modified in the meantime. It doesn't change the history of the silence.
+func (s *Silences) setResolveState(ctx context.Context, sil *pb.Silence, state pb.ResolveState) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	cur, ok := s.st[sil.Id]
+	if !ok || !cur.UpdatedAt.Equal(sil.UpdatedAt) {
+		return nil
+	}
+	upd := cloneSilence(cur)
+	upd.ResolveState = &state
+	return s.storeSilence(ctx, nil, upd)
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/test/redistest"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestResolveState(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, pb.ResolveState{}, resolveState(nil, now))
+
+	matched := map[string]matchedAlert{
+		"1": {MatchedAt: now.Add(-time.Hour), EndsAt: now.Add(-10 * time.Minute)},
+		"2": {MatchedAt: now.Add(-30 * time.Minute), EndsAt: now.Add(5 * time.Minute)},
+	}
+	require.Equal(t, pb.ResolveState{MatchedAt: now.Add(-time.Hour)}, resolveState(matched, now))
+
+	// All alerts resolved once the last one ended.
+	require.Equal(t,
+		pb.ResolveState{MatchedAt: now.Add(-time.Hour), ResolvedAt: now.Add(5 * time.Minute)},
+		resolveState(matched, now.Add(5*time.Minute)),
+	)
+
+	// Alerts without end never resolve.
+	matched["3"] = matchedAlert{MatchedAt: now}
+	require.Equal(t, pb.ResolveState{MatchedAt: now.Add(-time.Hour)}, resolveState(matched, now.Add(time.Hour)))
+}
+
+func newResolveTestAlert(name string, startsAt, endsAt, updatedAt time.Time) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"job": "test", "alertname": model.LabelValue(name)},
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+		},
+		UpdatedAt: updatedAt,
+	}
+}
+
+func TestExpireResolved(t *testing.T) {
+	srv := redistest.Run(t)
+	grace := 5 * time.Minute
+	a, mc := newTestSilences(t, srv, Options{ResolveGracePeriod: grace})
+	b, _ := newTestSilences(t, srv, Options{ResolveGracePeriod: grace})
+	b.clock = mc
+	ctx := context.Background()
+	start := mc.Now()
+
+	sil := newTestSilence(start, 24*time.Hour)
+	sil.ExpireOnResolve = true
+	id, err := a.Set(ctx, sil)
+	require.NoError(t, err)
+
+	resolveState := func(s *Silences) pb.ResolveState {
+		t.Helper()
+		require.NoError(t, s.resync(ctx))
+		sil, err := s.QueryOne(QIDs(id))
+		require.NoError(t, err)
+		require.Equal(t, types.SilenceStateActive, getState(sil, mc.Now()))
+		if sil.ResolveState == nil {
+			return pb.ResolveState{}
+		}
+		return *sil.ResolveState
+	}
+	expireResolved := func(s *Silences, alerts ...*types.Alert) int {
+		t.Helper()
+		n, err := s.ExpireResolved(ctx, alerts)
+		require.NoError(t, err)
+		return n
+	}
+
+	// Alerts which already resolved don't count as matched.
+	resolved := newResolveTestAlert("Resolved", start.Add(-time.Hour), start.Add(-time.Minute), start.Add(-time.Minute))
+	require.Equal(t, 0, expireResolved(a, resolved))
+	require.Equal(t, pb.ResolveState{}, resolveState(a))
+
+	// Instance a sees the first alert firing.
+	mc.Add(time.Minute)
+	first := newResolveTestAlert("First", mc.Now(), mc.Now().Add(5*time.Minute), mc.Now())
+	require.Equal(t, 0, expireResolved(a, first))
+	matchedAt := mc.Now()
+	require.Equal(t, pb.ResolveState{MatchedAt: matchedAt}, resolveState(a))
+
+	// Instance b doesn't know the alert, which doesn't make it resolved.
+	require.Equal(t, 0, expireResolved(b))
+	require.Equal(t, pb.ResolveState{MatchedAt: matchedAt}, resolveState(b))
+
+	// Instance b sees the second alert firing.
+	mc.Add(time.Minute)
+	second := newResolveTestAlert("Second", mc.Now(), mc.Now().Add(5*time.Minute), mc.Now())
+	require.Equal(t, 0, expireResolved(b, second))
+
+	// The first alert resolves, the second one is still firing.
+	mc.Add(time.Minute)
+	firstResolved := newResolveTestAlert("First", first.StartsAt, mc.Now(), mc.Now())
+	require.Equal(t, 0, expireResolved(a, firstResolved))
+	require.Equal(t, pb.ResolveState{MatchedAt: matchedAt}, resolveState(a))
+
+	// An outdated view of the first alert doesn't make it firing again.
+	second.EndsAt = mc.Now().Add(5 * time.Minute)
+	second.UpdatedAt = mc.Now()
+	require.Equal(t, 0, expireResolved(b, first, second))
+
+	// Once the second alert resolved, all matched alerts are resolved.
+	mc.Add(time.Minute)
+	resolvedAt := mc.Now()
+	secondResolved := newResolveTestAlert("Second", second.StartsAt, resolvedAt, resolvedAt)
+	require.Equal(t, 0, expireResolved(b, secondResolved))
+	require.Equal(t, pb.ResolveState{MatchedAt: matchedAt, ResolvedAt: resolvedAt}, resolveState(a))
+
+	// Both instances agree on the state and expire the silence after the
+	// grace period, even without knowing the alerts anymore.
+	mc.Add(grace - time.Second)
+	require.Equal(t, 0, expireResolved(a))
+	require.Equal(t, 0, expireResolved(b))
+	require.Equal(t, pb.ResolveState{MatchedAt: matchedAt, ResolvedAt: resolvedAt}, resolveState(b))
+
+	mc.Add(time.Second)
+	require.Equal(t, 1, expireResolved(a))
+	expiredAt := mc.Now()
+
+	mc.Add(time.Second)
+	require.NoError(t, b.resync(ctx))
+	require.Equal(t, 0, expireResolved(b))
+
+	expired, err := b.QueryOne(QIDs(id))
+	require.NoError(t, err)
+	require.Equal(t, expiredAt, expired.EndsAt)
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestSilencerMutesFor(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	matchers := []*pb.Matcher{{Name: "job", Pattern: "db", Type: pb.Matcher_EQUAL}}
+	s.loaded = true
+	s.cache(&pb.Silence{
+		Id:        "pager",
+		Matchers:  matchers,
+		StartsAt:  now.Add(-time.Hour),
+		EndsAt:    now.Add(time.Hour),
+		Receivers: []string{"team-pager"},
+	})
+	s.cache(&pb.Silence{
+		Id:           "chat-email",
+		Matchers:     matchers,
+		StartsAt:     now.Add(-time.Hour),
+		EndsAt:       now.Add(time.Hour),
+		Receivers:    []string{"team-chat"},
+		Integrations: []string{"email"},
+	})
+
+	marker := types.NewMarker(prometheus.NewRegistry())
+	silencer := NewSilencer(s, marker, log.NewNopLogger())
+	lset := model.LabelSet{"job": "db"}
+
+	require.False(t, silencer.Mutes(lset))
+	require.True(t, silencer.MutesFor(lset, "team-pager", ""))
+	require.True(t, silencer.MutesFor(lset, "team-pager", "pagerduty"))
+	require.False(t, silencer.MutesFor(lset, "team-chat", ""))
+	require.False(t, silencer.MutesFor(lset, "team-chat", "slack"))
+	require.True(t, silencer.MutesFor(lset, "team-chat", "email"))
+	require.False(t, silencer.MutesFor(model.LabelSet{"job": "web"}, "team-pager", ""))
+
+	all, integrations := silencer.MutedIntegrations(lset, "team-chat")
+	require.False(t, all)
+	require.Equal(t, []string{"email"}, integrations)
+	all, integrations = silencer.MutedIntegrations(lset, "team-pager")
+	require.True(t, all)
+	require.Empty(t, integrations)
+
+	// Scoped silences don't suppress the alert, but are visible in its status.
+	status := marker.Status(lset.Fingerprint())
+	require.Equal(t, types.AlertStateActive, status.State)
+	require.Empty(t, status.SilencedBy)
+	require.Equal(t, []string{"team-chat/email", "team-pager"}, status.SilencedReceivers)
+	ids, _ := marker.SilencedReceivers(lset.Fingerprint())
+	require.Equal(t, []string{"chat-email", "pager"}, ids)
+
+	// A silence for all receivers suppresses the alert.
+	s.cache(&pb.Silence{
+		Id:       "all",
+		Matchers: matchers,
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+	})
+	require.True(t, silencer.Mutes(lset))
+	require.True(t, silencer.MutesFor(lset, "team-chat", "slack"))
+	status = marker.Status(lset.Fingerprint())
+	require.Equal(t, types.AlertStateSuppressed, status.State)
+	require.Equal(t, []string{"all"}, status.SilencedBy)
+}
+
+func TestValidateSilenceScope(t *testing.T) {
+	now := time.Now()
+	sil := &pb.Silence{
+		Id:           "scoped",
+		Matchers:     []*pb.Matcher{{Name: "job", Pattern: "db", Type: pb.Matcher_EQUAL}},
+		StartsAt:     now,
+		EndsAt:       now.Add(time.Hour),
+		UpdatedAt:    now,
+		Integrations: []string{"email"},
+	}
+	require.EqualError(t, validateSilence(sil), "integrations require at least one receiver")
+
+	sil.Receivers = []string{"team-chat"}
+	require.NoError(t, validateSilence(sil))
+}
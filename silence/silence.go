@@ -41,6 +41,7 @@ import (
 
 const orgSilenceIdx = "%d_silence_idx"
 const orgSilenceVersion = "%d_silence_version"
+const orgSilenceHistory = "%d_silence_history_%s"
 
 // ErrNotFound is returned if a silence was not found.
 var ErrNotFound = fmt.Errorf("silence not found")
@@ -63,6 +64,17 @@ func (c matcherCache) Get(s *pb.Silence) (labels.Matchers, error) {
 // add compiles a silences' matchers and adds them to the cache.
 // It returns the compiled matchers.
 func (c matcherCache) add(s *pb.Silence) (labels.Matchers, error) {
+	ms, err := CompileMatchers(s)
+	if err != nil {
+		return nil, err
+	}
+
+	c[s.Id] = ms
+	return ms, nil
+}
+
+// CompileMatchers compiles the matchers of a silence.
+func CompileMatchers(s *pb.Silence) (labels.Matchers, error) {
 	ms := make(labels.Matchers, len(s.Matchers))
 
 	for i, m := range s.Matchers {
@@ -86,8 +98,6 @@ func (c matcherCache) add(s *pb.Silence) (labels.Matchers, error) {
 
 		ms[i] = matcher
 	}
-
-	c[s.Id] = ms
 	return ms, nil
 }
 
@@ -108,11 +118,46 @@ func NewSilencer(s *Silences, m types.Marker, l log.Logger) *Silencer {
 	}
 }
 
-// Mutes implements the Muter interface.
+// Mutes implements the Muter interface. Silences scoped to receivers are not
+// taken into account.
 func (s *Silencer) Mutes(lset model.LabelSet) bool {
+	return s.MutesFor(lset, "", "")
+}
+
+// MutesFor returns whether the label set is muted for the given receiver and,
+// if not empty, integration of the receiver. Besides the silences not scoped
+// to receivers, this takes the silences scoped to the receiver or the
+// integration into account.
+func (s *Silencer) MutesFor(lset model.LabelSet, receiver, integration string) bool {
+	all, integrations := s.MutedIntegrations(lset, receiver)
+	return all || (integration != "" && contains(integrations, integration))
+}
+
+// MutedIntegrations returns whether the label set is muted for all
+// integrations of the receiver and, if not, the integrations of the receiver
+// it is muted for. The silences are queried and the marker is updated once,
+// so that the integrations can be checked without further queries.
+func (s *Silencer) MutedIntegrations(lset model.LabelSet, receiver string) (bool, []string) {
+	var integrations []string
+	for _, sil := range s.activeSilences(lset) {
+		if !inScope(sil, receiver, "") {
+			if contains(sil.Receivers, receiver) {
+				integrations = append(integrations, sil.Integrations...)
+			}
+			continue
+		}
+		return true, nil
+	}
+	return false, uniqueSorted(integrations)
+}
+
+// activeSilences returns the silences currently muting the label set,
+// regardless of their scope, and updates the marker accordingly.
+func (s *Silencer) activeSilences(lset model.LabelSet) []*pb.Silence {
 	fp := lset.Fingerprint()
 	// 激活的静默规则ID、静默规则版本
 	activeIDs, pendingIDs, markerVersion, _ := s.marker.Silenced(fp)
+	scopedIDs, _ := s.marker.SilencedReceivers(fp)
 
 	var (
 		err        error
@@ -121,14 +166,14 @@ func (s *Silencer) Mutes(lset model.LabelSet) bool {
 	)
 	// 检查静默规则有没有变化
 	if markerVersion == s.silences.Version() {
-		totalSilences := len(activeIDs) + len(pendingIDs)
+		totalSilences := len(activeIDs) + len(pendingIDs) + len(scopedIDs)
 		// No new silences added, just need to check which of the old
 		// silences are still relevant and which of the pending ones
 		// have become active.
 		if totalSilences == 0 {
 			// Super fast path: No silences ever applied to this
 			// alert, none have been added. We are done.
-			return false
+			return nil
 		}
 		// This is still a quite fast path: No silences have been added,
 		// we only need to check which of the applicable silences are
@@ -136,7 +181,8 @@ func (s *Silencer) Mutes(lset model.LabelSet) bool {
 		// markerVersion because the Query call might already return a
 		// newer version, which is not the version our old list of
 		// applicable silences is based on.
-		allIDs := append(append(make([]string, 0, totalSilences), activeIDs...), pendingIDs...)
+		allIDs := make([]string, 0, totalSilences)
+		allIDs = append(append(append(allIDs, activeIDs...), pendingIDs...), scopedIDs...)
 		allSils, _, err = s.silences.Query(
 			QIDs(allIDs...),
 			QState(types.SilenceStateActive, types.SilenceStatePending),
@@ -152,22 +198,34 @@ func (s *Silencer) Mutes(lset model.LabelSet) bool {
 	if len(allSils) == 0 {
 		// Easy case, neither active nor pending silences anymore.
 		s.marker.SetActiveOrSilenced(fp, newVersion, nil, nil)
-		return false
+		s.marker.SetSilencedReceivers(fp, nil, nil)
+		return nil
 	}
 	// It is still possible that nothing has changed, but finding out is not
 	// much less effort than just recreating the IDs from the query
 	// result. So let's do it in any case. Note that we cannot reuse the
 	// current ID slices for concurrency reasons.
-	activeIDs, pendingIDs = nil, nil
+	var (
+		active    []*pb.Silence
+		receivers []string
+	)
+	activeIDs, pendingIDs, scopedIDs = nil, nil, nil
 	now := s.silences.now()
 	for _, sil := range allSils {
-		switch getState(sil, now) {
+		switch s.silences.mutingState(sil, now) {
 		case types.SilenceStatePending:
 			pendingIDs = append(pendingIDs, sil.Id)
 		case types.SilenceStateActive:
-			activeIDs = append(activeIDs, sil.Id)
+			active = append(active, sil)
+			if len(sil.Receivers) == 0 {
+				activeIDs = append(activeIDs, sil.Id)
+				continue
+			}
+			scopedIDs = append(scopedIDs, sil.Id)
+			receivers = append(receivers, scopes(sil)...)
 		default:
-			// Do nothing, silence has expired in the meantime.
+			// Do nothing, silence has expired in the meantime or is pending
+			// approval.
 		}
 	}
 	level.Debug(s.logger).Log(
@@ -176,13 +234,66 @@ func (s *Silencer) Mutes(lset model.LabelSet) bool {
 		"total", len(allSils),
 		"active", len(activeIDs),
 		"pending", len(pendingIDs),
+		"scoped", len(scopedIDs),
 	)
 	sort.Strings(activeIDs)
 	sort.Strings(pendingIDs)
+	sort.Strings(scopedIDs)
 
 	s.marker.SetActiveOrSilenced(fp, newVersion, activeIDs, pendingIDs)
+	s.marker.SetSilencedReceivers(fp, scopedIDs, uniqueSorted(receivers))
+
+	return active
+}
+
+// inScope returns whether the silence applies to the given receiver and
+// integration. A silence not scoped to receivers applies to all of them.
+func inScope(sil *pb.Silence, receiver, integration string) bool {
+	if len(sil.Receivers) == 0 {
+		return true
+	}
+	if !contains(sil.Receivers, receiver) {
+		return false
+	}
+	return len(sil.Integrations) == 0 || contains(sil.Integrations, integration)
+}
+
+// scopes returns the receivers, or receiver/integration pairs, the silence
+// is scoped to.
+func scopes(sil *pb.Silence) []string {
+	if len(sil.Integrations) == 0 {
+		return sil.Receivers
+	}
+	res := make([]string, 0, len(sil.Receivers)*len(sil.Integrations))
+	for _, r := range sil.Receivers {
+		for _, i := range sil.Integrations {
+			res = append(res, r+"/"+i)
+		}
+	}
+	return res
+}
 
-	return len(activeIDs) > 0
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueSorted(ss []string) []string {
+	if len(ss) == 0 {
+		return nil
+	}
+	sort.Strings(ss)
+	res := ss[:1]
+	for _, s := range ss[1:] {
+		if s != res[len(res)-1] {
+			res = append(res, s)
+		}
+	}
+	return res
 }
 
 // Silences holds a silence state that can be modified, queried, and snapshot.
@@ -191,15 +302,34 @@ type Silences struct {
 	rdb   redis.Cmdable
 	clock clock.Clock
 
-	logger  log.Logger
-	metrics *metrics
-	mtx     sync.RWMutex
-	mc      matcherCache
-	st      state
+	logger    log.Logger
+	metrics   *metrics
+	retention time.Duration
+	// resolveGracePeriod is the time for which the alerts matched by a
+	// silence expiring on resolve must stay resolved before it expires.
+	resolveGracePeriod time.Duration
+	// origin identifies this instance in the published silence updates.
+	origin string
+	// policy restricts the silences which take effect without approval.
+	policy Policy
+	// alerts returns the alerts currently known, if set.
+	alerts func() []*types.Alert
+
+	mtx sync.RWMutex
+	mc  matcherCache
+	rc  recurrenceCache
+	st  state
+	idx *silenceIndex
+	// loaded is true once st holds all silences stored in Redis.
+	loaded bool
+	// seen is the latest silence version in Redis reflected by st.
+	seen int64
+	// version is incremented whenever st changes.
+	version int64
 }
 
 // MaintenanceFunc represents the function to run as part of the periodic maintenance for silences.
-// It returns the size of the snapshot taken or an error if it failed.
+// It returns the number of silences garbage collected or an error if it failed.
 type MaintenanceFunc func() (int64, error)
 
 type metrics struct {
@@ -211,10 +341,12 @@ type metrics struct {
 	queryDuration           prometheus.Histogram
 	silencesActive          prometheus.GaugeFunc
 	silencesPending         prometheus.GaugeFunc
+	silencesPendingApproval prometheus.GaugeFunc
 	silencesExpired         prometheus.GaugeFunc
 	propagatedMessagesTotal prometheus.Counter
 	maintenanceTotal        prometheus.Counter
 	maintenanceErrorsTotal  prometheus.Counter
+	cacheLoadsTotal         prometheus.Counter
 }
 
 func newSilenceMetricByState(s *Silences, st types.SilenceState) prometheus.GaugeFunc {
@@ -275,9 +407,14 @@ func newMetrics(r prometheus.Registerer, s *Silences) *metrics {
 		Name: "alertmanager_silences_gossip_messages_propagated_total",
 		Help: "Number of received gossip messages that have been further gossiped.",
 	})
+	m.cacheLoadsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "alertmanager_silences_cache_loads_total",
+		Help: "How many times all silences were loaded from Redis into the cache.",
+	})
 	if s != nil {
 		m.silencesActive = newSilenceMetricByState(s, types.SilenceStateActive)
 		m.silencesPending = newSilenceMetricByState(s, types.SilenceStatePending)
+		m.silencesPendingApproval = newSilenceMetricByState(s, types.SilenceStatePendingApproval)
 		m.silencesExpired = newSilenceMetricByState(s, types.SilenceStateExpired)
 	}
 
@@ -291,10 +428,12 @@ func newMetrics(r prometheus.Registerer, s *Silences) *metrics {
 			m.queryDuration,
 			m.silencesActive,
 			m.silencesPending,
+			m.silencesPendingApproval,
 			m.silencesExpired,
 			m.propagatedMessagesTotal,
 			m.maintenanceTotal,
 			m.maintenanceErrorsTotal,
+			m.cacheLoadsTotal,
 		)
 	}
 	return m
@@ -305,6 +444,17 @@ func newMetrics(r prometheus.Registerer, s *Silences) *metrics {
 type Options struct {
 	OrgId int64
 	Rdb   redis.Cmdable
+	// Retention is the time for which expired silences and their history
+	// are kept before they are garbage collected.
+	Retention time.Duration
+	// ResolveGracePeriod is the time for which the alerts matched by a
+	// silence expiring on resolve must stay resolved before it expires.
+	ResolveGracePeriod time.Duration
+	// Policy restricts the silences which take effect without approval.
+	Policy Policy
+	// Alerts returns the alerts currently known. It is used to count the
+	// alerts matched by new silences for the policy.
+	Alerts func() []*types.Alert
 	// A logger used by background processing.
 	Logger  log.Logger
 	Metrics prometheus.Registerer
@@ -312,13 +462,25 @@ type Options struct {
 
 // New returns a new Silences object with the given configuration.
 func New(o Options) (*Silences, error) {
+	origin, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate origin")
+	}
 	s := &Silences{
-		clock:  clock.New(),
-		mc:     matcherCache{},
-		logger: log.NewNopLogger(),
-		st:     state{},
-		rdb:    o.Rdb,
-		orgId:  o.OrgId,
+		clock:     clock.New(),
+		mc:        matcherCache{},
+		rc:        recurrenceCache{},
+		logger:    log.NewNopLogger(),
+		st:        state{},
+		idx:       newSilenceIndex(),
+		rdb:       o.Rdb,
+		orgId:     o.OrgId,
+		retention: o.Retention,
+		origin:    origin.String(),
+
+		resolveGracePeriod: o.ResolveGracePeriod,
+		policy:             o.Policy,
+		alerts:             o.Alerts,
 	}
 	s.metrics = newMetrics(o.Metrics, s)
 
@@ -332,6 +494,97 @@ func (s *Silences) now() time.Time {
 	return s.clock.Now()
 }
 
+// Maintenance garbage collects the silences at the given interval until
+// stopc is closed. If override is set, it is run instead of the garbage
+// collection.
+func (s *Silences) Maintenance(interval time.Duration, stopc <-chan struct{}, override MaintenanceFunc) {
+	if interval == 0 || stopc == nil {
+		level.Error(s.logger).Log("msg", "interval or stop signal are missing - not running maintenance")
+		return
+	}
+	t := s.clock.Ticker(interval)
+	defer t.Stop()
+
+	doMaintenance := func() (int64, error) {
+		n, err := s.GC()
+		return int64(n), err
+	}
+	if override != nil {
+		doMaintenance = override
+	}
+
+	runMaintenance := func(do MaintenanceFunc) error {
+		s.metrics.maintenanceTotal.Inc()
+		level.Debug(s.logger).Log("msg", "Running maintenance")
+		start := s.now()
+		n, err := do()
+		if err != nil {
+			s.metrics.maintenanceErrorsTotal.Inc()
+			return err
+		}
+		level.Debug(s.logger).Log("msg", "Maintenance done", "duration", s.now().Sub(start), "removed", n)
+		return nil
+	}
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			if err := runMaintenance(doMaintenance); err != nil {
+				level.Info(s.logger).Log("msg", "Running maintenance failed", "err", err)
+			}
+		}
+	}
+}
+
+// GC removes the silences which expired longer than the retention ago,
+// together with their history and matched alerts. It returns the number of
+// removed silences.
+func (s *Silences) GC() (int, error) {
+	start := time.Now()
+	defer func() { s.metrics.gcDuration.Observe(time.Since(start).Seconds()) }()
+
+	ctx := context.Background()
+	now := s.now()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	allSilJson, err := s.rdb.HGetAll(ctx, s.orgSilenceIdx()).Result()
+	if err != nil {
+		return 0, errors.Wrap(err, "get all silence for redis")
+	}
+	var n int
+	for uid, silJson := range allSilJson {
+		sil, err := unmarshalSilence(silJson)
+		if err != nil {
+			level.Error(s.logger).Log("msg", "unmarshal silence failed", "uid", uid, "err", err)
+			continue
+		}
+		if !sil.EndsAt.Add(s.retention).Before(now) {
+			continue
+		}
+		if err := s.rdb.HDel(ctx, s.orgSilenceIdx(), uid).Err(); err != nil {
+			return n, errors.Wrap(err, "del org silence idx for redis failed")
+		}
+		if err := s.rdb.Del(ctx, s.historyIdx(uid), s.matchedIdx(uid)).Err(); err != nil {
+			return n, errors.Wrap(err, "del silence history for redis failed")
+		}
+		version, err := s.rdb.Incr(ctx, s.versionIdx()).Result()
+		if err != nil {
+			return n, errors.Wrap(err, "incr silence version for redis failed")
+		}
+		if s.loaded {
+			s.uncache(uid)
+			s.observeVersion(version)
+		}
+		s.publish(ctx, uid, version)
+		n++
+	}
+	return n, nil
+}
+
 // ValidateMatcher runs validation on the matcher name, type, and pattern.
 var ValidateMatcher = func(m *pb.Matcher) error {
 	if !model.LabelName(m.Name).IsValid() {
@@ -393,11 +646,20 @@ func validateSilence(s *pb.Silence) error {
 	if s.UpdatedAt.IsZero() {
 		return errors.New("invalid zero update timestamp")
 	}
+	if err := validateRecurrence(s); err != nil {
+		return fmt.Errorf("invalid recurrence: %s", err)
+	}
+	if len(s.Integrations) > 0 && len(s.Receivers) == 0 {
+		return errors.New("integrations require at least one receiver")
+	}
 	return nil
 }
 
 func (s *Silences) getSilence(ctx context.Context, id string) (*pb.Silence, bool) {
 	silJson, err := s.rdb.HGet(ctx, s.orgSilenceIdx(), id).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, false
+	}
 	if err != nil {
 		level.Error(s.logger).Log("msg", "get silence from redis failed", "uid", id, "err", err)
 		return nil, false
@@ -413,29 +675,140 @@ func (s *Silences) getSilence(ctx context.Context, id string) (*pb.Silence, bool
 	return sli, true
 }
 
-func (s *Silences) setSilence(ctx context.Context, sil *pb.Silence, now time.Time) error {
-	sil.UpdatedAt = now
+// setSilence stores the silence. If prev is not nil, it is the version of
+// the silence being replaced and is appended to the history of the silence.
+func (s *Silences) setSilence(ctx context.Context, prev, sil *pb.Silence, now time.Time) error {
+	return s.setSilences(ctx, []silenceWrite{{prev: prev, sil: sil}}, now)
+}
+
+// silenceWrite is a change to a silence. If prev is not nil, it is the
+// version of the silence being replaced.
+type silenceWrite struct {
+	prev, sil *pb.Silence
+}
 
-	if err := validateSilence(sil); err != nil {
-		return errors.Wrap(err, "silence invalid")
+// setSilences validates and stores the silences. Either all or none of them
+// are stored.
+func (s *Silences) setSilences(ctx context.Context, ws []silenceWrite, now time.Time) error {
+	if err := prepareWrites(ws, now); err != nil {
+		return err
 	}
-	silJson, err := marshalSilence(sil)
-	if err != nil {
-		return errors.Wrap(err, "marshal silence failed")
+	return s.storeSilences(ctx, ws)
+}
+
+// prepareWrites sets the update time of the silences and validates them.
+func prepareWrites(ws []silenceWrite, now time.Time) error {
+	for _, w := range ws {
+		w.sil.UpdatedAt = now
+
+		if err := validateSilence(w.sil); err != nil {
+			return errors.Wrap(err, "silence invalid")
+		}
 	}
-	err = s.rdb.HSet(ctx, s.orgSilenceIdx(), sil.Id, silJson).Err()
+	return nil
+}
+
+// storeSilence writes the silence to Redis and the cache. If prev is not nil,
+// it is appended to the history of the silence.
+func (s *Silences) storeSilence(ctx context.Context, prev, sil *pb.Silence) error {
+	return s.storeSilences(ctx, []silenceWrite{{prev: prev, sil: sil}})
+}
+
+// storeSilences writes the silences to Redis in a single transaction and
+// then to the cache.
+func (s *Silences) storeSilences(ctx context.Context, ws []silenceWrite) error {
+	versions, err := s.writeSilences(ctx, s.rdb, ws)
 	if err != nil {
-		return errors.Wrap(err, "silence set redis failed")
+		return err
+	}
+	s.applyWrites(ctx, ws, versions)
+	return nil
+}
+
+// writeSilences writes the silences to Redis in a single transaction. rdb is
+// either the client or a transaction watching keys read before. It returns
+// the version of each change.
+func (s *Silences) writeSilences(ctx context.Context, rdb redis.Cmdable, ws []silenceWrite) ([]int64, error) {
+	if len(ws) == 0 {
+		return nil, nil
+	}
+	silJsons := make([][]byte, len(ws))
+	prevJsons := make([][]byte, len(ws))
+	for i, w := range ws {
+		var err error
+		if silJsons[i], err = marshalSilence(w.sil); err != nil {
+			return nil, errors.Wrap(err, "marshal silence failed")
+		}
+		if w.prev != nil {
+			if prevJsons[i], err = marshalSilence(w.prev); err != nil {
+				return nil, errors.Wrap(err, "marshal previous silence failed")
+			}
+		}
 	}
-	err = s.rdb.Incr(context.Background(), s.versionIdx()).Err()
+	incrs := make([]*redis.IntCmd, len(ws))
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, w := range ws {
+			if w.prev != nil {
+				pipe.RPush(ctx, s.historyIdx(w.sil.Id), prevJsons[i])
+			}
+			pipe.HSet(ctx, s.orgSilenceIdx(), w.sil.Id, silJsons[i])
+			// Every change gets its own version, so that other instances
+			// notice missed updates.
+			incrs[i] = pipe.Incr(ctx, s.versionIdx())
+		}
+		return nil
+	})
 	if err != nil {
-		return errors.Wrap(err, "set silence version redis failed")
+		return nil, errors.Wrap(err, "silence set redis failed")
 	}
-	return nil
+	versions := make([]int64, len(incrs))
+	for i, incr := range incrs {
+		versions[i] = incr.Val()
+	}
+	return versions, nil
+}
+
+// applyWrites updates the cache with the silences written to Redis and
+// notifies the other instances.
+func (s *Silences) applyWrites(ctx context.Context, ws []silenceWrite, versions []int64) {
+	for i, w := range ws {
+		if s.loaded {
+			s.cache(cloneSilence(w.sil))
+			s.observeVersion(versions[i])
+		}
+		s.publish(ctx, w.sil.Id, versions[i])
+	}
+}
+
+// watcher is implemented by Redis clients supporting optimistic locking.
+type watcher interface {
+	Watch(ctx context.Context, fn func(*redis.Tx) error, keys ...string) error
+}
+
+// maxWatchRetries is the number of attempts of a transaction whose watched
+// keys were modified concurrently.
+const maxWatchRetries = 10
+
+// watch runs fn in a transaction which fails if one of the keys is modified
+// before fn executes its pipeline. It is retried in that case.
+func (s *Silences) watch(ctx context.Context, fn func(*redis.Tx) error, keys ...string) error {
+	w, ok := s.rdb.(watcher)
+	if !ok {
+		return errors.New("Redis client doesn't support transactions")
+	}
+	var err error
+	for i := 0; i < maxWatchRetries; i++ {
+		err = w.Watch(ctx, fn, keys...)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return errors.Wrap(err, "watched keys modified concurrently")
 }
 
 // Set the specified silence. If a silence with the ID already exists and the modification
-// modifies history, the old silence gets expired and a new one is created.
+// modifies history, the old silence gets expired and a new one is created. Otherwise, the
+// silence is updated in place and the previous version is kept in its history.
 func (s *Silences) Set(ctx context.Context, sil *pb.Silence) (string, error) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
@@ -446,13 +819,27 @@ func (s *Silences) Set(ctx context.Context, sil *pb.Silence) (string, error) {
 	if sil.Id != "" && !ok {
 		return "", ErrNotFound
 	}
-	if ok {
-		if canUpdate(prev, sil, now) {
-			if err := s.expire(ctx, []string{sil.Id}); err != nil {
-				return sil.Id, errors.Wrap(err, "expire silence")
-			}
+	// Any change to a silence violating the policy requires an approval.
+	reasons, err := s.violations(ctx, sil, now)
+	if err != nil {
+		return "", errors.Wrap(err, "check silence policy")
+	}
+	sil.Approval = nil
+	if len(reasons) > 0 {
+		sil.Approval = &pb.Approval{Reasons: reasons}
+	}
+	if ok && canUpdate(prev, sil, now) {
+		if sil.ExpireOnResolve {
+			// Keep tracking the matched alerts.
+			sil.ResolveState = prev.ResolveState
+		}
+		return sil.Id, s.setSilence(ctx, prev, sil, now)
+	}
+	if ok && getState(prev, now) != types.SilenceStateExpired {
+		// We cannot update the silence, expire it to preserve history.
+		if err := s.expire(ctx, []string{prev.Id}); err != nil {
+			return "", errors.Wrap(err, "expire previous silence")
 		}
-		return sil.Id, s.setSilence(ctx, sil, now)
 	}
 	// If we got here it's either a new silence or a replacing one.
 	uid, err := uuid.NewV4()
@@ -460,23 +847,42 @@ func (s *Silences) Set(ctx context.Context, sil *pb.Silence) (string, error) {
 		return "", errors.Wrap(err, "generate uuid")
 	}
 	sil.Id = uid.String()
+	sil.ResolveState = nil
 
-	return sil.Id, s.setSilence(ctx, sil, now)
+	return sil.Id, s.setSilence(ctx, nil, sil, now)
 }
 
 // canUpdate returns true if silence a can be updated to b without
 // affecting the historic view of silencing.
 func canUpdate(a, b *pb.Silence, now time.Time) bool {
 	if !reflect.DeepEqual(a.Matchers, b.Matchers) {
-		return true
+		return false
 	}
-	if b.StartsAt.Unix() != a.StartsAt.Unix() {
-		return true
+	if !reflect.DeepEqual(a.Recurrence, b.Recurrence) {
+		return false
 	}
-	if b.EndsAt.After(now) {
-		return true
+	if !reflect.DeepEqual(a.Receivers, b.Receivers) || !reflect.DeepEqual(a.Integrations, b.Integrations) {
+		return false
 	}
-	return false
+	// Allowed timestamp modifications depend on the current time.
+	switch st := getState(a, now); st {
+	case types.SilenceStateActive:
+		if b.StartsAt.Unix() != a.StartsAt.Unix() {
+			return false
+		}
+		if b.EndsAt.Before(now) {
+			return false
+		}
+	case types.SilenceStatePending:
+		if b.StartsAt.Before(now) {
+			return false
+		}
+	case types.SilenceStateExpired:
+		return false
+	default:
+		panic("unknown silence state")
+	}
+	return true
 }
 
 // Expire the silence with the given ID immediately.
@@ -490,22 +896,73 @@ func (s *Silences) Expire(ctx context.Context, id string) error {
 // It is idempotent, nil is returned if the silence already expired before it is GC'd.
 // If the silence is not found an error is returned.
 func (s *Silences) expire(ctx context.Context, ids []string) error {
-	if err := s.rdb.HDel(ctx, s.orgSilenceIdx(), ids...).Err(); err != nil {
-		return errors.Wrap(err, "del org silence idx for redis failed")
-	}
+	now := s.now()
 	for _, id := range ids {
-		delete(s.mc, id)
-		delete(s.st, id)
+		prev, ok := s.getSilence(ctx, id)
+		if !ok {
+			return ErrNotFound
+		}
+		sil, ok := expiredSilence(prev, now)
+		if !ok {
+			continue
+		}
+		if err := s.setSilence(ctx, prev, sil, now); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// expiredSilence returns a copy of the silence which expires at the given
+// time. It returns false if the silence already expired.
+func expiredSilence(prev *pb.Silence, now time.Time) (*pb.Silence, bool) {
+	sil := cloneSilence(prev)
+	switch getState(sil, now) {
+	case types.SilenceStateExpired:
+		return nil, false
+	case types.SilenceStateActive:
+		sil.EndsAt = now
+	case types.SilenceStatePending:
+		// Set both to now to make the silence move to the expired state.
+		sil.StartsAt = now
+		sil.EndsAt = now
+	}
+	return sil, true
+}
+
+// History returns the prior versions of the silence with the given ID,
+// starting with the oldest one.
+func (s *Silences) History(ctx context.Context, id string) ([]*pb.Silence, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if _, ok := s.getSilence(ctx, id); !ok {
+		return nil, ErrNotFound
+	}
+	versions, err := s.rdb.LRange(ctx, s.historyIdx(id), 0, -1).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "get silence history for redis")
+	}
+	res := make([]*pb.Silence, 0, len(versions))
+	for _, v := range versions {
+		sil, err := unmarshalSilence(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal silence failed")
+		}
+		res = append(res, sil)
+	}
+	return res, nil
+}
+
 // QueryParam expresses parameters along which silences are queried.
 type QueryParam func(*query) error
 
 type query struct {
 	ids     []string
 	filters []silenceFilter
+	// lset is the label set the silences must match, it's used to narrow
+	// down the silences to check using the index.
+	lset model.LabelSet
 }
 
 // silenceFilter is a function that returns true if a silence
@@ -523,6 +980,7 @@ func QIDs(ids ...string) QueryParam {
 // QMatches returns silences that match the given label set.
 func QMatches(set model.LabelSet) QueryParam {
 	return func(q *query) error {
+		q.lset = set
 		f := func(sil *pb.Silence, s *Silences, _ time.Time) (bool, error) {
 			m, err := s.mc.Get(sil)
 			if err != nil {
@@ -546,11 +1004,21 @@ func getState(sil *pb.Silence, ts time.Time) types.SilenceState {
 	return types.SilenceStateActive
 }
 
+// silenceState returns a silence's SilenceState at the given timestamp,
+// taking into account whether it is pending approval.
+func silenceState(sil *pb.Silence, ts time.Time) types.SilenceState {
+	st := getState(sil, ts)
+	if st != types.SilenceStateExpired && sil.Approval.Pending() {
+		return types.SilenceStatePendingApproval
+	}
+	return st
+}
+
 // QState filters queried silences by the given states.
 func QState(states ...types.SilenceState) QueryParam {
 	return func(q *query) error {
 		f := func(sil *pb.Silence, _ *Silences, now time.Time) (bool, error) {
-			s := getState(sil, now)
+			s := silenceState(sil, now)
 			for _, ps := range states {
 				if s == ps {
 					return true, nil
@@ -597,7 +1065,12 @@ func (s *Silences) Query(params ...QueryParam) ([]*pb.Silence, int64, error) {
 }
 
 func (s *Silences) QueryAll(ctx context.Context) ([]*pb.Silence, error) {
-	allSilJson, err := s.rdb.HGetAll(ctx, s.orgSilenceIdx()).Result()
+	return s.loadAll(ctx, s.rdb)
+}
+
+// loadAll reads all silences of the org from rdb.
+func (s *Silences) loadAll(ctx context.Context, rdb redis.Cmdable) ([]*pb.Silence, error) {
+	allSilJson, err := rdb.HGetAll(ctx, s.orgSilenceIdx()).Result()
 	if err != nil {
 		return nil, errors.Wrap(err, "get all silence for redis")
 	}
@@ -627,33 +1100,29 @@ func (s *Silences) query(q *query, now time.Time) ([]*pb.Silence, int64, error)
 	// If we have no ID constraint, all silences are our base set.  This and
 	// the use of post-filter functions is the trivial solution for now.
 	var res []*pb.Silence
-	ctx := context.Background()
+	// The matcher cache is written by the filters, so the write lock is
+	// needed.
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
-	version := s.Version()
-	if q.ids != nil {
+	if err := s.ensureLoaded(context.Background()); err != nil {
+		return nil, s.version, err
+	}
+	version := s.version
+	switch {
+	case q.ids != nil:
 		for _, id := range q.ids {
 			if sil, ok := s.st[id]; ok {
 				res = append(res, sil)
 			}
 		}
-	} else {
-		allSilJson, err := s.rdb.HGetAll(ctx, s.orgSilenceIdx()).Result()
-		if err != nil {
-			return nil, version, errors.Wrap(err, "get all silence for redis")
+	case q.lset != nil:
+		for _, id := range s.idx.candidates(q.lset) {
+			res = append(res, s.st[id])
 		}
-		for uid, silJson := range allSilJson {
-			sil, err := unmarshalSilence(silJson)
-			if err != nil {
-				level.Error(s.logger).Log("msg", "unmarshal silence failed", "uid", uid, "err", err)
-				continue
-			}
-			if getState(sil, now) != types.SilenceStateExpired {
-				s.st[uid] = sil
-				res = append(res, sil)
-			}
+	default:
+		for _, sil := range s.st {
+			res = append(res, sil)
 		}
-
 	}
 
 	var resf []*pb.Silence
@@ -681,16 +1150,35 @@ func (s *Silences) orgSilenceIdx() string {
 	return fmt.Sprintf(orgSilenceIdx, s.orgId)
 }
 
+func (s *Silences) historyIdx(id string) string {
+	return fmt.Sprintf(orgSilenceHistory, s.orgId, id)
+}
+
+// OrgId returns the identifier of the org the silences belong to.
+func (s *Silences) OrgId() int64 {
+	return s.orgId
+}
+
 func (s *Silences) versionIdx() string {
 	return fmt.Sprintf(orgSilenceVersion, s.orgId)
 }
 
+// Version returns the version of the silences cache. It changes whenever
+// a silence is modified by this or another instance.
 func (s *Silences) Version() int64 {
-	version, err := s.rdb.Get(context.Background(), s.versionIdx()).Int64()
-	if err != nil {
-		level.Error(s.logger).Log("msg", "Get silences version failed", "org", s.versionIdx(), "err", err)
+	s.mtx.RLock()
+	if s.loaded {
+		defer s.mtx.RUnlock()
+		return s.version
+	}
+	s.mtx.RUnlock()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if err := s.ensureLoaded(context.Background()); err != nil {
+		level.Error(s.logger).Log("msg", "Loading silences failed", "org", s.orgId, "err", err)
 	}
-	return version
+	return s.version
 }
 
 // cloneSilence returns a shallow copy of a silence.
@@ -0,0 +1,264 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/test/redistest"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// newTestSilences returns silences stored in the in-memory Redis server and
+// driven by a mock clock.
+func newTestSilences(t *testing.T, srv *redistest.Server, o Options) (*Silences, *clock.Mock) {
+	t.Helper()
+
+	if o.OrgId == 0 {
+		o.OrgId = 1
+	}
+	if o.Rdb == nil {
+		o.Rdb = srv.Client(t)
+	}
+	s, err := New(o)
+	require.NoError(t, err)
+
+	mc := clock.NewMock()
+	mc.Set(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	s.clock = mc
+	return s, mc
+}
+
+func newTestSilence(now time.Time, d time.Duration) *pb.Silence {
+	return &pb.Silence{
+		Matchers:  []*pb.Matcher{{Name: "job", Pattern: "test", Type: pb.Matcher_EQUAL}},
+		StartsAt:  now,
+		EndsAt:    now.Add(d),
+		CreatedBy: "alice",
+		Comment:   "maintenance",
+	}
+}
+
+func TestCanUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	matchers := []*pb.Matcher{{Name: "job", Pattern: "test", Type: pb.Matcher_EQUAL}}
+
+	active := &pb.Silence{Matchers: matchers, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+	pending := &pb.Silence{Matchers: matchers, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)}
+	expired := &pb.Silence{Matchers: matchers, StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)}
+
+	for _, tc := range []struct {
+		name   string
+		a, b   *pb.Silence
+		update bool
+	}{
+		{
+			name:   "active, comment changed",
+			a:      active,
+			b:      &pb.Silence{Matchers: matchers, StartsAt: active.StartsAt, EndsAt: active.EndsAt, Comment: "changed"},
+			update: true,
+		},
+		{
+			name:   "active, end extended",
+			a:      active,
+			b:      &pb.Silence{Matchers: matchers, StartsAt: active.StartsAt, EndsAt: now.Add(3 * time.Hour)},
+			update: true,
+		},
+		{
+			name: "active, end moved to the past",
+			a:    active,
+			b:    &pb.Silence{Matchers: matchers, StartsAt: active.StartsAt, EndsAt: now.Add(-time.Minute)},
+		},
+		{
+			name: "active, start changed",
+			a:    active,
+			b:    &pb.Silence{Matchers: matchers, StartsAt: now.Add(-2 * time.Hour), EndsAt: active.EndsAt},
+		},
+		{
+			name: "active, matchers changed",
+			a:    active,
+			b: &pb.Silence{
+				Matchers: []*pb.Matcher{{Name: "job", Pattern: "other", Type: pb.Matcher_EQUAL}},
+				StartsAt: active.StartsAt,
+				EndsAt:   active.EndsAt,
+			},
+		},
+		{
+			name: "active, receivers changed",
+			a:    active,
+			b:    &pb.Silence{Matchers: matchers, StartsAt: active.StartsAt, EndsAt: active.EndsAt, Receivers: []string{"team-a"}},
+		},
+		{
+			name:   "pending, start moved",
+			a:      pending,
+			b:      &pb.Silence{Matchers: matchers, StartsAt: now.Add(30 * time.Minute), EndsAt: pending.EndsAt},
+			update: true,
+		},
+		{
+			name: "pending, start moved to the past",
+			a:    pending,
+			b:    &pb.Silence{Matchers: matchers, StartsAt: now.Add(-time.Minute), EndsAt: pending.EndsAt},
+		},
+		{
+			name: "expired",
+			a:    expired,
+			b:    &pb.Silence{Matchers: matchers, StartsAt: expired.StartsAt, EndsAt: expired.EndsAt, Comment: "changed"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.update, canUpdate(tc.a, tc.b, now))
+		})
+	}
+}
+
+func TestSilencesSetKeepsHistory(t *testing.T) {
+	s, mc := newTestSilences(t, redistest.Run(t), Options{})
+	ctx := context.Background()
+	now := mc.Now()
+
+	sil := newTestSilence(now, time.Hour)
+	id, err := s.Set(ctx, sil)
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	history, err := s.History(ctx, id)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	// Changing the comment updates the silence in place and keeps the
+	// previous version.
+	mc.Add(time.Minute)
+	upd := cloneSilence(sil)
+	upd.Comment = "extended maintenance"
+	upd.EndsAt = now.Add(2 * time.Hour)
+	updID, err := s.Set(ctx, upd)
+	require.NoError(t, err)
+	require.Equal(t, id, updID)
+
+	history, err = s.History(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "maintenance", history[0].Comment)
+	require.Equal(t, now.Add(time.Hour), history[0].EndsAt)
+
+	// Changing the matchers expires the silence and creates a new one.
+	mc.Add(time.Minute)
+	repl := cloneSilence(upd)
+	repl.Matchers = []*pb.Matcher{{Name: "job", Pattern: "other", Type: pb.Matcher_EQUAL}}
+	replID, err := s.Set(ctx, repl)
+	require.NoError(t, err)
+	require.NotEqual(t, id, replID)
+
+	old, err := s.QueryOne(QIDs(id))
+	require.NoError(t, err)
+	require.Equal(t, mc.Now(), old.EndsAt)
+	require.Equal(t, types.SilenceStateExpired, getState(old, mc.Now().Add(time.Second)))
+
+	history, err = s.History(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "extended maintenance", history[1].Comment)
+
+	history, err = s.History(ctx, replID)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	_, err = s.History(ctx, "unknown")
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestSilencesExpireKeepsRecord(t *testing.T) {
+	s, mc := newTestSilences(t, redistest.Run(t), Options{})
+	ctx := context.Background()
+	now := mc.Now()
+
+	active, err := s.Set(ctx, newTestSilence(now.Add(-time.Minute), time.Hour))
+	require.NoError(t, err)
+	pending, err := s.Set(ctx, newTestSilence(now.Add(time.Hour), time.Hour))
+	require.NoError(t, err)
+
+	mc.Add(time.Minute)
+	require.NoError(t, s.Expire(ctx, active))
+	require.NoError(t, s.Expire(ctx, pending))
+
+	for _, id := range []string{active, pending} {
+		sil, err := s.QueryOne(QIDs(id))
+		require.NoError(t, err, id)
+		require.Equal(t, mc.Now(), sil.EndsAt)
+
+		history, err := s.History(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.NotEqual(t, mc.Now(), history[0].EndsAt)
+	}
+	pendingSil, err := s.QueryOne(QIDs(pending))
+	require.NoError(t, err)
+	require.Equal(t, mc.Now(), pendingSil.StartsAt)
+
+	// Expiring an expired silence is a no-op.
+	mc.Add(time.Minute)
+	require.NoError(t, s.Expire(ctx, active))
+	history, err := s.History(ctx, active)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	require.Equal(t, ErrNotFound, s.Expire(ctx, "unknown"))
+}
+
+func TestSilencesGC(t *testing.T) {
+	srv := redistest.Run(t)
+	s, mc := newTestSilences(t, srv, Options{Retention: time.Hour})
+	ctx := context.Background()
+	now := mc.Now()
+
+	expired, err := s.Set(ctx, newTestSilence(now, time.Minute))
+	require.NoError(t, err)
+	active, err := s.Set(ctx, newTestSilence(now, 3*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, s.Expire(ctx, expired))
+	require.True(t, srv.Exists(s.historyIdx(expired)))
+
+	// Expired silences are kept for the retention.
+	mc.Add(59 * time.Minute)
+	n, err := s.GC()
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+	_, err = s.QueryOne(QIDs(expired))
+	require.NoError(t, err)
+
+	mc.Add(2 * time.Minute)
+	n, err = s.GC()
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	_, err = s.QueryOne(QIDs(expired))
+	require.Equal(t, ErrNotFound, err)
+	_, err = s.History(ctx, expired)
+	require.Equal(t, ErrNotFound, err)
+	require.False(t, srv.Exists(s.historyIdx(expired)))
+
+	_, err = s.QueryOne(QIDs(active))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		fmt.Sprintf(orgSilenceIdx, 1),
+		fmt.Sprintf(orgSilenceVersion, 1),
+	}, srv.Keys())
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silencepb
+
+import "time"
+
+// Approval records why a silence violated the silence policy and who
+// approved it.
+type Approval struct {
+	// Reasons are the policy violations which require the approval.
+	Reasons []string `json:"reasons"`
+	// ApprovedBy is the user who approved the silence. It is empty while
+	// the approval is pending.
+	ApprovedBy string `json:"approved_by,omitempty"`
+	// ApprovedAt is the time the silence was approved.
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+// Pending returns true if the approval has not been given yet. A nil
+// approval is never pending.
+func (a *Approval) Pending() bool {
+	return a != nil && a.ApprovedBy == ""
+}
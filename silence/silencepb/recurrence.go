@@ -0,0 +1,32 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silencepb
+
+import (
+	"time"
+
+	"github.com/prometheus/alertmanager/timeinterval"
+)
+
+// Recurrence restricts a silence to recurring windows between its start and
+// end time. The windows are given either by time intervals or by an RFC 5545
+// recurrence rule whose occurrences last Duration.
+type Recurrence struct {
+	TimeIntervals []timeinterval.TimeInterval `json:"time_intervals,omitempty"`
+	RRule         string                      `json:"rrule,omitempty"`
+	Duration      time.Duration               `json:"duration,omitempty"`
+	// Location is the time zone the recurrence rule is evaluated in.
+	// It defaults to UTC.
+	Location string `json:"location,omitempty"`
+}
@@ -0,0 +1,26 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silencepb
+
+import "time"
+
+// ResolveState tracks the alerts matched by a silence which expires when
+// they resolve.
+type ResolveState struct {
+	// MatchedAt is the time a firing alert first matched the silence.
+	MatchedAt time.Time `json:"matched_at"`
+	// ResolvedAt is the time since which all alerts matched by the silence
+	// are resolved. It is zero while a matched alert is firing.
+	ResolvedAt time.Time `json:"resolved_at"`
+}
@@ -165,12 +165,18 @@ type Silence struct {
 	// DEPRECATED: A set of comments made on the silence.
 	Comments []*Comment `protobuf:"bytes,7,rep,name=comments,proto3" json:"comments,omitempty"`
 	// Comment for the silence.
-	CreatedBy            string   `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	Comment              string   `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
-	Name                 string   `json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CreatedBy            string        `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Comment              string        `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
+	Name                 string        `json:"name,omitempty"`
+	Recurrence           *Recurrence   `json:"recurrence,omitempty"`
+	ExpireOnResolve      bool          `json:"expire_on_resolve,omitempty"`
+	ResolveState         *ResolveState `json:"resolve_state,omitempty"`
+	Approval             *Approval     `json:"approval,omitempty"`
+	Receivers            []string      `json:"receivers,omitempty"`
+	Integrations         []string      `json:"integrations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *Silence) Reset()         { *m = Silence{} }
@@ -303,6 +303,12 @@ type Data struct {
 	CommonAnnotations KV `json:"commonAnnotations"`
 
 	ExternalURL string `json:"externalURL"`
+
+	// The changes of the group since its previous notification. They are
+	// only set for routes with notify_mode delta.
+	NewFiring     Alerts `json:"newFiring,omitempty"`
+	StillFiring   Alerts `json:"stillFiring,omitempty"`
+	NewlyResolved Alerts `json:"newlyResolved,omitempty"`
 }
 
 // Alert holds one alert for notification templates.
@@ -316,6 +322,7 @@ type Alert struct {
 	GeneratorURL string    `json:"generatorURL"`
 	Fingerprint  string    `json:"fingerprint"`
 	SentCount    int64     `json:"sentCount"`
+	Flapping     bool      `json:"flapping"`
 }
 
 // Alerts is a list of Alert objects.
@@ -355,28 +362,7 @@ func (t *Template) Data(recv string, groupLabels model.LabelSet, alerts ...*type
 		ExternalURL:       t.ExternalURL.String(),
 	}
 
-	// The call to types.Alert is necessary to correctly resolve the internal
-	// representation to the user representation.
-	for _, a := range types.Alerts(alerts...) {
-		alert := Alert{
-			Status:       string(a.Status()),
-			Labels:       make(KV, len(a.Labels)),
-			Annotations:  make(KV, len(a.Annotations)),
-			TriggerAt:    a.TriggerAt,
-			StartsAt:     a.StartsAt,
-			EndsAt:       a.EndsAt,
-			GeneratorURL: a.GeneratorURL,
-			Fingerprint:  a.Fingerprint().String(),
-			SentCount:    a.SentCount,
-		}
-		for k, v := range a.Labels {
-			alert.Labels[string(k)] = string(v)
-		}
-		for k, v := range a.Annotations {
-			alert.Annotations[string(k)] = string(v)
-		}
-		data.Alerts = append(data.Alerts, alert)
-	}
+	data.Alerts = append(data.Alerts, templateAlerts(alerts)...)
 
 	for k, v := range groupLabels {
 		data.GroupLabels[string(k)] = string(v)
@@ -412,3 +398,39 @@ func (t *Template) Data(recv string, groupLabels model.LabelSet, alerts ...*type
 
 	return data
 }
+
+// SetDelta sets the changes of the group since its previous notification.
+func (d *Data) SetDelta(newFiring, stillFiring, newlyResolved []*types.Alert) {
+	d.NewFiring = templateAlerts(newFiring)
+	d.StillFiring = templateAlerts(stillFiring)
+	d.NewlyResolved = templateAlerts(newlyResolved)
+}
+
+// templateAlerts converts the alerts for notification templates.
+func templateAlerts(alerts []*types.Alert) Alerts {
+	res := make(Alerts, 0, len(alerts))
+	// The call to types.Alert is necessary to correctly resolve the internal
+	// representation to the user representation.
+	for i, a := range types.Alerts(alerts...) {
+		alert := Alert{
+			Status:       string(a.Status()),
+			Labels:       make(KV, len(a.Labels)),
+			Annotations:  make(KV, len(a.Annotations)),
+			TriggerAt:    a.TriggerAt,
+			StartsAt:     a.StartsAt,
+			EndsAt:       a.EndsAt,
+			GeneratorURL: a.GeneratorURL,
+			Fingerprint:  a.Fingerprint().String(),
+			SentCount:    a.SentCount,
+			Flapping:     alerts[i].Flapping,
+		}
+		for k, v := range a.Labels {
+			alert.Labels[string(k)] = string(v)
+		}
+		for k, v := range a.Annotations {
+			alert.Annotations[string(k)] = string(v)
+		}
+		res = append(res, alert)
+	}
+	return res
+}
@@ -286,6 +286,55 @@ func TestData(t *testing.T) {
 	}
 }
 
+func TestDataSetDelta(t *testing.T) {
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://example.com/")
+
+	alert := func(name string, resolved bool) *types.Alert {
+		a := &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": model.LabelValue(name)},
+				StartsAt: time.Time{}.Add(time.Second),
+			},
+		}
+		if resolved {
+			a.EndsAt = time.Time{}.Add(2 * time.Second)
+		}
+		return a
+	}
+	names := func(as Alerts) []string {
+		var res []string
+		for _, a := range as {
+			res = append(res, a.Labels["alertname"])
+		}
+		return res
+	}
+	var (
+		newFiring     = []*types.Alert{alert("a", false), alert("b", false), alert("c", false)}
+		stillFiring   = []*types.Alert{alert("d", false)}
+		newlyResolved = []*types.Alert{alert("e", true), alert("f", true)}
+	)
+
+	data := tmpl.Data("webhook", model.LabelSet{}, append(newFiring, newlyResolved...)...)
+	require.Empty(t, data.NewFiring)
+	require.Empty(t, data.StillFiring)
+	require.Empty(t, data.NewlyResolved)
+
+	data.SetDelta(newFiring, stillFiring, newlyResolved)
+	require.Equal(t, []string{"a", "b", "c"}, names(data.NewFiring))
+	require.Equal(t, []string{"d"}, names(data.StillFiring))
+	require.Equal(t, []string{"e", "f"}, names(data.NewlyResolved))
+	require.Equal(t, "resolved", data.NewlyResolved[0].Status)
+
+	got, err := tmpl.ExecuteTextString(
+		`{{ len .NewFiring }} new, {{ len .NewlyResolved }} resolved, {{ len .StillFiring }} ongoing`,
+		data,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "3 new, 2 resolved, 1 ongoing", got)
+}
+
 func TestTemplateExpansion(t *testing.T) {
 	tmpl, err := FromGlobs([]string{})
 	require.NoError(t, err)
@@ -0,0 +1,565 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redistest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// command is a Redis command executed with the server lock held.
+type command struct {
+	fn func(s *Server, args []string) interface{}
+	// minArgs and maxArgs bound the number of arguments. A negative
+	// maxArgs means unbounded.
+	minArgs, maxArgs int
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"ping":      {cmdPing, 0, 1},
+		"hello":     {cmdHello, 0, -1},
+		"get":       {cmdGet, 1, 1},
+		"set":       {cmdSet, 2, -1},
+		"setnx":     {cmdSetNX, 2, 2},
+		"mget":      {cmdMGet, 1, -1},
+		"incr":      {cmdIncr, 1, 1},
+		"decr":      {cmdDecr, 1, 1},
+		"del":       {cmdDel, 1, -1},
+		"exists":    {cmdExists, 1, -1},
+		"keys":      {cmdKeys, 1, 1},
+		"expire":    {cmdExpire, 2, 3},
+		"pexpire":   {cmdPExpire, 2, 3},
+		"ttl":       {cmdTTL, 1, 1},
+		"pttl":      {cmdPTTL, 1, 1},
+		"hset":      {cmdHSet, 3, -1},
+		"hget":      {cmdHGet, 2, 2},
+		"hgetall":   {cmdHGetAll, 1, 1},
+		"hdel":      {cmdHDel, 2, -1},
+		"hlen":      {cmdHLen, 1, 1},
+		"sadd":      {cmdSAdd, 2, -1},
+		"srem":      {cmdSRem, 2, -1},
+		"smembers":  {cmdSMembers, 1, 1},
+		"sismember": {cmdSIsMember, 2, 2},
+		"scard":     {cmdSCard, 1, 1},
+		"rpush":     {cmdRPush, 2, -1},
+		"lrange":    {cmdLRange, 3, 3},
+		"llen":      {cmdLLen, 1, 1},
+		"publish":   {cmdPublish, 2, 2},
+		"flushall":  {cmdFlushAll, 0, 1},
+	}
+}
+
+const (
+	errWrongType  = replyError("WRONGTYPE Operation against a key holding the wrong kind of value")
+	errNotInteger = replyError("ERR value is not an integer or out of range")
+	errSyntax     = replyError("ERR syntax error")
+)
+
+// lookup returns the value of the key if it has the given kind. The reply is
+// non-nil if the key holds another kind.
+func (s *Server) lookup(key string, k kind) (*value, interface{}) {
+	v := s.get(key)
+	if v == nil {
+		return nil, nil
+	}
+	if v.kind != k {
+		return nil, errWrongType
+	}
+	return v, nil
+}
+
+// create returns the value of the key, creating it with the given kind if it
+// doesn't exist. The reply is non-nil if the key holds another kind.
+func (s *Server) create(key string, k kind) (*value, interface{}) {
+	v, res := s.lookup(key, k)
+	if res != nil || v != nil {
+		return v, res
+	}
+	v = &value{kind: k}
+	switch k {
+	case kindHash:
+		v.hash = map[string]string{}
+	case kindSet:
+		v.set = map[string]struct{}{}
+	}
+	s.data[key] = v
+	return v, nil
+}
+
+func cmdPing(_ *Server, args []string) interface{} {
+	if len(args) == 1 {
+		return args[0]
+	}
+	return status("PONG")
+}
+
+// cmdHello makes clients fall back to RESP2.
+func cmdHello(_ *Server, _ []string) interface{} {
+	return replyError("ERR unknown command 'HELLO'")
+}
+
+func cmdGet(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindString)
+	if v == nil {
+		return res
+	}
+	return v.str
+}
+
+func cmdSet(s *Server, args []string) interface{} {
+	var (
+		nx, xx, keepTTL bool
+		ttl             time.Duration
+	)
+	for i := 2; i < len(args); i++ {
+		switch opt := strings.ToLower(args[i]); opt {
+		case "nx":
+			nx = true
+		case "xx":
+			xx = true
+		case "keepttl":
+			keepTTL = true
+		case "ex", "px":
+			if i+1 >= len(args) {
+				return errSyntax
+			}
+			i++
+			n, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return errNotInteger
+			}
+			if n <= 0 {
+				return replyError("ERR invalid expire time in 'set' command")
+			}
+			if opt == "ex" {
+				ttl = time.Duration(n) * time.Second
+			} else {
+				ttl = time.Duration(n) * time.Millisecond
+			}
+		default:
+			return errSyntax
+		}
+	}
+	if nx && xx {
+		return errSyntax
+	}
+
+	old := s.get(args[0])
+	if (nx && old != nil) || (xx && old == nil) {
+		return nil
+	}
+	v := &value{kind: kindString, str: args[1]}
+	if ttl > 0 {
+		v.expiresAt = s.now().Add(ttl)
+	} else if keepTTL && old != nil {
+		v.expiresAt = old.expiresAt
+	}
+	s.data[args[0]] = v
+	s.touch(args[0])
+	return status("OK")
+}
+
+func cmdSetNX(s *Server, args []string) interface{} {
+	if s.get(args[0]) != nil {
+		return int64(0)
+	}
+	s.data[args[0]] = &value{kind: kindString, str: args[1]}
+	s.touch(args[0])
+	return int64(1)
+}
+
+func cmdMGet(s *Server, args []string) interface{} {
+	res := make([]interface{}, 0, len(args))
+	for _, k := range args {
+		v, _ := s.lookup(k, kindString)
+		if v == nil {
+			res = append(res, nil)
+			continue
+		}
+		res = append(res, v.str)
+	}
+	return res
+}
+
+func incrBy(s *Server, key string, by int64) interface{} {
+	v, res := s.lookup(key, kindString)
+	if res != nil {
+		return res
+	}
+	var n int64
+	if v != nil {
+		var err error
+		if n, err = strconv.ParseInt(v.str, 10, 64); err != nil {
+			return errNotInteger
+		}
+	} else {
+		v = &value{kind: kindString}
+		s.data[key] = v
+	}
+	n += by
+	v.str = strconv.FormatInt(n, 10)
+	s.touch(key)
+	return n
+}
+
+func cmdIncr(s *Server, args []string) interface{} {
+	return incrBy(s, args[0], 1)
+}
+
+func cmdDecr(s *Server, args []string) interface{} {
+	return incrBy(s, args[0], -1)
+}
+
+func cmdDel(s *Server, args []string) interface{} {
+	var n int64
+	for _, k := range args {
+		if s.get(k) != nil && s.del(k) {
+			n++
+		}
+	}
+	return n
+}
+
+func cmdExists(s *Server, args []string) interface{} {
+	var n int64
+	for _, k := range args {
+		if s.get(k) != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func cmdKeys(s *Server, args []string) interface{} {
+	keys := s.keys(args[0])
+	res := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, k)
+	}
+	return res
+}
+
+func expire(s *Server, args []string, unit time.Duration) interface{} {
+	n, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return errNotInteger
+	}
+	v := s.get(args[0])
+	if v == nil {
+		return int64(0)
+	}
+	at := s.now().Add(time.Duration(n) * unit)
+	if len(args) == 3 {
+		// Keys without TTL count as having an infinite TTL.
+		switch strings.ToLower(args[2]) {
+		case "nx":
+			if !v.expiresAt.IsZero() {
+				return int64(0)
+			}
+		case "xx":
+			if v.expiresAt.IsZero() {
+				return int64(0)
+			}
+		case "gt":
+			if v.expiresAt.IsZero() || !at.After(v.expiresAt) {
+				return int64(0)
+			}
+		case "lt":
+			if !v.expiresAt.IsZero() && !at.Before(v.expiresAt) {
+				return int64(0)
+			}
+		default:
+			return replyError("ERR Unsupported option " + args[2])
+		}
+	}
+	if n <= 0 {
+		s.del(args[0])
+		return int64(1)
+	}
+	v.expiresAt = at
+	s.touch(args[0])
+	return int64(1)
+}
+
+func cmdExpire(s *Server, args []string) interface{} {
+	return expire(s, args, time.Second)
+}
+
+func cmdPExpire(s *Server, args []string) interface{} {
+	return expire(s, args, time.Millisecond)
+}
+
+func ttl(s *Server, key string, unit time.Duration) interface{} {
+	v := s.get(key)
+	if v == nil {
+		return int64(-2)
+	}
+	if v.expiresAt.IsZero() {
+		return int64(-1)
+	}
+	// Round up like Redis does, so that a key doesn't report a TTL of 0
+	// before it expired.
+	d := v.expiresAt.Sub(s.now())
+	return int64((d + unit - 1) / unit)
+}
+
+func cmdTTL(s *Server, args []string) interface{} {
+	return ttl(s, args[0], time.Second)
+}
+
+func cmdPTTL(s *Server, args []string) interface{} {
+	return ttl(s, args[0], time.Millisecond)
+}
+
+func cmdHSet(s *Server, args []string) interface{} {
+	if len(args)%2 != 1 {
+		return errArgs("hset")
+	}
+	v, res := s.create(args[0], kindHash)
+	if res != nil {
+		return res
+	}
+	var n int64
+	for i := 1; i < len(args); i += 2 {
+		if _, ok := v.hash[args[i]]; !ok {
+			n++
+		}
+		v.hash[args[i]] = args[i+1]
+	}
+	s.touch(args[0])
+	return n
+}
+
+func cmdHGet(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindHash)
+	if v == nil {
+		return res
+	}
+	f, ok := v.hash[args[1]]
+	if !ok {
+		return nil
+	}
+	return f
+}
+
+func cmdHGetAll(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindHash)
+	if res != nil {
+		return res
+	}
+	out := []interface{}{}
+	if v == nil {
+		return out
+	}
+	fields := make([]string, 0, len(v.hash))
+	for f := range v.hash {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		out = append(out, f, v.hash[f])
+	}
+	return out
+}
+
+func cmdHDel(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindHash)
+	if v == nil {
+		if res != nil {
+			return res
+		}
+		return int64(0)
+	}
+	var n int64
+	for _, f := range args[1:] {
+		if _, ok := v.hash[f]; ok {
+			delete(v.hash, f)
+			n++
+		}
+	}
+	if len(v.hash) == 0 {
+		s.del(args[0])
+	} else if n > 0 {
+		s.touch(args[0])
+	}
+	return n
+}
+
+func cmdHLen(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindHash)
+	if v == nil {
+		if res != nil {
+			return res
+		}
+		return int64(0)
+	}
+	return int64(len(v.hash))
+}
+
+func cmdSAdd(s *Server, args []string) interface{} {
+	v, res := s.create(args[0], kindSet)
+	if res != nil {
+		return res
+	}
+	var n int64
+	for _, m := range args[1:] {
+		if _, ok := v.set[m]; !ok {
+			v.set[m] = struct{}{}
+			n++
+		}
+	}
+	s.touch(args[0])
+	return n
+}
+
+func cmdSRem(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindSet)
+	if v == nil {
+		if res != nil {
+			return res
+		}
+		return int64(0)
+	}
+	var n int64
+	for _, m := range args[1:] {
+		if _, ok := v.set[m]; ok {
+			delete(v.set, m)
+			n++
+		}
+	}
+	if len(v.set) == 0 {
+		s.del(args[0])
+	} else if n > 0 {
+		s.touch(args[0])
+	}
+	return n
+}
+
+func cmdSMembers(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindSet)
+	if res != nil {
+		return res
+	}
+	out := []interface{}{}
+	if v == nil {
+		return out
+	}
+	members := make([]string, 0, len(v.set))
+	for m := range v.set {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	for _, m := range members {
+		out = append(out, m)
+	}
+	return out
+}
+
+func cmdSIsMember(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindSet)
+	if v == nil {
+		if res != nil {
+			return res
+		}
+		return int64(0)
+	}
+	if _, ok := v.set[args[1]]; ok {
+		return int64(1)
+	}
+	return int64(0)
+}
+
+func cmdSCard(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindSet)
+	if v == nil {
+		if res != nil {
+			return res
+		}
+		return int64(0)
+	}
+	return int64(len(v.set))
+}
+
+func cmdRPush(s *Server, args []string) interface{} {
+	v, res := s.create(args[0], kindList)
+	if res != nil {
+		return res
+	}
+	v.list = append(v.list, args[1:]...)
+	s.touch(args[0])
+	return int64(len(v.list))
+}
+
+func cmdLRange(s *Server, args []string) interface{} {
+	start, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errNotInteger
+	}
+	stop, err := strconv.Atoi(args[2])
+	if err != nil {
+		return errNotInteger
+	}
+	v, res := s.lookup(args[0], kindList)
+	if res != nil {
+		return res
+	}
+	out := []interface{}{}
+	if v == nil {
+		return out
+	}
+	n := len(v.list)
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= n {
+		stop = n - 1
+	}
+	for i := start; i <= stop; i++ {
+		out = append(out, v.list[i])
+	}
+	return out
+}
+
+func cmdLLen(s *Server, args []string) interface{} {
+	v, res := s.lookup(args[0], kindList)
+	if v == nil {
+		if res != nil {
+			return res
+		}
+		return int64(0)
+	}
+	return int64(len(v.list))
+}
+
+func cmdPublish(s *Server, args []string) interface{} {
+	return s.publish(args[0], args[1])
+}
+
+func cmdFlushAll(s *Server, _ []string) interface{} {
+	for k := range s.data {
+		s.del(k)
+	}
+	return status("OK")
+}
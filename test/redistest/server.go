@@ -0,0 +1,547 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redistest provides an in-memory Redis server for tests. It speaks
+// RESP2 and implements the subset of commands used by Alertmanager.
+package redistest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Server is an in-memory Redis server.
+type Server struct {
+	ln net.Listener
+
+	mtx sync.Mutex
+	// offset is added to the wall clock to implement FastForward.
+	offset time.Duration
+	data   map[string]*value
+	// mods counts the modifications of every key to implement WATCH.
+	mods   map[string]uint64
+	subs   map[string]map[*conn]struct{}
+	conns  map[*conn]struct{}
+	closed bool
+
+	wg sync.WaitGroup
+}
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindHash
+	kindSet
+	kindList
+)
+
+type value struct {
+	kind      kind
+	str       string
+	hash      map[string]string
+	set       map[string]struct{}
+	list      []string
+	expiresAt time.Time
+}
+
+// Run starts a server which is closed at the end of the test.
+func Run(t testing.TB) *Server {
+	t.Helper()
+
+	s, err := Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Start starts a server listening on a random local port.
+func Start() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		ln:    ln,
+		data:  map[string]*value{},
+		mods:  map[string]uint64{},
+		subs:  map[string]map[*conn]struct{}{},
+		conns: map[*conn]struct{}{},
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Client returns a new client of the server which is closed at the end of
+// the test.
+func (s *Server) Client(t testing.TB) *redis.Client {
+	c := redis.NewClient(&redis.Options{Addr: s.Addr(), Protocol: 2})
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// Close closes all connections and stops the server.
+func (s *Server) Close() {
+	s.ln.Close()
+	s.mtx.Lock()
+	s.closed = true
+	for c := range s.conns {
+		c.nc.Close()
+	}
+	s.mtx.Unlock()
+	s.wg.Wait()
+}
+
+// FastForward moves the time of the server forward, expiring keys whose
+// TTL elapsed.
+func (s *Server) FastForward(d time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.offset += d
+}
+
+// Keys returns the sorted names of all keys which aren't expired.
+func (s *Server) Keys() []string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.keys("*")
+}
+
+// Exists returns whether the key exists and isn't expired.
+func (s *Server) Exists(key string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.get(key) != nil
+}
+
+// TTL returns the time to live of the key. It is zero if the key doesn't
+// exist or has no TTL.
+func (s *Server) TTL(key string) time.Duration {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	v := s.get(key)
+	if v == nil || v.expiresAt.IsZero() {
+		return 0
+	}
+	return v.expiresAt.Sub(s.now())
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		nc, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		c := &conn{
+			srv: s,
+			nc:  nc,
+			r:   bufio.NewReader(nc),
+			w:   bufio.NewWriter(nc),
+		}
+		s.mtx.Lock()
+		if s.closed {
+			s.mtx.Unlock()
+			nc.Close()
+			return
+		}
+		s.conns[c] = struct{}{}
+		s.mtx.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			c.serve()
+		}()
+	}
+}
+
+// now returns the current time of the server. The caller must hold the lock.
+func (s *Server) now() time.Time {
+	return time.Now().Add(s.offset)
+}
+
+// get returns the value of the key, removing it if it expired. The caller
+// must hold the lock.
+func (s *Server) get(key string) *value {
+	v, ok := s.data[key]
+	if !ok {
+		return nil
+	}
+	if !v.expiresAt.IsZero() && !s.now().Before(v.expiresAt) {
+		s.del(key)
+		return nil
+	}
+	return v
+}
+
+// del removes the key. The caller must hold the lock.
+func (s *Server) del(key string) bool {
+	if _, ok := s.data[key]; !ok {
+		return false
+	}
+	delete(s.data, key)
+	s.touch(key)
+	return true
+}
+
+// touch records a modification of the key. The caller must hold the lock.
+func (s *Server) touch(key string) {
+	s.mods[key]++
+}
+
+// keys returns the sorted names of the keys matching the pattern. The caller
+// must hold the lock.
+func (s *Server) keys(pattern string) []string {
+	var res []string
+	for k := range s.data {
+		if s.get(k) == nil {
+			continue
+		}
+		if ok, _ := path.Match(pattern, k); ok {
+			res = append(res, k)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// conn is a client connection.
+type conn struct {
+	srv *Server
+	nc  net.Conn
+	r   *bufio.Reader
+
+	wmtx sync.Mutex
+	w    *bufio.Writer
+
+	// The following fields are only accessed with the server lock held.
+	multi   bool
+	queued  [][]string
+	txErr   bool
+	watched map[string]uint64
+	subs    map[string]struct{}
+}
+
+func (c *conn) serve() {
+	defer func() {
+		c.srv.mtx.Lock()
+		for ch := range c.subs {
+			delete(c.srv.subs[ch], c)
+		}
+		delete(c.srv.conns, c)
+		c.srv.mtx.Unlock()
+		c.nc.Close()
+	}()
+
+	for {
+		args, err := readCommand(c.r)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				c.write(replyError("ERR " + err.Error()))
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if strings.ToLower(args[0]) == "quit" {
+			c.write(status("OK"))
+			return
+		}
+		// Replies are written with the server lock held so that they are
+		// ordered with the messages published to the connection.
+		c.srv.mtx.Lock()
+		c.write(c.handle(args))
+		c.srv.mtx.Unlock()
+	}
+}
+
+func (c *conn) write(res interface{}) {
+	c.wmtx.Lock()
+	defer c.wmtx.Unlock()
+
+	writeReply(c.w, res)
+	c.w.Flush()
+}
+
+// handle executes the command. The caller must hold the server lock.
+func (c *conn) handle(args []string) interface{} {
+	name := strings.ToLower(args[0])
+
+	if len(c.subs) > 0 {
+		switch name {
+		case "subscribe", "unsubscribe", "ping":
+		default:
+			return replyError(fmt.Sprintf("ERR Can't execute '%s': only (P|S)SUBSCRIBE / (P|S)UNSUBSCRIBE / PING / QUIT / RESET are allowed in this context", name))
+		}
+	}
+
+	switch name {
+	case "multi":
+		if c.multi {
+			return replyError("ERR MULTI calls can not be nested")
+		}
+		c.multi = true
+		return status("OK")
+	case "exec":
+		if !c.multi {
+			return replyError("ERR EXEC without MULTI")
+		}
+		return c.exec()
+	case "discard":
+		if !c.multi {
+			return replyError("ERR DISCARD without MULTI")
+		}
+		c.reset()
+		return status("OK")
+	case "watch":
+		if c.multi {
+			return replyError("ERR WATCH inside MULTI is not allowed")
+		}
+		if len(args) < 2 {
+			return errArgs(name)
+		}
+		if c.watched == nil {
+			c.watched = map[string]uint64{}
+		}
+		for _, k := range args[1:] {
+			// Expired keys count as modified.
+			c.srv.get(k)
+			if _, ok := c.watched[k]; !ok {
+				c.watched[k] = c.srv.mods[k]
+			}
+		}
+		return status("OK")
+	case "unwatch":
+		c.watched = nil
+		return status("OK")
+	case "subscribe":
+		return c.subscribe(args[1:])
+	case "unsubscribe":
+		return c.unsubscribe(args[1:])
+	case "ping":
+		if len(c.subs) > 0 {
+			msg := ""
+			if len(args) > 1 {
+				msg = args[1]
+			}
+			return []interface{}{"pong", msg}
+		}
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		if c.multi {
+			c.txErr = true
+		}
+		return replyError(fmt.Sprintf("ERR unknown command '%s'", args[0]))
+	}
+	if len(args)-1 < cmd.minArgs || (cmd.maxArgs >= 0 && len(args)-1 > cmd.maxArgs) {
+		if c.multi {
+			c.txErr = true
+		}
+		return errArgs(name)
+	}
+	if c.multi {
+		c.queued = append(c.queued, args)
+		return status("QUEUED")
+	}
+	return cmd.fn(c.srv, args[1:])
+}
+
+// exec executes the queued commands of a transaction. The caller must hold
+// the server lock.
+func (c *conn) exec() interface{} {
+	defer c.reset()
+
+	if c.txErr {
+		return replyError("EXECABORT Transaction discarded because of previous errors.")
+	}
+	for k, mod := range c.watched {
+		c.srv.get(k)
+		if c.srv.mods[k] != mod {
+			return nilArray{}
+		}
+	}
+	res := make([]interface{}, 0, len(c.queued))
+	for _, args := range c.queued {
+		res = append(res, commands[strings.ToLower(args[0])].fn(c.srv, args[1:]))
+	}
+	return res
+}
+
+func (c *conn) reset() {
+	c.multi = false
+	c.queued = nil
+	c.txErr = false
+	c.watched = nil
+}
+
+func (c *conn) subscribe(channels []string) interface{} {
+	if len(channels) == 0 {
+		return errArgs("subscribe")
+	}
+	if c.subs == nil {
+		c.subs = map[string]struct{}{}
+	}
+	res := make(multiReply, 0, len(channels))
+	for _, ch := range channels {
+		c.subs[ch] = struct{}{}
+		if c.srv.subs[ch] == nil {
+			c.srv.subs[ch] = map[*conn]struct{}{}
+		}
+		c.srv.subs[ch][c] = struct{}{}
+		res = append(res, []interface{}{"subscribe", ch, int64(len(c.subs))})
+	}
+	return res
+}
+
+func (c *conn) unsubscribe(channels []string) interface{} {
+	if len(channels) == 0 {
+		for ch := range c.subs {
+			channels = append(channels, ch)
+		}
+		sort.Strings(channels)
+	}
+	if len(channels) == 0 {
+		return []interface{}{"unsubscribe", nil, int64(0)}
+	}
+	res := make(multiReply, 0, len(channels))
+	for _, ch := range channels {
+		delete(c.subs, ch)
+		delete(c.srv.subs[ch], c)
+		res = append(res, []interface{}{"unsubscribe", ch, int64(len(c.subs))})
+	}
+	return res
+}
+
+// publish sends the message to the subscribers of the channel and returns
+// their number. The caller must hold the server lock.
+func (s *Server) publish(ch, msg string) int64 {
+	var n int64
+	for c := range s.subs[ch] {
+		c.write([]interface{}{"message", ch, msg})
+		n++
+	}
+	return n
+}
+
+func errArgs(name string) replyError {
+	return replyError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", name))
+}
+
+// readCommand reads a command sent as an array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		// Inline command.
+		return strings.Fields(line), nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, fmt.Errorf("Protocol error: invalid multibulk length")
+	}
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, fmt.Errorf("Protocol error: expected '$', got '%s'", line)
+		}
+		l, err := strconv.Atoi(line[1:])
+		if err != nil || l < 0 {
+			return nil, fmt.Errorf("Protocol error: invalid bulk length")
+		}
+		buf := make([]byte, l+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:l]))
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+type (
+	status     string
+	replyError string
+	// nilArray is the null array replied by an aborted transaction.
+	nilArray struct{}
+	// multiReply holds several replies sent for a single command.
+	multiReply []interface{}
+)
+
+func writeReply(w *bufio.Writer, res interface{}) {
+	switch v := res.(type) {
+	case status:
+		fmt.Fprintf(w, "+%s\r\n", v)
+	case replyError:
+		fmt.Fprintf(w, "-%s\r\n", v)
+	case int64:
+		fmt.Fprintf(w, ":%d\r\n", v)
+	case string:
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+	case nil:
+		w.WriteString("$-1\r\n")
+	case nilArray:
+		w.WriteString("*-1\r\n")
+	case []interface{}:
+		fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, e := range v {
+			writeReply(w, e)
+		}
+	case multiReply:
+		for _, e := range v {
+			writeReply(w, e)
+		}
+	default:
+		panic(fmt.Sprintf("unsupported reply type %T", res))
+	}
+}
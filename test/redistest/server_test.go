@@ -0,0 +1,133 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redistest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommands(t *testing.T) {
+	s := Run(t)
+	c := s.Client(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Ping(ctx).Err())
+
+	require.NoError(t, c.Set(ctx, "a", "1", 0).Err())
+	require.Equal(t, "1", c.Get(ctx, "a").Val())
+	require.Equal(t, int64(2), c.Incr(ctx, "a").Val())
+	require.Equal(t, int64(1), c.Decr(ctx, "a").Val())
+	require.ErrorIs(t, c.Get(ctx, "missing").Err(), redis.Nil)
+	require.False(t, c.SetNX(ctx, "a", "2", time.Minute).Val())
+	require.True(t, c.SetNX(ctx, "b", "2", time.Minute).Val())
+	require.Equal(t, []interface{}{"1", "2", nil}, c.MGet(ctx, "a", "b", "c").Val())
+
+	require.Equal(t, int64(2), c.HSet(ctx, "h", "f1", "v1", "f2", "v2").Val())
+	require.Equal(t, "v1", c.HGet(ctx, "h", "f1").Val())
+	require.Equal(t, map[string]string{"f1": "v1", "f2": "v2"}, c.HGetAll(ctx, "h").Val())
+	require.Equal(t, int64(1), c.HDel(ctx, "h", "f1").Val())
+	require.EqualError(t, c.Get(ctx, "h").Err(), "WRONGTYPE Operation against a key holding the wrong kind of value")
+
+	require.Equal(t, int64(2), c.SAdd(ctx, "s", "m1", "m2").Val())
+	require.Equal(t, int64(1), c.SRem(ctx, "s", "m1").Val())
+	require.Equal(t, []string{"m2"}, c.SMembers(ctx, "s").Val())
+
+	require.Equal(t, int64(3), c.RPush(ctx, "l", "1", "2", "3").Val())
+	require.Equal(t, []string{"2", "3"}, c.LRange(ctx, "l", 1, -1).Val())
+
+	require.Equal(t, int64(2), c.Exists(ctx, "a", "b", "c").Val())
+	require.Equal(t, int64(2), c.Del(ctx, "a", "b", "c").Val())
+	require.Equal(t, []string{"h", "l", "s"}, s.Keys())
+}
+
+func TestExpiry(t *testing.T) {
+	s := Run(t)
+	c := s.Client(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "a", "1", time.Minute).Err())
+	require.NoError(t, c.Set(ctx, "b", "1", 0).Err())
+
+	require.False(t, c.ExpireNX(ctx, "a", time.Hour).Val())
+	require.True(t, c.ExpireNX(ctx, "b", time.Hour).Val())
+	require.False(t, c.ExpireGT(ctx, "b", time.Minute).Val())
+	require.True(t, c.ExpireGT(ctx, "a", 2*time.Minute).Val())
+	require.Equal(t, 2*time.Minute, c.TTL(ctx, "a").Val())
+
+	s.FastForward(2 * time.Minute)
+	require.False(t, s.Exists("a"))
+	require.True(t, s.Exists("b"))
+	require.InDelta(t, time.Hour-2*time.Minute, s.TTL("b"), float64(time.Second))
+}
+
+func TestTransactions(t *testing.T) {
+	s := Run(t)
+	c := s.Client(t)
+	ctx := context.Background()
+
+	_, err := c.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, "a", "1", 0)
+		pipe.Incr(ctx, "a")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "2", c.Get(ctx, "a").Val())
+
+	// A transaction fails if a watched key is modified in between.
+	err = c.Watch(ctx, func(tx *redis.Tx) error {
+		require.NoError(t, c.Set(ctx, "a", "3", 0).Err())
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, "a", "4", 0)
+			return nil
+		})
+		return err
+	}, "a")
+	require.ErrorIs(t, err, redis.TxFailedErr)
+	require.Equal(t, "3", c.Get(ctx, "a").Val())
+
+	err = c.Watch(ctx, func(tx *redis.Tx) error {
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, "a", "4", 0)
+			return nil
+		})
+		return err
+	}, "a")
+	require.NoError(t, err)
+	require.Equal(t, "4", c.Get(ctx, "a").Val())
+}
+
+func TestPubSub(t *testing.T) {
+	s := Run(t)
+	c := s.Client(t)
+	ctx := context.Background()
+
+	ps := c.Subscribe(ctx, "ch")
+	defer ps.Close()
+	_, err := ps.Receive(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), c.Publish(ctx, "ch", "hello").Val())
+	select {
+	case msg := <-ps.Channel():
+		require.Equal(t, "ch", msg.Channel)
+		require.Equal(t, "hello", msg.Payload)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not received")
+	}
+}
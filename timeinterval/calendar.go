@@ -0,0 +1,435 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeinterval
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/pkg/rrule"
+)
+
+// DateLayout specifies the layout of the dates of a DateRange.
+const DateLayout = "2006-01-02"
+
+// A DateRange is an inclusive range of calendar dates, such as public
+// holidays. Begin and End are at midnight UTC.
+type DateRange struct {
+	Begin time.Time
+	End   time.Time
+}
+
+// UnmarshalYAML implements the Unmarshaller interface for DateRange.
+func (r *DateRange) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	begin, end, found := strings.Cut(str, ":")
+	if !found {
+		end = begin
+	}
+	var err error
+	if r.Begin, err = time.Parse(DateLayout, begin); err != nil {
+		return fmt.Errorf("%s is not a valid date: expected YYYY-MM-DD", begin)
+	}
+	if r.End, err = time.Parse(DateLayout, end); err != nil {
+		return fmt.Errorf("%s is not a valid date: expected YYYY-MM-DD", end)
+	}
+	if r.End.Before(r.Begin) {
+		return fmt.Errorf("end date %s is before start date %s", end, begin)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for DateRange.
+// It delegates to the YAML unmarshaller as it can parse JSON and has validation logic.
+func (r *DateRange) UnmarshalJSON(in []byte) error {
+	return yaml.Unmarshal(in, r)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for DateRange.
+// It converts the range into a colon-separated string, or a single date if
+// appropriate. e.g. "2024-12-24:2024-12-26" or "2024-12-25"
+func (r DateRange) MarshalText() ([]byte, error) {
+	if r.Begin.Equal(r.End) {
+		return []byte(r.Begin.Format(DateLayout)), nil
+	}
+	return []byte(r.Begin.Format(DateLayout) + ":" + r.End.Format(DateLayout)), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface for DateRange.
+func (r DateRange) MarshalYAML() (interface{}, error) {
+	bytes, err := r.MarshalText()
+	return string(bytes), err
+}
+
+// containsDate returns true if the date of t is within the range.
+func (r DateRange) containsDate(t time.Time) bool {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return !d.Before(r.Begin) && !d.After(r.End)
+}
+
+// A Calendar holds the events of an iCalendar (RFC 5545) file.
+//
+// The DTSTART, DTEND, DURATION, RRULE and EXDATE properties of the VEVENT
+// components are supported, see the rrule package for the supported subset
+// of recurrence rules. Time zones are referenced by their IANA names in TZID
+// parameters, VTIMEZONE components are ignored.
+type Calendar struct {
+	events []event
+}
+
+// event is a VEVENT of a calendar.
+type event struct {
+	start time.Time
+	// The duration of events with a date-time start, or the number of days
+	// of all-day events.
+	duration time.Duration
+	days     int
+	rule     *rrule.Rule
+	exdates  []time.Time
+}
+
+// LoadCalendar reads the iCalendar file. Floating times and all-day events
+// are in loc, or else in the X-WR-TIMEZONE of the calendar, or else in UTC.
+func LoadCalendar(filename string, loc *time.Location) (*Calendar, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := ParseCalendar(f, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return c, nil
+}
+
+// property is a content line of an iCalendar file.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseCalendar parses an iCalendar stream. See LoadCalendar for the
+// location of floating times.
+func ParseCalendar(r io.Reader, loc *time.Location) (*Calendar, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		// The properties of the events are parsed once the location of
+		// the calendar is known, as X-WR-TIMEZONE may follow them.
+		events  [][]property
+		props   []property
+		depth   int
+		inEvent bool
+	)
+	for n, line := range lines {
+		p, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		switch p.name {
+		case "BEGIN":
+			depth++
+			if depth == 2 && p.value == "VEVENT" {
+				inEvent = true
+				props = nil
+			}
+		case "END":
+			if depth == 2 && inEvent {
+				inEvent = false
+				events = append(events, props)
+			}
+			depth--
+		case "X-WR-TIMEZONE":
+			if depth == 1 && loc == nil {
+				if loc, err = time.LoadLocation(p.value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", n+1, err)
+				}
+			}
+		default:
+			// Properties of the components nested in events, such as
+			// VALARM, are ignored.
+			if inEvent && depth == 2 {
+				props = append(props, p)
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unterminated component")
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	c := &Calendar{events: make([]event, 0, len(events))}
+	for i, props := range events {
+		e, err := parseEvent(props, loc)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i+1, err)
+		}
+		c.events = append(c.events, e)
+	}
+	return c, nil
+}
+
+// unfoldLines returns the content lines of the stream, joining the lines
+// folded with a leading space or tab.
+func unfoldLines(r io.Reader) ([]string, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, sc.Err()
+}
+
+// parseProperty parses a content line of the form NAME;PARAM=VALUE:VALUE.
+func parseProperty(line string) (property, error) {
+	head, value, found := cutUnquoted(line, ':')
+	if !found {
+		return property{}, fmt.Errorf("missing value in %q", line)
+	}
+	p := property{value: value, params: map[string]string{}}
+	parts := strings.Split(head, ";")
+	p.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return p, nil
+}
+
+// cutUnquoted slices s around the first instance of sep outside of double
+// quotes.
+func cutUnquoted(s string, sep byte) (before, after string, found bool) {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+	return s, "", false
+}
+
+// parseEvent parses the properties of a VEVENT.
+func parseEvent(props []property, loc *time.Location) (event, error) {
+	var (
+		e                  event
+		end                time.Time
+		rule               string
+		hasStart, allDay   bool
+		hasEnd, hasDurProp bool
+	)
+	for _, p := range props {
+		var err error
+		switch p.name {
+		case "DTSTART":
+			e.start, allDay, err = parseDateTime(p, loc)
+			hasStart = true
+		case "DTEND":
+			end, _, err = parseDateTime(p, loc)
+			hasEnd = true
+		case "DURATION":
+			e.duration, err = parseDuration(p.value)
+			hasDurProp = true
+		case "RRULE":
+			rule = p.value
+		case "EXDATE":
+			for _, v := range strings.Split(p.value, ",") {
+				var t time.Time
+				t, _, err = parseDateTime(property{params: p.params, value: v}, loc)
+				if err != nil {
+					break
+				}
+				e.exdates = append(e.exdates, t)
+			}
+		}
+		if err != nil {
+			return event{}, fmt.Errorf("%s: %w", p.name, err)
+		}
+	}
+	if !hasStart {
+		return event{}, fmt.Errorf("missing DTSTART")
+	}
+
+	switch {
+	case hasEnd && allDay:
+		e.days = int(end.Sub(e.start).Hours()+12) / 24
+	case hasEnd:
+		e.duration = end.Sub(e.start)
+	case hasDurProp && allDay:
+		e.days = int(e.duration / (24 * time.Hour))
+	case allDay:
+		// All-day events without an end last one day.
+		e.days = 1
+	}
+	if allDay && e.days < 1 {
+		return event{}, fmt.Errorf("all-day event must last at least one day")
+	}
+	if e.duration < 0 {
+		return event{}, fmt.Errorf("end before start")
+	}
+
+	if rule != "" {
+		r, err := rrule.Parse(rule, e.start)
+		if err != nil {
+			return event{}, fmt.Errorf("RRULE: %w", err)
+		}
+		e.rule = r
+	}
+	return e, nil
+}
+
+// parseDateTime parses a DATE or DATE-TIME value. The second result is true
+// for dates.
+func parseDateTime(p property, loc *time.Location) (time.Time, bool, error) {
+	if tzid, ok := p.params["TZID"]; ok {
+		var err error
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return time.Time{}, false, err
+		}
+	}
+	if p.params["VALUE"] == "DATE" || len(p.value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", p.value, loc)
+		if err != nil {
+			return t, true, fmt.Errorf("invalid date %q", p.value)
+		}
+		return t, true, nil
+	}
+	var (
+		t   time.Time
+		err error
+	)
+	if strings.HasSuffix(p.value, "Z") {
+		t, err = time.Parse("20060102T150405Z", p.value)
+	} else {
+		t, err = time.ParseInLocation("20060102T150405", p.value, loc)
+	}
+	if err != nil {
+		return t, false, fmt.Errorf("invalid date-time %q", p.value)
+	}
+	return t, false, nil
+}
+
+var durationRE = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses a DURATION value such as P1D or PT1H30M.
+func parseDuration(s string) (time.Duration, error) {
+	m := durationRE.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		return 0, fmt.Errorf("negative duration %q", s)
+	}
+	return d, nil
+}
+
+// Contains returns true if t is within an occurrence of one of the events.
+func (c *Calendar) Contains(t time.Time) bool {
+	for _, e := range c.events {
+		if e.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e event) contains(t time.Time) bool {
+	if e.rule == nil {
+		return e.occurrenceContains(e.start, t)
+	}
+	for _, o := range e.rule.Between(t.Add(-e.maxDuration()), t) {
+		if e.excluded(o) {
+			continue
+		}
+		if e.occurrenceContains(o, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// occurrenceContains returns true if t is within the occurrence starting at
+// o. Occurrences are half-open intervals.
+func (e event) occurrenceContains(o, t time.Time) bool {
+	return !t.Before(o) && t.Before(e.end(o))
+}
+
+// end returns the end of the occurrence starting at o.
+func (e event) end(o time.Time) time.Time {
+	if e.days > 0 {
+		return o.AddDate(0, 0, e.days)
+	}
+	return o.Add(e.duration)
+}
+
+// maxDuration returns the longest duration of the occurrences. All-day
+// occurrences may last an hour longer on days with daylight saving time
+// changes.
+func (e event) maxDuration() time.Duration {
+	if e.days > 0 {
+		return time.Duration(e.days) * 25 * time.Hour
+	}
+	return e.duration
+}
+
+func (e event) excluded(o time.Time) bool {
+	for _, ex := range e.exdates {
+		if ex.Equal(o) {
+			return true
+		}
+	}
+	return false
+}
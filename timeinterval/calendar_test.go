@@ -0,0 +1,187 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeinterval
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const testCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//HR//Holidays//EN
+X-WR-TIMEZONE:Europe/Berlin
+BEGIN:VTIMEZONE
+TZID:Europe/Berlin
+BEGIN:STANDARD
+DTSTART:19701025T030000
+END:STANDARD
+END:VTIMEZONE
+BEGIN:VEVENT
+SUMMARY:Christmas
+DTSTART;VALUE=DATE:20231225
+DTEND;VALUE=DATE:20231227
+RRULE:FREQ=YEARLY
+EXDATE;VALUE=DATE:20251225
+BEGIN:VALARM
+TRIGGER:-PT15M
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:Company
+  offsite
+DTSTART;TZID=America/New_York:20240612T090000
+DURATION:PT8H
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:Maintenance
+DTSTART:20240601T220000Z
+DTEND:20240601T230000Z
+RRULE:FREQ=WEEKLY;COUNT=2
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestCalendarContains(t *testing.T) {
+	c, err := ParseCalendar(strings.NewReader(strings.ReplaceAll(testCalendar, "\n", "\r\n")), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		t   string
+		exp bool
+	}{
+		// All-day events in the X-WR-TIMEZONE of the calendar.
+		{t: "2023-12-24T22:59:59Z", exp: false},
+		{t: "2023-12-24T23:00:00Z", exp: true},
+		{t: "2023-12-26T22:59:59Z", exp: true},
+		{t: "2023-12-26T23:00:00Z", exp: false},
+		{t: "2024-12-26T12:00:00Z", exp: true},
+		// Excluded occurrence.
+		{t: "2025-12-25T12:00:00Z", exp: false},
+		{t: "2030-12-25T12:00:00Z", exp: true},
+		// Event in its own time zone.
+		{t: "2024-06-12T12:59:59Z", exp: false},
+		{t: "2024-06-12T13:00:00Z", exp: true},
+		{t: "2024-06-12T20:59:59Z", exp: true},
+		{t: "2024-06-12T21:00:00Z", exp: false},
+		// Recurring event limited by a count.
+		{t: "2024-06-01T22:30:00Z", exp: true},
+		{t: "2024-06-08T22:30:00Z", exp: true},
+		{t: "2024-06-15T22:30:00Z", exp: false},
+	} {
+		at, err := time.Parse(time.RFC3339, tc.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Contains(at); got != tc.exp {
+			t.Errorf("Contains(%s): expected %t, got %t", tc.t, tc.exp, got)
+		}
+	}
+}
+
+func TestCalendarLocation(t *testing.T) {
+	// The given location overrides the X-WR-TIMEZONE of the calendar.
+	c, err := ParseCalendar(strings.NewReader(testCalendar), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Contains(time.Date(2024, 12, 26, 23, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected the event to last until midnight UTC")
+	}
+	if c.Contains(time.Date(2024, 12, 24, 23, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected the event to start at midnight UTC")
+	}
+}
+
+func TestParseCalendarErrors(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in:  "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240101\n",
+			err: "unterminated component",
+		},
+		{
+			in:  "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:no start\nEND:VEVENT\nEND:VCALENDAR\n",
+			err: "event 1: missing DTSTART",
+		},
+		{
+			in:  "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2024-01-01\nEND:VEVENT\nEND:VCALENDAR\n",
+			err: `event 1: DTSTART: invalid date-time "2024-01-01"`,
+		},
+		{
+			in:  "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240101T100000\nDURATION:1H\nEND:VEVENT\nEND:VCALENDAR\n",
+			err: `event 1: DURATION: invalid duration "1H"`,
+		},
+		{
+			in:  "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240101\nRRULE:FREQ=HOURLY\nEND:VEVENT\nEND:VCALENDAR\n",
+			err: `event 1: RRULE: unsupported frequency "HOURLY"`,
+		},
+		{
+			in:  "BEGIN:VCALENDAR\nX-WR-TIMEZONE:Mars/Olympus\nEND:VCALENDAR\n",
+			err: "line 2: unknown time zone Mars/Olympus",
+		},
+	} {
+		_, err := ParseCalendar(strings.NewReader(tc.in), nil)
+		if err == nil || err.Error() != tc.err {
+			t.Errorf("expected error %q, got %v", tc.err, err)
+		}
+	}
+}
+
+func TestDateRanges(t *testing.T) {
+	var ti TimeInterval
+	in := `
+dates: ['2024-05-01', '2024-12-24:2024-12-26']
+location: Europe/Berlin
+`
+	if err := yaml.UnmarshalStrict([]byte(in), &ti); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		t   time.Time
+		exp bool
+	}{
+		{t: time.Date(2024, 4, 30, 22, 0, 0, 0, time.UTC), exp: true},
+		{t: time.Date(2024, 5, 1, 22, 0, 0, 0, time.UTC), exp: false},
+		{t: time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), exp: true},
+		{t: time.Date(2024, 12, 26, 22, 59, 0, 0, time.UTC), exp: true},
+		{t: time.Date(2024, 12, 26, 23, 0, 0, 0, time.UTC), exp: false},
+	} {
+		if got := ti.ContainsTime(tc.t); got != tc.exp {
+			t.Errorf("ContainsTime(%s): expected %t, got %t", tc.t, tc.exp, got)
+		}
+	}
+
+	out, err := yaml.Marshal(ti.Dates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "- \"2024-05-01\"\n- 2024-12-24:2024-12-26\n"; string(out) != exp {
+		t.Errorf("expected %q, got %q", exp, out)
+	}
+
+	for _, in := range []string{"2024-13-01", "2024-12-26:2024-12-24", "24.12.2024"} {
+		var r DateRange
+		if err := yaml.Unmarshal([]byte(in), &r); err == nil {
+			t.Errorf("expected an error for %s", in)
+		}
+	}
+}
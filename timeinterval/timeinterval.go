@@ -35,7 +35,32 @@ type TimeInterval struct {
 	DaysOfMonth []DayOfMonthRange `yaml:"days_of_month,flow,omitempty" json:"days_of_month,omitempty"`
 	Months      []MonthRange      `yaml:"months,flow,omitempty" json:"months,omitempty"`
 	Years       []YearRange       `yaml:"years,flow,omitempty" json:"years,omitempty"`
+	Dates       []DateRange       `yaml:"dates,flow,omitempty" json:"dates,omitempty"`
 	Location    *Location         `yaml:"location,flow,omitempty" json:"location,omitempty"`
+	// CalendarFile is the path of an iCalendar file whose events the time
+	// interval is restricted to. It is read by LoadCalendarFile.
+	CalendarFile string `yaml:"calendar_file,omitempty" json:"calendar_file,omitempty"`
+
+	calendar *Calendar
+}
+
+// LoadCalendarFile reads the calendar file of the time interval, if any.
+// The location of the time interval applies to the floating times and
+// all-day events of the calendar.
+func (tp *TimeInterval) LoadCalendarFile() error {
+	if tp.CalendarFile == "" {
+		return nil
+	}
+	var loc *time.Location
+	if tp.Location != nil {
+		loc = tp.Location.Location
+	}
+	c, err := LoadCalendar(tp.CalendarFile, loc)
+	if err != nil {
+		return err
+	}
+	tp.calendar = c
+	return nil
 }
 
 // TimeRange represents a range of minutes within a 1440 minute day, exclusive of the End minute. A day consists of 1440 minutes.
@@ -546,6 +571,22 @@ func (tp TimeInterval) ContainsTime(t time.Time) bool {
 			return false
 		}
 	}
+	if tp.Dates != nil {
+		in := false
+		for _, validDates := range tp.Dates {
+			if validDates.containsDate(t) {
+				in = true
+				break
+			}
+		}
+		if !in {
+			return false
+		}
+	}
+	// A calendar file which was not read contains no events.
+	if tp.CalendarFile != "" && (tp.calendar == nil || !tp.calendar.Contains(t)) {
+		return false
+	}
 	return true
 }
 
@@ -0,0 +1,116 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeinterval
+
+import (
+	"sort"
+	"time"
+)
+
+// A Transition is a time at which a set of time intervals starts or stops
+// containing the time.
+type Transition struct {
+	Time   time.Time
+	Active bool
+}
+
+// Contains returns true if one of the time intervals contains the time.
+func Contains(tis []TimeInterval, t time.Time) bool {
+	for _, ti := range tis {
+		if ti.ContainsTime(t.UTC()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Transitions returns the first n transitions of the time intervals after
+// from, searching forward until to.
+func Transitions(tis []TimeInterval, from, to time.Time, n int) []Transition {
+	// The time intervals can only start or stop containing the time at
+	// their boundaries, so only those need to be evaluated.
+	var candidates []time.Time
+	for _, ti := range tis {
+		candidates = append(candidates, ti.boundaries(from, to)...)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Before(candidates[j])
+	})
+
+	var (
+		res    []Transition
+		active = Contains(tis, from)
+		prev   time.Time
+	)
+	for _, t := range candidates {
+		if len(res) >= n {
+			break
+		}
+		if !t.After(from) || t.After(to) || t.Equal(prev) {
+			continue
+		}
+		prev = t
+		if Contains(tis, t) != active {
+			active = !active
+			res = append(res, Transition{Time: t, Active: active})
+		}
+	}
+	return res
+}
+
+// boundaries returns the times between from and to at which the time
+// interval may start or stop containing the time: the midnights and the
+// bounds of the time ranges in its location, and the bounds of the events
+// of its calendar.
+func (tp TimeInterval) boundaries(from, to time.Time) []time.Time {
+	loc := time.UTC
+	if tp.Location != nil {
+		loc = tp.Location.Location
+	}
+
+	var res []time.Time
+	from, to = from.In(loc), to.In(loc)
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); !day.After(to); day = day.AddDate(0, 0, 1) {
+		res = append(res, day)
+		for _, tr := range tp.Times {
+			res = append(res,
+				time.Date(day.Year(), day.Month(), day.Day(), 0, tr.StartMinute, 0, 0, loc),
+				time.Date(day.Year(), day.Month(), day.Day(), 0, tr.EndMinute, 0, 0, loc),
+			)
+		}
+	}
+	if tp.calendar != nil {
+		res = append(res, tp.calendar.boundaries(from, to)...)
+	}
+	return res
+}
+
+// boundaries returns the starts and ends of the occurrences of the events
+// which overlap the time between from and to.
+func (c *Calendar) boundaries(from, to time.Time) []time.Time {
+	var res []time.Time
+	for _, e := range c.events {
+		occurrences := []time.Time{e.start}
+		if e.rule != nil {
+			occurrences = e.rule.Between(from.Add(-e.maxDuration()), to)
+		}
+		for _, o := range occurrences {
+			if e.excluded(o) {
+				continue
+			}
+			res = append(res, o, e.end(o))
+		}
+	}
+	return res
+}
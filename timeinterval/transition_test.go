@@ -0,0 +1,96 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeinterval
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestTransitions(t *testing.T) {
+	var tis []TimeInterval
+	in := `
+- weekdays: ['monday:friday']
+  times:
+  - start_time: '09:00'
+    end_time: '17:00'
+  location: Europe/Berlin
+- dates: ['2024-03-02']
+`
+	if err := yaml.UnmarshalStrict([]byte(in), &tis); err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+	if !Contains(tis, from) {
+		t.Fatalf("expected %s to be contained", from)
+	}
+
+	exp := []Transition{
+		{Time: time.Date(2024, 3, 1, 16, 0, 0, 0, time.UTC), Active: false},
+		{Time: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), Active: true},
+		{Time: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), Active: false},
+		{Time: time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC), Active: true},
+	}
+	got := Transitions(tis, from, from.AddDate(1, 0, 0), 4)
+	if len(got) != len(exp) {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+	for i := range exp {
+		if !got[i].Time.Equal(exp[i].Time) || got[i].Active != exp[i].Active {
+			t.Errorf("transition %d: expected %v, got %v", i, exp[i], got[i])
+		}
+	}
+
+	// The search stops at the given time.
+	got = Transitions(tis, from, from.Add(time.Hour), 4)
+	if len(got) != 1 {
+		t.Errorf("expected a single transition, got %v", got)
+	}
+	if got := Transitions(nil, from, from.AddDate(1, 0, 0), 4); len(got) != 0 {
+		t.Errorf("expected no transitions, got %v", got)
+	}
+}
+
+func TestTransitionsCalendar(t *testing.T) {
+	c, err := ParseCalendar(strings.NewReader(testCalendar), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tis := []TimeInterval{{CalendarFile: "holidays.ics", calendar: c}}
+
+	from := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	got := Transitions(tis, from, from.AddDate(3, 0, 0), 4)
+	exp := []Transition{
+		{Time: time.Date(2024, 12, 24, 23, 0, 0, 0, time.UTC), Active: true},
+		{Time: time.Date(2024, 12, 26, 23, 0, 0, 0, time.UTC), Active: false},
+		// The occurrence starting on 2025-12-25 is excluded.
+		{Time: time.Date(2026, 12, 24, 23, 0, 0, 0, time.UTC), Active: true},
+		{Time: time.Date(2026, 12, 26, 23, 0, 0, 0, time.UTC), Active: false},
+	}
+	if !reflect.DeepEqual(exp, utcTransitions(got)) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func utcTransitions(ts []Transition) []Transition {
+	res := make([]Transition, 0, len(ts))
+	for _, t := range ts {
+		res = append(res, Transition{Time: t.Time.UTC(), Active: t.Active})
+	}
+	return res
+}
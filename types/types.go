@@ -32,6 +32,7 @@ const (
 	AlertStateUnprocessed AlertState = "unprocessed"
 	AlertStateActive      AlertState = "active"
 	AlertStateSuppressed  AlertState = "suppressed"
+	AlertStateFlapping    AlertState = "flapping"
 )
 
 // AlertStatus stores the state of an alert and, as applicable, the IDs of
@@ -39,15 +40,51 @@ const (
 // that currently, SilencedBy is supposed to be the complete set of the relevant
 // silences while InhibitedBy may contain only a subset of the inhibiting alerts
 // – in practice exactly one ID. (This somewhat confusing semantics might change
-// in the future.)
+// in the future.) SilencedReceivers lists the receivers, or receiver/integration
+// pairs, the alert is silenced for by silences scoped to receivers.
 type AlertStatus struct {
 	State       AlertState `json:"state"`
 	SilencedBy  []string   `json:"silencedBy"`
 	InhibitedBy []string   `json:"inhibitedBy"`
 
+	SilencedReceivers []string `json:"silencedReceivers,omitempty"`
+
+	// Inhibition explains why the alert is inhibited, if it is.
+	Inhibition *Inhibition `json:"-"`
+
 	// For internal tracking, not exposed in the API.
 	pendingSilences []string
+	scopedSilences  []string
 	silencesVersion int64
+	flapping        bool
+}
+
+// Inhibition explains why an alert is inhibited.
+type Inhibition struct {
+	// RuleIndex is the index of the inhibition rule in the configuration. It
+	// is -1 for inhibitions by the service dependencies.
+	RuleIndex int
+	// Equal holds the values of the equal labels of the rule, which are the
+	// same in the source and the target alert.
+	Equal model.LabelSet
+	// Source is the alert inhibiting the target alert.
+	Source *Alert
+	// DependencyPath is the chain of services from the service of the
+	// target alert to the service of the source alert it depends on.
+	DependencyPath []string
+}
+
+// updateState sets the state according to the silences, inhibitions and
+// flapping of the alert. Suppression takes precedence over flapping.
+func (s *AlertStatus) updateState() {
+	switch {
+	case len(s.SilencedBy) > 0 || len(s.InhibitedBy) > 0:
+		s.State = AlertStateSuppressed
+	case s.flapping:
+		s.State = AlertStateFlapping
+	default:
+		s.State = AlertStateActive
+	}
 }
 
 // Marker helps to mark alerts as silenced and/or inhibited.
@@ -69,6 +106,18 @@ type Marker interface {
 	// AlertStateActive. Otherwise, it sets the provided alert to
 	// AlertStateSuppressed.
 	SetInhibited(alert model.Fingerprint, alertIDs ...string)
+	// SetInhibition replaces the previous InhibitedBy by the fingerprint of
+	// the source alert of the inhibition and records the inhibition. It sets
+	// the provided alert to AlertStateSuppressed.
+	SetInhibition(alert model.Fingerprint, inhibition *Inhibition)
+	// SetFlapping marks the alert as flapping or not. A flapping alert that
+	// is neither silenced nor inhibited is set to AlertStateFlapping.
+	SetFlapping(alert model.Fingerprint, flapping bool)
+	// SetSilencedReceivers replaces the IDs of the active silences scoped to
+	// receivers and the receivers, or receiver/integration pairs, they
+	// silence the alert for. As the alert is still sent to other receivers,
+	// this does not change its state.
+	SetSilencedReceivers(alert model.Fingerprint, silenceIDs, receivers []string)
 
 	// Count alerts of the given state(s). With no state provided, count all
 	// alerts.
@@ -88,12 +137,19 @@ type Marker interface {
 	Active(model.Fingerprint) bool
 	Silenced(model.Fingerprint) (activeIDs, pendingIDs []string, version int64, silenced bool)
 	Inhibited(model.Fingerprint) ([]string, bool)
+	Flapping(model.Fingerprint) bool
+	SilencedReceivers(model.Fingerprint) (silenceIDs, receivers []string)
 }
 
+// markerShards is the number of partitions of the alert statuses held by a
+// memMarker. It must be a power of two.
+const markerShards = 64
+
 // NewMarker returns an instance of a Marker implementation.
 func NewMarker(r prometheus.Registerer) Marker {
-	m := &memMarker{
-		m: map[model.Fingerprint]*AlertStatus{},
+	m := &memMarker{}
+	for i := range m.shards {
+		m.shards[i].m = map[model.Fingerprint]*AlertStatus{}
 	}
 
 	m.registerMetrics(r)
@@ -101,12 +157,34 @@ func NewMarker(r prometheus.Registerer) Marker {
 	return m
 }
 
+// memMarker holds the alert statuses partitioned by fingerprint, so that
+// updates of different alerts rarely contend for the same lock.
 type memMarker struct {
+	shards [markerShards]markerShard
+}
+
+type markerShard struct {
 	m map[model.Fingerprint]*AlertStatus
 
 	mtx sync.RWMutex
 }
 
+// shard returns the partition holding the status of the given alert.
+func (m *memMarker) shard(alert model.Fingerprint) *markerShard {
+	return &m.shards[uint64(alert)&(markerShards-1)]
+}
+
+// status returns the status of the given alert, creating it if needed. The
+// caller must hold the lock of the shard.
+func (sh *markerShard) status(alert model.Fingerprint) *AlertStatus {
+	s, found := sh.m[alert]
+	if !found {
+		s = &AlertStatus{}
+		sh.m[alert] = s
+	}
+	return s
+}
+
 func (m *memMarker) registerMetrics(r prometheus.Registerer) {
 	newMarkedAlertMetricByState := func(st AlertState) prometheus.GaugeFunc {
 		return prometheus.NewGaugeFunc(
@@ -124,86 +202,116 @@ func (m *memMarker) registerMetrics(r prometheus.Registerer) {
 	alertsActive := newMarkedAlertMetricByState(AlertStateActive)
 	alertsSuppressed := newMarkedAlertMetricByState(AlertStateSuppressed)
 	alertStateUnprocessed := newMarkedAlertMetricByState(AlertStateUnprocessed)
+	alertsFlapping := newMarkedAlertMetricByState(AlertStateFlapping)
 
 	r.MustRegister(alertsActive)
 	r.MustRegister(alertsSuppressed)
 	r.MustRegister(alertStateUnprocessed)
+	r.MustRegister(alertsFlapping)
 }
 
 // Count implements Marker.
 func (m *memMarker) Count(states ...AlertState) int {
-	m.mtx.RLock()
-	defer m.mtx.RUnlock()
-
-	if len(states) == 0 {
-		return len(m.m)
-	}
-
 	var count int
-	for _, status := range m.m {
-		for _, state := range states {
-			if status.State == state {
-				count++
+	for i := range m.shards {
+		sh := &m.shards[i]
+		sh.mtx.RLock()
+		if len(states) == 0 {
+			count += len(sh.m)
+		} else {
+			for _, status := range sh.m {
+				for _, state := range states {
+					if status.State == state {
+						count++
+					}
+				}
 			}
 		}
+		sh.mtx.RUnlock()
 	}
 	return count
 }
 
 // SetActiveOrSilenced implements Marker.
 func (m *memMarker) SetActiveOrSilenced(alert model.Fingerprint, version int64, activeIDs, pendingIDs []string) {
-	m.mtx.Lock()
-	defer m.mtx.Unlock()
+	sh := m.shard(alert)
+	sh.mtx.Lock()
+	defer sh.mtx.Unlock()
 
-	s, found := m.m[alert]
-	if !found {
-		s = &AlertStatus{}
-		m.m[alert] = s
-	}
+	s := sh.status(alert)
 	s.SilencedBy = activeIDs
 	s.pendingSilences = pendingIDs
 	s.silencesVersion = version
 
-	// If there are any silence or alert IDs associated with the
-	// fingerprint, it is suppressed. Otherwise, set it to
-	// AlertStateActive.
-	if len(activeIDs) == 0 && len(s.InhibitedBy) == 0 {
-		s.State = AlertStateActive
-		return
-	}
-
-	s.State = AlertStateSuppressed
+	s.updateState()
 }
 
 // SetInhibited implements Marker.
 func (m *memMarker) SetInhibited(alert model.Fingerprint, ids ...string) {
-	m.mtx.Lock()
-	defer m.mtx.Unlock()
+	sh := m.shard(alert)
+	sh.mtx.Lock()
+	defer sh.mtx.Unlock()
 
-	s, found := m.m[alert]
-	if !found {
-		s = &AlertStatus{}
-		m.m[alert] = s
-	}
+	s := sh.status(alert)
 	s.InhibitedBy = ids
+	s.Inhibition = nil
 
-	// If there are any silence or alert IDs associated with the
-	// fingerprint, it is suppressed. Otherwise, set it to
-	// AlertStateActive.
-	if len(ids) == 0 && len(s.SilencedBy) == 0 {
-		s.State = AlertStateActive
+	s.updateState()
+}
+
+// SetInhibition implements Marker.
+func (m *memMarker) SetInhibition(alert model.Fingerprint, inhibition *Inhibition) {
+	sh := m.shard(alert)
+	sh.mtx.Lock()
+	defer sh.mtx.Unlock()
+
+	s := sh.status(alert)
+	s.InhibitedBy = []string{inhibition.Source.Fingerprint().String()}
+	s.Inhibition = inhibition
+
+	s.updateState()
+}
+
+// SetFlapping implements Marker.
+func (m *memMarker) SetFlapping(alert model.Fingerprint, flapping bool) {
+	sh := m.shard(alert)
+	sh.mtx.Lock()
+	defer sh.mtx.Unlock()
+
+	if _, found := sh.m[alert]; !found && !flapping {
 		return
 	}
+	s := sh.status(alert)
+	s.flapping = flapping
+
+	s.updateState()
+}
 
-	s.State = AlertStateSuppressed
+// SetSilencedReceivers implements Marker.
+func (m *memMarker) SetSilencedReceivers(alert model.Fingerprint, silenceIDs, receivers []string) {
+	sh := m.shard(alert)
+	sh.mtx.Lock()
+	defer sh.mtx.Unlock()
+
+	s, found := sh.m[alert]
+	if !found {
+		if len(silenceIDs) == 0 {
+			return
+		}
+		s = sh.status(alert)
+		s.updateState()
+	}
+	s.scopedSilences = silenceIDs
+	s.SilencedReceivers = receivers
 }
 
 // Status implements Marker.
 func (m *memMarker) Status(alert model.Fingerprint) AlertStatus {
-	m.mtx.RLock()
-	defer m.mtx.RUnlock()
+	sh := m.shard(alert)
+	sh.mtx.RLock()
+	defer sh.mtx.RUnlock()
 
-	if s, found := m.m[alert]; found {
+	if s, found := sh.m[alert]; found {
 		return *s
 	}
 	return AlertStatus{
@@ -215,10 +323,11 @@ func (m *memMarker) Status(alert model.Fingerprint) AlertStatus {
 
 // Delete implements Marker.
 func (m *memMarker) Delete(alert model.Fingerprint) {
-	m.mtx.Lock()
-	defer m.mtx.Unlock()
+	sh := m.shard(alert)
+	sh.mtx.Lock()
+	defer sh.mtx.Unlock()
 
-	delete(m.m, alert)
+	delete(sh.m, alert)
 }
 
 // Unprocessed implements Marker.
@@ -238,6 +347,11 @@ func (m *memMarker) Inhibited(alert model.Fingerprint) ([]string, bool) {
 		s.State == AlertStateSuppressed && len(s.InhibitedBy) > 0
 }
 
+// Flapping implements Marker.
+func (m *memMarker) Flapping(alert model.Fingerprint) bool {
+	return m.Status(alert).flapping
+}
+
 // Silenced returns whether the alert for the given Fingerprint is in the
 // Silenced state, any associated silence IDs, and the silences state version
 // the result is based on.
@@ -247,6 +361,12 @@ func (m *memMarker) Silenced(alert model.Fingerprint) (activeIDs, pendingIDs []s
 		s.State == AlertStateSuppressed && len(s.SilencedBy) > 0
 }
 
+// SilencedReceivers implements Marker.
+func (m *memMarker) SilencedReceivers(alert model.Fingerprint) (silenceIDs, receivers []string) {
+	s := m.Status(alert)
+	return s.scopedSilences, s.SilencedReceivers
+}
+
 // MultiError contains multiple errors and implements the error interface. Its
 // zero value is ready to use. All its methods are goroutine safe.
 type MultiError struct {
@@ -300,6 +420,9 @@ type Alert struct {
 	// The authoritative timestamp.
 	UpdatedAt time.Time
 	Timeout   bool
+	// Flapping is set during notification for alerts which have stopped
+	// flapping recently.
+	Flapping bool
 }
 
 // AlertSlice is a sortable slice of Alerts.
@@ -439,6 +562,9 @@ const (
 	SilenceStateExpired SilenceState = "expired"
 	SilenceStateActive  SilenceState = "active"
 	SilenceStatePending SilenceState = "pending"
+	// SilenceStatePendingApproval is the state of a silence which violates
+	// the silence policy and has not been approved yet.
+	SilenceStatePendingApproval SilenceState = "pendingApproval"
 )
 
 // CalcSilenceState returns the SilenceState that a silence with the given start
@@ -0,0 +1,104 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+)
+
+const benchmarkAlerts = 50000
+
+func benchmarkFingerprints() []model.Fingerprint {
+	fps := make([]model.Fingerprint, 0, benchmarkAlerts)
+	for i := 0; i < benchmarkAlerts; i++ {
+		fps = append(fps, model.LabelSet{"instance": model.LabelValue(strconv.Itoa(i))}.Fingerprint())
+	}
+	return fps
+}
+
+// BenchmarkMarkerConcurrent measures marking alerts from many goroutines,
+// as done by the notification pipelines of concurrent aggregation groups,
+// while the statuses are read by API queries.
+func BenchmarkMarkerConcurrent(b *testing.B) {
+	fps := benchmarkFingerprints()
+	m := NewMarker(prometheus.NewRegistry())
+	for _, fp := range fps {
+		m.SetActiveOrSilenced(fp, 0, nil, nil)
+	}
+
+	b.Run("set", func(b *testing.B) {
+		var n uint64
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				i := atomic.AddUint64(&n, 1)
+				m.SetInhibited(fps[i%benchmarkAlerts])
+			}
+		})
+	})
+
+	b.Run("set and status", func(b *testing.B) {
+		var n uint64
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				i := atomic.AddUint64(&n, 1)
+				if i%2 == 0 {
+					m.SetInhibited(fps[i%benchmarkAlerts])
+				} else {
+					m.Status(fps[i%benchmarkAlerts])
+				}
+			}
+		})
+	})
+}
+
+// BenchmarkMarkerCount measures counting the alerts by state, as done when
+// the metrics are scraped, while alerts are marked concurrently.
+func BenchmarkMarkerCount(b *testing.B) {
+	fps := benchmarkFingerprints()
+	m := NewMarker(prometheus.NewRegistry())
+	for _, fp := range fps {
+		m.SetActiveOrSilenced(fp, 0, nil, nil)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			m.SetInhibited(fps[i%benchmarkAlerts])
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Count(AlertStateActive)
+	}
+	b.StopTimer()
+
+	close(stop)
+	<-done
+}
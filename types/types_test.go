@@ -77,6 +77,30 @@ func TestMemMarker_Count(t *testing.T) {
 	require.Equal(t, 3, countTotal())
 }
 
+func TestMemMarker_Flapping(t *testing.T) {
+	marker := NewMarker(prometheus.NewRegistry())
+	fp := model.LabelSet{"test": "flapping"}.Fingerprint()
+
+	// Unflapping unknown alerts are not tracked.
+	marker.SetFlapping(fp, false)
+	require.Equal(t, AlertStateUnprocessed, marker.Status(fp).State)
+
+	marker.SetFlapping(fp, true)
+	require.True(t, marker.Flapping(fp))
+	require.Equal(t, AlertStateFlapping, marker.Status(fp).State)
+	require.Equal(t, 1, marker.Count(AlertStateFlapping))
+
+	// Inhibitions take precedence over flapping.
+	marker.SetInhibited(fp, "1")
+	require.Equal(t, AlertStateSuppressed, marker.Status(fp).State)
+	marker.SetInhibited(fp)
+	require.Equal(t, AlertStateFlapping, marker.Status(fp).State)
+
+	marker.SetFlapping(fp, false)
+	require.False(t, marker.Flapping(fp))
+	require.Equal(t, AlertStateActive, marker.Status(fp).State)
+}
+
 func TestAlertMerge(t *testing.T) {
 	now := time.Now()
 